@@ -0,0 +1,197 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+)
+
+// ErrAccessDenied is returned (wrapped with the operation and key) when an
+// ACLAdapter rule rejects an operation.
+var ErrAccessDenied = errors.New("access denied")
+
+// Rule effects
+const (
+	Allow = "allow"
+	Deny  = "deny"
+)
+
+// ACLRule allows or denies operations on the keys matching Pattern. Patterns
+// use path.Match syntax, where "*" does not match "/"; a pattern ending in
+// "/**" matches everything below that path and "**" matches every key.
+// An empty Operations list applies the rule to every operation.
+type ACLRule struct {
+	Effect     string
+	Pattern    string
+	Operations []string
+}
+
+func (r ACLRule) matches(op string, key string) bool {
+	if len(r.Operations) > 0 {
+		found := false
+		for _, o := range r.Operations {
+			if o == op {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return matchKey(r.Pattern, key)
+}
+
+func matchKey(pattern string, key string) bool {
+	if pattern == "**" {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+		return key == prefix || strings.HasPrefix(key, prefix+"/")
+	}
+	matched, err := path.Match(pattern, key)
+	return err == nil && matched
+}
+
+// ACL is an ordered list of ACLRules. The first matching rule decides;
+// operations matching no rule are denied.
+type ACL struct {
+	mu    sync.RWMutex
+	rules []ACLRule
+}
+
+// Create a new ACL with the given rules.
+func NewACL(rules ...ACLRule) (*ACL, error) {
+	acl := &ACL{}
+	for _, rule := range rules {
+		err := acl.AddRule(rule)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return acl, nil
+}
+
+// Add a rule after the existing ones.
+func (acl *ACL) AddRule(rule ACLRule) error {
+	if rule.Effect != Allow && rule.Effect != Deny {
+		return fmt.Errorf("Invalid ACL rule effect %q", rule.Effect)
+	}
+	if _, err := path.Match(rule.Pattern, ""); err != nil {
+		return fmt.Errorf("Invalid ACL rule pattern %q: %v", rule.Pattern, err)
+	}
+	acl.mu.Lock()
+	defer acl.mu.Unlock()
+	acl.rules = append(acl.rules, rule)
+	return nil
+}
+
+// Check whether op is allowed on key. Keys rejected by CheckKey() are
+// never allowed, since the backend may resolve them outside the pattern
+// they match.
+func (acl *ACL) Check(op string, key string) error {
+	err := CheckKey(key)
+	if err != nil {
+		return err
+	}
+	acl.mu.RLock()
+	defer acl.mu.RUnlock()
+	for _, rule := range acl.rules {
+		if rule.matches(op, key) {
+			if rule.Effect == Allow {
+				return nil
+			}
+			break
+		}
+	}
+	return fmt.Errorf("%w: %s %s", ErrAccessDenied, op, key)
+}
+
+// ACLAdapter checks every operation against an ACL before it reaches the
+// wrapped store, as a guardrail for backends without a policy engine of
+// their own. LookupKeys() is checked against the key path listed.
+type ACLAdapter struct {
+	Inner SecureStorage
+	*ACL
+}
+
+// Create a new ACLAdapter wrapping inner with the given rules.
+func NewACLAdapter(inner SecureStorage, rules ...ACLRule) (*ACLAdapter, error) {
+	acl, err := NewACL(rules...)
+	if err != nil {
+		return nil, err
+	}
+	return &ACLAdapter{Inner: inner, ACL: acl}, nil
+}
+
+func (aa *ACLAdapter) Store(key string, value interface{}) error {
+	err := aa.Check(OpStore, key)
+	if err != nil {
+		return err
+	}
+	return aa.Inner.Store(key, value)
+}
+
+func (aa *ACLAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	err := aa.Check(OpStoreWithData, key)
+	if err != nil {
+		return err
+	}
+	return aa.Inner.StoreWithData(key, value, output)
+}
+
+func (aa *ACLAdapter) Lookup(key string, output interface{}) error {
+	err := aa.Check(OpLookup, key)
+	if err != nil {
+		return err
+	}
+	return aa.Inner.Lookup(key, output)
+}
+
+func (aa *ACLAdapter) Delete(key string) error {
+	err := aa.Check(OpDelete, key)
+	if err != nil {
+		return err
+	}
+	return aa.Inner.Delete(key)
+}
+
+func (aa *ACLAdapter) LookupKeys(keyPath string) ([]string, error) {
+	err := aa.Check(OpLookupKeys, keyPath)
+	if err != nil {
+		return nil, err
+	}
+	return aa.Inner.LookupKeys(keyPath)
+}
+
+func (aa *ACLAdapter) Health() error {
+	return Health(aa.Inner)
+}
+
+func (aa *ACLAdapter) Close() error {
+	return Close(aa.Inner)
+}
@@ -0,0 +1,95 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestACLAdapter(t *testing.T) {
+	aa, err := NewACLAdapter(newMemStore(),
+		ACLRule{Effect: Deny, Pattern: "hms-creds/x0c0s9b0"},
+		ACLRule{Effect: Allow, Pattern: "hms-creds/*", Operations: []string{OpLookup, OpStore}},
+		ACLRule{Effect: Allow, Pattern: "hms-creds", Operations: []string{OpLookupKeys}},
+		ACLRule{Effect: Allow, Pattern: "scratch/**"},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+
+	var tests = []struct {
+		op      string
+		key     string
+		allowed bool
+		invalid bool
+	}{
+		{op: OpStore, key: "hms-creds/x0c0s1b0", allowed: true},
+		{op: OpLookup, key: "hms-creds/x0c0s1b0", allowed: true},
+		{op: OpDelete, key: "hms-creds/x0c0s1b0", allowed: false},
+		{op: OpLookup, key: "hms-creds/x0c0s9b0", allowed: false},
+		{op: OpLookup, key: "hms-creds/x0c0/s1b0", allowed: false},
+		{op: OpLookupKeys, key: "hms-creds", allowed: true},
+		{op: OpDelete, key: "scratch/a/b/c", allowed: true},
+		{op: OpLookupKeys, key: "scratch", allowed: true},
+		{op: OpLookup, key: "other", allowed: false},
+		{op: OpLookupKeys, key: "scratch/", allowed: true},
+		{op: OpLookup, key: "scratch/../hms-creds/x0c0s9b0", invalid: true},
+		{op: OpStore, key: "scratch/a/../../x", invalid: true},
+		{op: OpDelete, key: "scratch/./a", invalid: true},
+		{op: OpLookup, key: "scratch//a", invalid: true},
+		{op: OpLookup, key: "/scratch/a", invalid: true},
+		{op: OpLookupKeys, key: "scratch/..", invalid: true},
+	}
+
+	for i, test := range tests {
+		var err error
+		switch test.op {
+		case OpStore:
+			err = aa.Store(test.key, creds{Password: "pw"})
+		case OpLookup:
+			var r creds
+			err = aa.Lookup(test.key, &r)
+		case OpDelete:
+			err = aa.Delete(test.key)
+		case OpLookupKeys:
+			_, err = aa.LookupKeys(test.key)
+		}
+		if test.invalid {
+			if !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("Test %v Failed: Expected ErrInvalidKey but got %v", i, err)
+			}
+		} else if test.allowed && err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+		} else if !test.allowed && !errors.Is(err, ErrAccessDenied) {
+			t.Errorf("Test %v Failed: Expected ErrAccessDenied but got %v", i, err)
+		}
+	}
+
+	if err := aa.AddRule(ACLRule{Effect: "maybe", Pattern: "*"}); err == nil {
+		t.Errorf("Expected an error for an invalid effect")
+	}
+	if err := aa.AddRule(ACLRule{Effect: Allow, Pattern: "["}); err == nil {
+		t.Errorf("Expected an error for an invalid pattern")
+	}
+}
@@ -0,0 +1,186 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+// Package agent runs a local process that sits between services and Vault.
+// It authenticates to Vault once, caches lookups, writes through to Vault
+// while it is available and queues writes while it is not, and serves the
+// store over a Unix socket, so services on the node use
+// securestorage.NewUnixRemoteAdapter() instead of holding their own Vault
+// credentials.
+package agent
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	securestorage "github.com/Cray-HPE/hms-securestorage"
+	"github.com/Cray-HPE/hms-securestorage/httpserver"
+)
+
+// Config controls an Agent. Zero values are replaced by the defaults below.
+type Config struct {
+	SocketPath string
+	SocketMode os.FileMode
+	// How long a looked up value is served from memory.
+	CacheTTL time.Duration
+	// Maximum number of cached values; <= 0 does not bound the cache.
+	CacheSize int
+	// Optional. How long a lookup of a missing key is served from memory.
+	NegativeCacheTTL time.Duration
+	// Maximum number of keys with writes queued for Vault.
+	MaxPending int
+	// A queued write is retried MaxRetries times, RetryDelay apart, before
+	// it is dropped and reported to OnError.
+	MaxRetries int
+	RetryDelay time.Duration
+	// Optional. Restricts which local users may use which keys.
+	Policies []httpserver.PeerPolicy
+	// Optional. Called when a queued write is dropped.
+	OnError func(key string, err error)
+}
+
+const (
+	DefaultSocketPath = "/run/securestorage/agent.sock"
+	DefaultSocketMode = 0660
+	DefaultCacheTTL   = 5 * time.Minute
+	DefaultMaxPending = 1000
+	DefaultMaxRetries = 60
+	DefaultRetryDelay = 5 * time.Second
+)
+
+// Agent serves a SecureStorage on a Unix socket.
+type Agent struct {
+	Config
+	Cache  *securestorage.CacheAdapter
+	Writes *securestorage.WriteBehindAdapter
+
+	mu     sync.Mutex
+	server *http.Server
+	closed bool
+}
+
+// Create a new Agent in front of store, which is normally an authenticated
+// Vault adapter. Closing the Agent closes store.
+func New(store securestorage.SecureStorage, cfg Config) *Agent {
+	if cfg.SocketPath == "" {
+		cfg.SocketPath = DefaultSocketPath
+	}
+	if cfg.SocketMode == 0 {
+		cfg.SocketMode = DefaultSocketMode
+	}
+	if cfg.CacheTTL == 0 {
+		cfg.CacheTTL = DefaultCacheTTL
+	}
+	if cfg.MaxPending <= 0 {
+		cfg.MaxPending = DefaultMaxPending
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = DefaultMaxRetries
+	}
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = DefaultRetryDelay
+	}
+
+	a := &Agent{Config: cfg}
+	a.Writes = securestorage.NewWriteBehindAdapter(store, cfg.MaxPending, cfg.MaxRetries, cfg.RetryDelay)
+	a.Writes.OnError = cfg.OnError
+	a.Writes.Fallback = securestorage.DefaultIsRetryable
+	a.Cache = securestorage.NewCacheAdapter(a.Writes, cfg.CacheTTL, cfg.CacheSize)
+	a.Cache.NegativeTTL = cfg.NegativeCacheTTL
+	return a
+}
+
+// Build the HTTP handler for the agent's socket.
+func (a *Agent) Handler() http.Handler {
+	var middleware []httpserver.Middleware
+
+	if len(a.Policies) > 0 {
+		middleware = append(middleware, httpserver.PeerCredAuth(a.Policies...))
+	}
+	return httpserver.NewServer(a.Cache, middleware...)
+}
+
+// Listen on the socket and serve requests until Close() is called. If the
+// agent was started by systemd socket activation the inherited socket is
+// used instead of SocketPath.
+func (a *Agent) Serve() error {
+	l, err := a.listen()
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		l.Close()
+		return http.ErrServerClosed
+	}
+	a.server = &http.Server{
+		Handler:     a.Handler(),
+		ConnContext: httpserver.ConnContext,
+	}
+	server := a.server
+	a.mu.Unlock()
+
+	httpserver.Notify("READY=1")
+	err = server.Serve(l)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+func (a *Agent) listen() (net.Listener, error) {
+	listeners, err := httpserver.Listeners()
+	if err != nil {
+		return nil, err
+	}
+	if len(listeners) > 0 {
+		for _, l := range listeners[1:] {
+			l.Close()
+		}
+		return listeners[0], nil
+	}
+	return httpserver.ListenUnix(a.SocketPath, a.SocketMode)
+}
+
+// Stop serving, apply any queued writes and close the store.
+func (a *Agent) Close() error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil
+	}
+	a.closed = true
+	server := a.server
+	a.mu.Unlock()
+
+	httpserver.Notify("STOPPING=1")
+	if server != nil {
+		server.Close()
+	}
+	return a.Cache.Close()
+}
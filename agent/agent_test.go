@@ -0,0 +1,219 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package agent
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	securestorage "github.com/Cray-HPE/hms-securestorage"
+	"github.com/mitchellh/mapstructure"
+)
+
+type creds struct {
+	Username string
+	Password string
+}
+
+// memStore is a minimal in-memory SecureStorage that can be taken down to
+// simulate a Vault outage, or made to deny writes.
+type memStore struct {
+	mu      sync.Mutex
+	data    map[string]map[string]interface{}
+	down    bool
+	denied  bool
+	lookups int
+}
+
+// Errors in the form returned by the Vault client.
+var (
+	errUnavailable = fmt.Errorf("Error making API request. Code: 503. Errors: Vault is sealed")
+	errDenied      = fmt.Errorf("Error making API request. Code: 403. Errors: permission denied")
+)
+
+func newMemStore() *memStore {
+	return &memStore{data: map[string]map[string]interface{}{}}
+}
+
+func (ms *memStore) setDown(down bool) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.down = down
+}
+
+func (ms *memStore) Store(key string, value interface{}) error {
+	var data map[string]interface{}
+	if err := mapstructure.Decode(value, &data); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if ms.down {
+		return errUnavailable
+	}
+	if ms.denied {
+		return errDenied
+	}
+	ms.data[key] = data
+	return nil
+}
+
+func (ms *memStore) StoreWithData(key string, value interface{}, output interface{}) error {
+	return ms.Store(key, value)
+}
+
+func (ms *memStore) Lookup(key string, output interface{}) error {
+	ms.mu.Lock()
+	ms.lookups++
+	data, ok := ms.data[key]
+	down := ms.down
+	ms.mu.Unlock()
+	if down {
+		return errUnavailable
+	}
+	if !ok {
+		return nil
+	}
+	return mapstructure.Decode(data, output)
+}
+
+func (ms *memStore) Delete(key string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if ms.down {
+		return errUnavailable
+	}
+	if ms.denied {
+		return errDenied
+	}
+	delete(ms.data, key)
+	return nil
+}
+
+func (ms *memStore) LookupKeys(keyPath string) ([]string, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	var keys []string
+	for k := range ms.data {
+		if strings.HasPrefix(k, keyPath+"/") {
+			keys = append(keys, strings.TrimPrefix(k, keyPath+"/"))
+		}
+	}
+	return keys, nil
+}
+
+func startAgent(t *testing.T, store *memStore) (*Agent, *securestorage.RemoteAdapter) {
+	a := New(store, Config{
+		SocketPath: filepath.Join(t.TempDir(), "agent.sock"),
+		MaxRetries: 100,
+		RetryDelay: 10 * time.Millisecond,
+	})
+	go a.Serve()
+	client := securestorage.NewUnixRemoteAdapter(a.SocketPath)
+	for i := 0; i < 100; i++ {
+		if _, err := client.LookupKeys("x"); err == nil {
+			return a, client
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Agent did not start")
+	return nil, nil
+}
+
+func TestAgent(t *testing.T) {
+	store := newMemStore()
+	a, client := startAgent(t, store)
+	defer a.Close()
+
+	err := client.Store("creds/x0c0s0b0", creds{Username: "root", Password: "secret"})
+	if err != nil {
+		t.Fatalf("Test Failed: Unexpected error on Store: %v", err)
+	}
+	store.mu.Lock()
+	written := store.data["creds/x0c0s0b0"]["Password"]
+	store.mu.Unlock()
+	if written != "secret" || a.Writes.Pending() != 0 {
+		t.Errorf("Test Failed: Expected the write to reach the store before Store returned but got %v", written)
+	}
+
+	var got creds
+	for i := 0; i < 3; i++ {
+		err = client.Lookup("creds/x0c0s0b0", &got)
+		if err != nil || got.Password != "secret" {
+			t.Errorf("Test %v Failed: Expected secret but got %v, %v", i, got, err)
+		}
+	}
+	if store.lookups != 1 {
+		t.Errorf("Test Failed: Expected 1 backend lookup but got %d", store.lookups)
+	}
+
+	keys, err := client.LookupKeys("creds")
+	if err != nil || len(keys) != 1 || keys[0] != "x0c0s0b0" {
+		t.Errorf("Test Failed: Unexpected keys %v, %v", keys, err)
+	}
+
+	var missing creds
+	err = client.Lookup("creds/none", &missing)
+	if err != nil || missing != (creds{}) {
+		t.Errorf("Test Failed: Expected missing key to be left empty but got %v, %v", missing, err)
+	}
+}
+
+func TestAgentOutage(t *testing.T) {
+	store := newMemStore()
+	a, client := startAgent(t, store)
+
+	store.setDown(true)
+	err := client.Store("creds/x0c0s0b0", creds{Username: "root", Password: "queued"})
+	if err != nil {
+		t.Fatalf("Test Failed: Expected write to be queued during outage but got %v", err)
+	}
+	var got creds
+	err = client.Lookup("creds/x0c0s0b0", &got)
+	if err != nil || got.Password != "queued" {
+		t.Errorf("Test Failed: Expected queued value but got %v, %v", got, err)
+	}
+
+	store.setDown(false)
+	a.Writes.Flush()
+	store.mu.Lock()
+	store.denied = true
+	store.mu.Unlock()
+	err = client.Store("creds/x0c0s0b0", creds{Username: "root", Password: "denied"})
+	if err == nil || a.Writes.Pending() != 0 {
+		t.Errorf("Test Failed: Expected a denied write to fail without being queued but got %v", err)
+	}
+	store.mu.Lock()
+	store.denied = false
+	store.mu.Unlock()
+	a.Close()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if store.data["creds/x0c0s0b0"]["Password"] != "queued" {
+		t.Errorf("Test Failed: Expected queued write to be applied but got %v", store.data)
+	}
+}
@@ -0,0 +1,371 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrApprovalRequired is returned (wrapped in a *PendingApprovalError) by
+// writes that were recorded for approval instead of being applied.
+var ErrApprovalRequired = errors.New("approval required")
+
+// DefaultPendingPath is where an ApprovalAdapter keeps pending changes in
+// the wrapped store.
+const DefaultPendingPath = ".pending"
+
+const pendingChangePurpose = "securestorage pending change"
+
+// PendingChange is a write waiting for approval.
+type PendingChange struct {
+	ID        string                 `json:"id"`
+	Operation string                 `json:"operation"`
+	Key       string                 `json:"key"`
+	Value     map[string]interface{} `json:"value,omitempty"`
+	Requester string                 `json:"requester"`
+	Requested string                 `json:"requested"`
+	Approvers []string               `json:"approvers,omitempty"`
+}
+
+// pendingRecord is how a PendingChange is kept in the wrapped store: the
+// change as JSON and a MAC of it, keyed by the master key.
+type pendingRecord struct {
+	Change string `mapstructure:"change"`
+	MAC    string `mapstructure:"mac"`
+}
+
+// PendingApprovalError reports the change recorded for a write.
+type PendingApprovalError struct {
+	ID  string
+	Key string
+}
+
+func (e *PendingApprovalError) Error() string {
+	return fmt.Sprintf("Change to %s is pending approval as %s", e.Key, e.ID)
+}
+
+func (e *PendingApprovalError) Is(target error) bool {
+	return target == ErrApprovalRequired
+}
+
+// ApprovalAdapter enforces four-eyes control over keys below Prefixes.
+// Store() and Delete() of those keys are not applied; they are recorded as
+// PendingChanges and applied once Approvals other identities have called
+// Approve(). Identity is who is making changes through this adapter; use
+// WithIdentity() to act for someone else. Pending changes are kept in the
+// wrapped store below PendingPath, so every process sharing the store sees
+// them. Each is authenticated with a MAC keyed by the master key from Keys,
+// so a record written to the store directly, or changed there, cannot be
+// approved; every process sharing pending changes needs the same key.
+// Writes through the adapter below PendingPath are denied.
+type ApprovalAdapter struct {
+	Inner       SecureStorage
+	Keys        KeyProvider
+	Prefixes    []string
+	Identity    string
+	Approvals   int
+	PendingPath string
+
+	mu  *sync.Mutex
+	now func() time.Time
+}
+
+// Create a new ApprovalAdapter acting as identity and requiring one
+// approval for changes below prefixes. Pending changes are authenticated
+// with the master key from keys.
+func NewApprovalAdapter(inner SecureStorage, keys KeyProvider, identity string, prefixes ...string) *ApprovalAdapter {
+	return &ApprovalAdapter{
+		Inner:       inner,
+		Keys:        keys,
+		Prefixes:    prefixes,
+		Identity:    identity,
+		Approvals:   1,
+		PendingPath: DefaultPendingPath,
+		mu:          &sync.Mutex{},
+		now:         time.Now,
+	}
+}
+
+// Get a copy of the adapter acting as identity. The copies share state.
+func (aa *ApprovalAdapter) WithIdentity(identity string) *ApprovalAdapter {
+	other := *aa
+	other.Identity = identity
+	return &other
+}
+
+func (aa *ApprovalAdapter) requiresApproval(key string) bool {
+	for _, prefix := range aa.Prefixes {
+		if key == prefix || underPath(prefix, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (aa *ApprovalAdapter) pendingKey(id string) string {
+	return joinKey(aa.PendingPath, id)
+}
+
+// Check a key written through the adapter. Keys that the backend would
+// resolve elsewhere could escape Prefixes, and pending changes may only be
+// written by the adapter itself.
+func (aa *ApprovalAdapter) checkWrite(op string, key string) error {
+	err := CheckKey(key)
+	if err != nil {
+		return err
+	}
+	if key == aa.PendingPath || underPath(aa.PendingPath, key) {
+		return fmt.Errorf("%w: %s %s", ErrAccessDenied, op, key)
+	}
+	return nil
+}
+
+// Compute the MAC of a pending change encoded as JSON.
+func (aa *ApprovalAdapter) mac(data []byte) ([]byte, error) {
+	masterKey, err := aa.Keys.MasterKey()
+	if err != nil {
+		return nil, err
+	}
+	err = checkMasterKey(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := currentCrypto()
+	if err != nil {
+		return nil, err
+	}
+	key, err := provider.DeriveKey(masterKey, pendingChangePurpose)
+	if err != nil {
+		return nil, err
+	}
+	return provider.MAC(key, data)
+}
+
+func (aa *ApprovalAdapter) storeChange(change *PendingChange) error {
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	mac, err := aa.mac(data)
+	if err != nil {
+		return err
+	}
+	record := pendingRecord{
+		Change: string(data),
+		MAC:    base64.StdEncoding.EncodeToString(mac),
+	}
+	return aa.Inner.Store(aa.pendingKey(change.ID), record)
+}
+
+func (aa *ApprovalAdapter) propose(op string, key string, value interface{}) error {
+	change := PendingChange{
+		Operation: op,
+		Key:       key,
+		Requester: aa.Identity,
+		Requested: aa.now().UTC().Format(time.RFC3339),
+	}
+	if value != nil {
+		data, err := toMap(value)
+		if err != nil {
+			return err
+		}
+		change.Value = data
+	}
+	id := make([]byte, 8)
+	err := readEntropy(id)
+	if err != nil {
+		return err
+	}
+	change.ID = hex.EncodeToString(id)
+	err = aa.storeChange(&change)
+	if err != nil {
+		return err
+	}
+	return &PendingApprovalError{ID: change.ID, Key: key}
+}
+
+func (aa *ApprovalAdapter) Store(key string, value interface{}) error {
+	err := aa.checkWrite(OpStore, key)
+	if err != nil {
+		return err
+	}
+	if aa.requiresApproval(key) {
+		return aa.propose(OpStore, key, value)
+	}
+	return aa.Inner.Store(key, value)
+}
+
+func (aa *ApprovalAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	err := aa.checkWrite(OpStoreWithData, key)
+	if err != nil {
+		return err
+	}
+	if aa.requiresApproval(key) {
+		return aa.propose(OpStore, key, value)
+	}
+	return aa.Inner.StoreWithData(key, value, output)
+}
+
+func (aa *ApprovalAdapter) Lookup(key string, output interface{}) error {
+	return aa.Inner.Lookup(key, output)
+}
+
+func (aa *ApprovalAdapter) Delete(key string) error {
+	err := aa.checkWrite(OpDelete, key)
+	if err != nil {
+		return err
+	}
+	if aa.requiresApproval(key) {
+		return aa.propose(OpDelete, key, nil)
+	}
+	return aa.Inner.Delete(key)
+}
+
+// PendingPath is hidden from listings, at any level.
+func (aa *ApprovalAdapter) LookupKeys(keyPath string) ([]string, error) {
+	keys, err := aa.Inner.LookupKeys(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	return hideKeyPath(keyPath, keys, aa.PendingPath), nil
+}
+
+// Read a pending change, failing with ErrAccessDenied if it was not
+// written by an ApprovalAdapter with the same key.
+func (aa *ApprovalAdapter) lookupChange(id string) (*PendingChange, error) {
+	var record pendingRecord
+	var change PendingChange
+
+	err := aa.Inner.Lookup(aa.pendingKey(id), &record)
+	if err != nil {
+		return nil, err
+	}
+	if record.Change == "" {
+		return nil, fmt.Errorf("%w: pending change %s", ErrNotFound, id)
+	}
+	mac, err := aa.mac([]byte(record.Change))
+	if err != nil {
+		return nil, err
+	}
+	stored, err := base64.StdEncoding.DecodeString(record.MAC)
+	if err != nil || !hmac.Equal(mac, stored) {
+		return nil, fmt.Errorf("%w: pending change %s failed authentication", ErrAccessDenied, id)
+	}
+	err = json.Unmarshal([]byte(record.Change), &change)
+	if err != nil {
+		return nil, err
+	}
+	if change.ID != id {
+		return nil, fmt.Errorf("%w: pending change %s failed authentication", ErrAccessDenied, id)
+	}
+	return &change, nil
+}
+
+// List the pending changes, oldest first. Records that fail authentication
+// are left out.
+func (aa *ApprovalAdapter) Pending() ([]PendingChange, error) {
+	ids, err := aa.Inner.LookupKeys(aa.PendingPath)
+	if err != nil {
+		return nil, err
+	}
+	var changes []PendingChange
+	for _, id := range ids {
+		change, err := aa.lookupChange(id)
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrAccessDenied) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, *change)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Requested != changes[j].Requested {
+			return changes[i].Requested < changes[j].Requested
+		}
+		return changes[i].ID < changes[j].ID
+	})
+	return changes, nil
+}
+
+// Approve a pending change as Identity, which must not be the requester.
+// The change is applied, and true returned, once it has enough approvals.
+func (aa *ApprovalAdapter) Approve(id string) (bool, error) {
+	aa.mu.Lock()
+	defer aa.mu.Unlock()
+
+	change, err := aa.lookupChange(id)
+	if err != nil {
+		return false, err
+	}
+	if aa.Identity == "" || aa.Identity == change.Requester {
+		return false, fmt.Errorf("%w: %q cannot approve their own change %s", ErrAccessDenied, aa.Identity, id)
+	}
+	for _, approver := range change.Approvers {
+		if approver == aa.Identity {
+			return false, fmt.Errorf("%s has already approved change %s", aa.Identity, id)
+		}
+	}
+	change.Approvers = append(change.Approvers, aa.Identity)
+	if len(change.Approvers) < aa.Approvals {
+		return false, aa.storeChange(change)
+	}
+
+	if change.Operation == OpDelete {
+		err = aa.Inner.Delete(change.Key)
+	} else {
+		err = aa.Inner.Store(change.Key, change.Value)
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, aa.Inner.Delete(aa.pendingKey(id))
+}
+
+// Discard a pending change without applying it.
+func (aa *ApprovalAdapter) Reject(id string) error {
+	aa.mu.Lock()
+	defer aa.mu.Unlock()
+
+	_, err := aa.lookupChange(id)
+	if err != nil {
+		return err
+	}
+	return aa.Inner.Delete(aa.pendingKey(id))
+}
+
+func (aa *ApprovalAdapter) Close() error {
+	return Close(aa.Inner)
+}
+
+func (aa *ApprovalAdapter) Health() error {
+	return Health(aa.Inner)
+}
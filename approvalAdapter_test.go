@@ -0,0 +1,162 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestApprovalAdapter(t *testing.T) {
+	ms := newMemStore()
+	key, _ := GenerateMasterKey()
+	kp, _ := NewStaticKeyProvider(key)
+	alice := NewApprovalAdapter(ms, kp, "alice", "root-creds")
+	alice.Approvals = 2
+	bob := alice.WithIdentity("bob")
+	carol := alice.WithIdentity("carol")
+
+	// Keys outside the prefixes are written directly.
+	err := alice.Store("hms-creds/x0c0s0b0", creds{Password: "pw"})
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+
+	err = alice.Store("root-creds/switch", creds{Password: "new"})
+	var pending *PendingApprovalError
+	if !errors.Is(err, ErrApprovalRequired) || !errors.As(err, &pending) {
+		t.Fatalf("Test Failed: Expected ErrApprovalRequired but got %v", err)
+	}
+	var got creds
+	alice.Lookup("root-creds/switch", &got)
+	if got.Password != "" {
+		t.Errorf("Test Failed: Expected change not to be applied before approval")
+	}
+	changes, err := bob.Pending()
+	if err != nil || len(changes) != 1 || changes[0].Requester != "alice" || changes[0].Key != "root-creds/switch" {
+		t.Fatalf("Test Failed: Unexpected pending changes %+v (%v)", changes, err)
+	}
+	keys, _ := alice.LookupKeys("")
+	for _, key := range keys {
+		if key == DefaultPendingPath+"/" {
+			t.Errorf("Test Failed: Expected pending changes to be hidden from the root listing")
+		}
+	}
+	if keys, _ := alice.LookupKeys(DefaultPendingPath); len(keys) != 0 {
+		t.Errorf("Test Failed: Expected pending changes to be hidden but got %v", keys)
+	}
+
+	tests := []struct {
+		approver *ApprovalAdapter
+		applied  bool
+		err      bool
+	}{
+		{alice, false, true},
+		{bob, false, false},
+		{bob, false, true},
+		{carol, true, false},
+	}
+	for i, test := range tests {
+		applied, err := test.approver.Approve(pending.ID)
+		if applied != test.applied || (err != nil) != test.err {
+			t.Errorf("Test %v Failed: Expected %v (error %v) but got %v, %v", i, test.applied, test.err, applied, err)
+		}
+	}
+	alice.Lookup("root-creds/switch", &got)
+	if got.Password != "new" {
+		t.Errorf("Test Failed: Expected approved change to be applied but got %v", got)
+	}
+	if changes, _ := alice.Pending(); len(changes) != 0 {
+		t.Errorf("Test Failed: Expected no pending changes but got %+v", changes)
+	}
+
+	// Deletes need approval too and can be rejected.
+	err = bob.Delete("root-creds/switch")
+	errors.As(err, &pending)
+	if err = carol.Reject(pending.ID); err != nil {
+		t.Errorf("Test Failed: Unexpected error - %v", err)
+	}
+	if _, err := alice.Approve(pending.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Test Failed: Expected ErrNotFound approving a rejected change but got %v", err)
+	}
+	got = creds{}
+	alice.Lookup("root-creds/switch", &got)
+	if got.Password != "new" {
+		t.Errorf("Test Failed: Expected rejected delete not to be applied")
+	}
+}
+
+func TestApprovalAdapterForgery(t *testing.T) {
+	ms := newMemStore()
+	ms.Store("root-creds/switch", map[string]interface{}{"Password": "old"})
+	key, _ := GenerateMasterKey()
+	keys, _ := NewStaticKeyProvider(key)
+	otherKey, _ := GenerateMasterKey()
+	otherKeys, _ := NewStaticKeyProvider(otherKey)
+	alice := NewApprovalAdapter(ms, keys, "alice", "root-creds")
+
+	forged := `{"id":"evil","operation":"store","key":"root-creds/switch","value":{"Password":"evil"},"requester":"mallory","requested":"2026-01-01T00:00:00Z","approvers":["bob"]}`
+	outsider := NewApprovalAdapter(ms, otherKeys, "mallory", "root-creds")
+	outsider.Store("root-creds/switch", creds{Password: "outsider"})
+	outsiderChanges, _ := ms.LookupKeys(DefaultPendingPath)
+
+	// Writes below the pending path and keys escaping the prefixes are
+	// rejected.
+	var tests = []struct {
+		key string
+		err error
+	}{
+		{key: DefaultPendingPath + "/evil", err: ErrAccessDenied},
+		{key: DefaultPendingPath, err: ErrAccessDenied},
+		{key: "scratch/../root-creds/switch", err: ErrInvalidKey},
+		{key: "/root-creds/switch", err: ErrInvalidKey},
+	}
+	for i, test := range tests {
+		err := alice.Store(test.key, pendingRecord{Change: forged})
+		if !errors.Is(err, test.err) {
+			t.Errorf("Test %v Failed: Expected %v storing %s but got %v", i, test.err, test.key, err)
+		}
+		err = alice.Delete(test.key)
+		if !errors.Is(err, test.err) {
+			t.Errorf("Test %v Failed: Expected %v deleting %s but got %v", i, test.err, test.key, err)
+		}
+	}
+
+	// Records written to the store directly, or by an adapter with another
+	// key, cannot be approved.
+	ms.Store(DefaultPendingPath+"/evil", map[string]interface{}{"change": forged, "mac": "AAAA"})
+	for i, id := range append([]string{"evil"}, outsiderChanges...) {
+		applied, err := alice.WithIdentity("bob").Approve(id)
+		if applied || !errors.Is(err, ErrAccessDenied) {
+			t.Errorf("Test %v Failed: Expected ErrAccessDenied approving %s but got %v, %v", i, id, applied, err)
+		}
+	}
+	if changes, err := alice.Pending(); err != nil || len(changes) != 0 {
+		t.Errorf("Test Failed: Expected forged changes to be left out but got %+v (%v)", changes, err)
+	}
+	var got creds
+	alice.Lookup("root-creds/switch", &got)
+	if got.Password != "old" {
+		t.Errorf("Test Failed: Expected the protected key to be unchanged but got %v", got)
+	}
+}
@@ -0,0 +1,329 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Archive algorithms
+const (
+	// Hybrid post-quantum encryption to a HybridPublicKey: the data key is
+	// derived from both an X25519 and an ML-KEM-768 key exchange, so it
+	// stays safe unless both are broken. The cipher is that of the
+	// CryptoProvider.
+	AlgX25519MLKEM768 = hybridAlgPrefix + AlgAES256GCM
+)
+
+const (
+	hybridAlg       = "X25519-MLKEM768"
+	hybridAlgPrefix = hybridAlg + "+"
+)
+
+// ArchiveVersion is the version of the archive format written by
+// SealArchive().
+const ArchiveVersion = 1
+
+const (
+	x25519KeySize = 32
+	// Domain separation for the hybrid key combiner
+	hybridLabel = "securestorage X25519-MLKEM768 v1"
+)
+
+// HybridPrivateKey is an X25519 key together with an ML-KEM-768 key, for
+// archives that must withstand an attacker who records them now and
+// decrypts them once quantum computers can break X25519.
+type HybridPrivateKey struct {
+	// X25519 private key followed by the ML-KEM-768 seed
+	seed []byte
+}
+
+// HybridPublicKey is the public half of a HybridPrivateKey.
+type HybridPublicKey struct {
+	// X25519 public key followed by the ML-KEM-768 encapsulation key
+	data []byte
+}
+
+// Generate a new HybridPrivateKey. ML-KEM requires Go 1.24 or later.
+func GenerateHybridKey() (*HybridPrivateKey, error) {
+	seed := make([]byte, x25519KeySize+mlkemSeedSize)
+	err := readEntropy(seed)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHybridPrivateKey(seed)
+}
+
+// Parse a key encoded with Bytes().
+func ParseHybridPrivateKey(data []byte) (*HybridPrivateKey, error) {
+	if len(data) != x25519KeySize+mlkemSeedSize {
+		return nil, fmt.Errorf("Hybrid private key must be %d bytes, got %d", x25519KeySize+mlkemSeedSize, len(data))
+	}
+	key := &HybridPrivateKey{seed: append([]byte(nil), data...)}
+	_, err := key.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (hk *HybridPrivateKey) Bytes() []byte {
+	return append([]byte(nil), hk.seed...)
+}
+
+func (hk *HybridPrivateKey) PublicKey() (*HybridPublicKey, error) {
+	x, err := ecdh.X25519().NewPrivateKey(hk.seed[:x25519KeySize])
+	if err != nil {
+		return nil, err
+	}
+	encapsulationKey, err := mlkemPublic(hk.seed[x25519KeySize:])
+	if err != nil {
+		return nil, err
+	}
+	return &HybridPublicKey{data: append(x.PublicKey().Bytes(), encapsulationKey...)}, nil
+}
+
+// Parse a key encoded with Bytes().
+func ParseHybridPublicKey(data []byte) (*HybridPublicKey, error) {
+	if len(data) <= x25519KeySize {
+		return nil, fmt.Errorf("Hybrid public key too short")
+	}
+	_, err := ecdh.X25519().NewPublicKey(data[:x25519KeySize])
+	if err != nil {
+		return nil, err
+	}
+	return &HybridPublicKey{data: append([]byte(nil), data...)}, nil
+}
+
+func (hk *HybridPublicKey) Bytes() []byte {
+	return append([]byte(nil), hk.data...)
+}
+
+// Combine both shared secrets into one key, binding the ciphertexts and
+// the recipient so neither exchange can be swapped out.
+func combineHybrid(mlkemShared []byte, x25519Shared []byte, ephemeral []byte, mlkemCiphertext []byte, recipient []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(hybridLabel))
+	h.Write(mlkemShared)
+	h.Write(x25519Shared)
+	h.Write(ephemeral)
+	h.Write(mlkemCiphertext)
+	h.Write(recipient[:x25519KeySize])
+	return h.Sum(nil)
+}
+
+// Check that hybrid encryption may be used. X25519 is not FIPS approved,
+// so it is refused in FIPS mode.
+func checkHybrid() error {
+	if fipsEnabled() {
+		return fmt.Errorf("%w: %s is not FIPS approved", ErrNotCompliant, hybridAlg)
+	}
+	return nil
+}
+
+// Derive a data key for recipient, returning it and the encapsulation to
+// store in the archive header.
+func (hk *HybridPublicKey) encapsulate() ([]byte, []byte, error) {
+	err := checkHybrid()
+	if err != nil {
+		return nil, nil, err
+	}
+	peer, err := ecdh.X25519().NewPublicKey(hk.data[:x25519KeySize])
+	if err != nil {
+		return nil, nil, err
+	}
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	x25519Shared, err := ephemeral.ECDH(peer)
+	if err != nil {
+		return nil, nil, err
+	}
+	mlkemShared, mlkemCiphertext, err := mlkemEncapsulate(hk.data[x25519KeySize:])
+	if err != nil {
+		return nil, nil, err
+	}
+	eph := ephemeral.PublicKey().Bytes()
+	key := combineHybrid(mlkemShared, x25519Shared, eph, mlkemCiphertext, hk.data)
+	return key, append(eph, mlkemCiphertext...), nil
+}
+
+func (hk *HybridPrivateKey) decapsulate(encapsulated []byte) ([]byte, error) {
+	err := checkHybrid()
+	if err != nil {
+		return nil, err
+	}
+	if len(encapsulated) <= x25519KeySize {
+		return nil, fmt.Errorf("Encapsulated key too short")
+	}
+	x, err := ecdh.X25519().NewPrivateKey(hk.seed[:x25519KeySize])
+	if err != nil {
+		return nil, err
+	}
+	eph, err := ecdh.X25519().NewPublicKey(encapsulated[:x25519KeySize])
+	if err != nil {
+		return nil, err
+	}
+	x25519Shared, err := x.ECDH(eph)
+	if err != nil {
+		return nil, err
+	}
+	mlkemCiphertext := encapsulated[x25519KeySize:]
+	mlkemShared, err := mlkemDecapsulate(hk.seed[x25519KeySize:], mlkemCiphertext)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := hk.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return combineHybrid(mlkemShared, x25519Shared, encapsulated[:x25519KeySize], mlkemCiphertext, pub.data), nil
+}
+
+// ArchiveOptions selects how an archive is encrypted: with a MasterKeySize
+// byte Key, or, if Recipient is set, with hybrid post-quantum encryption
+// to Recipient.
+type ArchiveOptions struct {
+	Key       []byte
+	Recipient *HybridPublicKey
+}
+
+type archiveFile struct {
+	Version      int    `json:"version"`
+	Alg          string `json:"alg"`
+	Encapsulated string `json:"encapsulated,omitempty"`
+	Ciphertext   string `json:"ciphertext"`
+}
+
+// Encrypt a set of secrets, such as the result of TenantManager's
+// ExportTenant(), into an archive suitable for long term storage.
+func SealArchive(secrets map[string]map[string]interface{}, opts ArchiveOptions) ([]byte, error) {
+	provider, err := currentCrypto()
+	if err != nil {
+		return nil, err
+	}
+	file := archiveFile{Version: ArchiveVersion, Alg: provider.Algorithm()}
+	key := opts.Key
+	if opts.Recipient != nil {
+		var encapsulated []byte
+		key, encapsulated, err = opts.Recipient.encapsulate()
+		if err != nil {
+			return nil, err
+		}
+		file.Alg = hybridAlgPrefix + file.Alg
+		file.Encapsulated = base64.StdEncoding.EncodeToString(encapsulated)
+	}
+	err = checkMasterKey(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := seal(provider, key, plaintext, []byte(file.Alg))
+	if err != nil {
+		return nil, err
+	}
+	file.Ciphertext = base64.StdEncoding.EncodeToString(sealed)
+	return json.Marshal(file)
+}
+
+// Decrypt an archive written by SealArchive(), using key for archives
+// encrypted with a key and recipient for hybrid encrypted ones.
+func OpenArchive(data []byte, key []byte, recipient *HybridPrivateKey) (map[string]map[string]interface{}, error) {
+	var (
+		file    archiveFile
+		secrets map[string]map[string]interface{}
+	)
+
+	err := json.Unmarshal(data, &file)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid archive: %v", err)
+	}
+	if file.Version != ArchiveVersion {
+		return nil, fmt.Errorf("Unsupported archive version %d", file.Version)
+	}
+	provider, err := currentCrypto()
+	if err != nil {
+		return nil, err
+	}
+	switch file.Alg {
+	case provider.Algorithm():
+	case hybridAlgPrefix + provider.Algorithm():
+		if recipient == nil {
+			return nil, fmt.Errorf("Archive is encrypted with %s; a hybrid private key is required", file.Alg)
+		}
+		encapsulated, err := base64.StdEncoding.DecodeString(file.Encapsulated)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid archive key encapsulation: %v", err)
+		}
+		key, err = recipient.decapsulate(encapsulated)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("Unsupported archive algorithm %q", file.Alg)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(file.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid archive ciphertext: %v", err)
+	}
+	plaintext, err := unseal(provider, key, sealed, []byte(file.Alg))
+	if err != nil {
+		return nil, fmt.Errorf("Cannot decrypt archive: %v", err)
+	}
+	defer plaintext.Destroy()
+	err = json.Unmarshal(plaintext.Bytes(), &secrets)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot decode archive: %v", err)
+	}
+	return secrets, nil
+}
+
+// Read every value below prefix and seal them into an archive.
+func ExportArchive(ss SecureStorage, prefix string, opts ArchiveOptions) ([]byte, error) {
+	secrets := map[string]map[string]interface{}{}
+	it := IterateKeys(ss, prefix)
+	defer it.Close()
+	for it.Next() {
+		var data map[string]interface{}
+		err := ss.Lookup(it.Key(), &data)
+		if err != nil {
+			return nil, err
+		}
+		if data != nil {
+			secrets[it.Key()] = data
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return SealArchive(secrets, opts)
+}
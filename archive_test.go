@@ -0,0 +1,128 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestArchive(t *testing.T) {
+	ms := newMemStore()
+	ms.Store("hms-creds/x0c0s0b0", creds{Xname: "x0c0s0b0", Username: "root", Password: "pw"})
+	ms.Store("hms-creds/x0c0s1b0", creds{Xname: "x0c0s1b0", Username: "root", Password: "pw2"})
+	ms.Store("other/x0c0s2b0", creds{Xname: "x0c0s2b0"})
+
+	key, _ := GenerateMasterKey()
+	otherKey, _ := GenerateMasterKey()
+	priv, err := GenerateHybridKey()
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	pub, _ := priv.PublicKey()
+	otherPriv, _ := GenerateHybridKey()
+
+	// Keys survive encoding.
+	parsedPriv, err := ParseHybridPrivateKey(priv.Bytes())
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	parsedPub, err := ParseHybridPublicKey(pub.Bytes())
+	if err != nil || !bytes.Equal(parsedPub.Bytes(), pub.Bytes()) {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+
+	tests := []struct {
+		opts      ArchiveOptions
+		alg       string
+		key       []byte
+		recipient *HybridPrivateKey
+		ok        bool
+	}{
+		{ArchiveOptions{Key: key}, AlgAES256GCM, key, nil, true},
+		{ArchiveOptions{Key: key}, AlgAES256GCM, otherKey, nil, false},
+		{ArchiveOptions{Recipient: parsedPub}, AlgX25519MLKEM768, nil, parsedPriv, true},
+		{ArchiveOptions{Recipient: pub}, AlgX25519MLKEM768, nil, otherPriv, false},
+		{ArchiveOptions{Recipient: pub}, AlgX25519MLKEM768, key, nil, false},
+	}
+	for i, test := range tests {
+		if fipsBuild && test.opts.Recipient != nil {
+			continue
+		}
+		data, err := ExportArchive(ms, "hms-creds", test.opts)
+		if err != nil {
+			t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		if bytes.Contains(data, []byte("x0c0s0b0")) {
+			t.Errorf("Test %v Failed: Archive contains plaintext", i)
+		}
+		var file archiveFile
+		json.Unmarshal(data, &file)
+		if file.Version != ArchiveVersion || file.Alg != test.alg {
+			t.Errorf("Test %v Failed: Unexpected archive header %+v", i, file)
+		}
+
+		secrets, err := OpenArchive(data, test.key, test.recipient)
+		if (err == nil) != test.ok {
+			t.Errorf("Test %v Failed: Expected success %v but got %v", i, test.ok, err)
+		}
+		if test.ok && (len(secrets) != 2 || secrets["hms-creds/x0c0s1b0"]["Password"] != "pw2") {
+			t.Errorf("Test %v Failed: Unexpected secrets %v", i, secrets)
+		}
+	}
+
+	// The algorithm is authenticated, so a downgrade fails.
+	data, _ := SealArchive(map[string]map[string]interface{}{"a": {"b": "c"}}, ArchiveOptions{Key: key})
+	tampered := bytes.Replace(data, []byte(`"alg":"AES-256-GCM"`), []byte(`"alg":"`+AlgX25519MLKEM768+`"`), 1)
+	if _, err := OpenArchive(tampered, key, priv); err == nil {
+		t.Errorf("Test Failed: Expected error opening tampered archive")
+	}
+	secrets, err := OpenArchive(data, key, nil)
+	if err != nil || !reflect.DeepEqual(secrets, map[string]map[string]interface{}{"a": {"b": "c"}}) {
+		t.Errorf("Test Failed: Unexpected secrets %v (%v)", secrets, err)
+	}
+	if _, err := SealArchive(nil, ArchiveOptions{}); err == nil {
+		t.Errorf("Test Failed: Expected error sealing without a key")
+	}
+
+	// X25519 is not FIPS approved, so hybrid archives are refused in FIPS
+	// mode.
+	hybrid, _ := SealArchive(map[string]map[string]interface{}{"a": {"b": "c"}}, ArchiveOptions{Recipient: pub})
+	fipsMu.Lock()
+	fipsMode = true
+	fipsMu.Unlock()
+	defer func() {
+		fipsMu.Lock()
+		fipsMode = fipsBuild
+		fipsMu.Unlock()
+	}()
+	if _, err := SealArchive(nil, ArchiveOptions{Recipient: pub}); !errors.Is(err, ErrNotCompliant) {
+		t.Errorf("Test Failed: Expected ErrNotCompliant sealing a hybrid archive but got %v", err)
+	}
+	if _, err := OpenArchive(hybrid, nil, priv); hybrid != nil && !errors.Is(err, ErrNotCompliant) {
+		t.Errorf("Test Failed: Expected ErrNotCompliant opening a hybrid archive but got %v", err)
+	}
+}
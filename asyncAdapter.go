@@ -0,0 +1,156 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"sync"
+)
+
+// KeysResult is the result of LookupKeysAsync.
+type KeysResult struct {
+	Keys []string
+	Err  error
+}
+
+// AsyncAdapter adds asynchronous variants of the SecureStorage operations,
+// run by a fixed pool of workers, so callers making thousands of independent
+// calls do not need their own goroutine pools. Each asynchronous call
+// returns a channel that receives exactly one result. Calls block while the
+// queue is full. The synchronous operations are passed straight through.
+type AsyncAdapter struct {
+	Inner SecureStorage
+
+	queue  chan func()
+	wg     sync.WaitGroup
+	mu     sync.RWMutex
+	closed bool
+}
+
+// Create a new AsyncAdapter with the given number of workers and a queue
+// holding up to queueSize pending calls.
+func NewAsyncAdapter(inner SecureStorage, workers int, queueSize int) *AsyncAdapter {
+	if workers < 1 {
+		workers = 1
+	}
+	aa := &AsyncAdapter{
+		Inner: inner,
+		queue: make(chan func(), queueSize),
+	}
+	for i := 0; i < workers; i++ {
+		aa.wg.Add(1)
+		go aa.work()
+	}
+	return aa
+}
+
+func (aa *AsyncAdapter) work() {
+	defer aa.wg.Done()
+	for call := range aa.queue {
+		call()
+	}
+}
+
+// Queue a call, or run fail if the adapter has been closed.
+func (aa *AsyncAdapter) submit(call func(), fail func(err error)) {
+	aa.mu.RLock()
+	defer aa.mu.RUnlock()
+	if aa.closed {
+		fail(fmt.Errorf("AsyncAdapter is closed"))
+		return
+	}
+	aa.queue <- call
+}
+
+func (aa *AsyncAdapter) runAsync(op func() error) <-chan error {
+	result := make(chan error, 1)
+	aa.submit(func() { result <- op() }, func(err error) { result <- err })
+	return result
+}
+
+// Store a value in the background.
+func (aa *AsyncAdapter) StoreAsync(key string, value interface{}) <-chan error {
+	return aa.runAsync(func() error { return aa.Inner.Store(key, value) })
+}
+
+// Read a value into output in the background. output must not be used until
+// the result has been received.
+func (aa *AsyncAdapter) LookupAsync(key string, output interface{}) <-chan error {
+	return aa.runAsync(func() error { return aa.Inner.Lookup(key, output) })
+}
+
+// Delete a value in the background.
+func (aa *AsyncAdapter) DeleteAsync(key string) <-chan error {
+	return aa.runAsync(func() error { return aa.Inner.Delete(key) })
+}
+
+// List keys in the background.
+func (aa *AsyncAdapter) LookupKeysAsync(keyPath string) <-chan KeysResult {
+	result := make(chan KeysResult, 1)
+	aa.submit(func() {
+		keys, err := aa.Inner.LookupKeys(keyPath)
+		result <- KeysResult{Keys: keys, Err: err}
+	}, func(err error) {
+		result <- KeysResult{Err: err}
+	})
+	return result
+}
+
+func (aa *AsyncAdapter) Store(key string, value interface{}) error {
+	return aa.Inner.Store(key, value)
+}
+
+func (aa *AsyncAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	return aa.Inner.StoreWithData(key, value, output)
+}
+
+func (aa *AsyncAdapter) Lookup(key string, output interface{}) error {
+	return aa.Inner.Lookup(key, output)
+}
+
+func (aa *AsyncAdapter) Delete(key string) error {
+	return aa.Inner.Delete(key)
+}
+
+func (aa *AsyncAdapter) LookupKeys(keyPath string) ([]string, error) {
+	return aa.Inner.LookupKeys(keyPath)
+}
+
+func (aa *AsyncAdapter) Health() error {
+	return Health(aa.Inner)
+}
+
+// Stop accepting asynchronous calls, wait for the queued ones to finish,
+// then close the wrapped store.
+func (aa *AsyncAdapter) Close() error {
+	aa.mu.Lock()
+	if aa.closed {
+		aa.mu.Unlock()
+		return nil
+	}
+	aa.closed = true
+	close(aa.queue)
+	aa.mu.Unlock()
+	aa.wg.Wait()
+	return Close(aa.Inner)
+}
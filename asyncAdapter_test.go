@@ -0,0 +1,62 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAsyncAdapter(t *testing.T) {
+	aa := NewAsyncAdapter(newMemStore(), 4, 8)
+
+	var results []<-chan error
+	for i := 0; i < 100; i++ {
+		results = append(results, aa.StoreAsync(fmt.Sprintf("hms-creds/x0c0s%db0", i), creds{Password: fmt.Sprint(i)}))
+	}
+	for i, result := range results {
+		if err := <-result; err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+	}
+
+	var r creds
+	if err := <-aa.LookupAsync("hms-creds/x0c0s42b0", &r); err != nil || r.Password != "42" {
+		t.Errorf("Expected password 42 but got %v (%v)", r.Password, err)
+	}
+	if err := <-aa.DeleteAsync("hms-creds/x0c0s42b0"); err != nil {
+		t.Errorf("Unexpected error - %v", err)
+	}
+	keys := <-aa.LookupKeysAsync("hms-creds")
+	if keys.Err != nil || len(keys.Keys) != 99 {
+		t.Errorf("Expected 99 keys but got %d (%v)", len(keys.Keys), keys.Err)
+	}
+
+	aa.Close()
+	if err := <-aa.StoreAsync("hms-creds/x0c0s1b0", creds{}); err == nil {
+		t.Errorf("Expected an error after Close")
+	}
+	if keys := <-aa.LookupKeysAsync("hms-creds"); keys.Err == nil {
+		t.Errorf("Expected an error after Close")
+	}
+}
@@ -0,0 +1,167 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ErrAuditChainBroken is returned (wrapped) by VerifyAuditLog() when a
+// record has been modified, removed, reordered or inserted.
+var ErrAuditChainBroken = errors.New("audit chain broken")
+
+// ChainedAuditRecord is an AuditEvent as written by ChainedAuditSink. Hash
+// is the SHA-256 of the record's JSON encoding with Hash and Signature
+// empty, so it covers Prev, the Hash of the record before it.
+type ChainedAuditRecord struct {
+	AuditEvent
+	Seq       uint64 `json:"seq"`
+	Prev      string `json:"prev"`
+	Hash      string `json:"hash"`
+	Signature string `json:"sig,omitempty"`
+}
+
+// Compute the hash of a record.
+func (cr *ChainedAuditRecord) digest() ([]byte, error) {
+	unsigned := *cr
+	unsigned.Hash = ""
+	unsigned.Signature = ""
+	encoded, err := json.Marshal(&unsigned)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(encoded)
+	return sum[:], nil
+}
+
+// ChainedAuditSink writes audit events as lines of JSON that form a hash
+// chain, each record including the hash of the one before it, and signs
+// each hash with Key if it is set. Modifying or removing a record breaks
+// the chain. Removing records from the end cannot be detected from the log
+// alone, so publish Checkpoint() somewhere else from time to time.
+type ChainedAuditSink struct {
+	Key ed25519.PrivateKey
+
+	mu   sync.Mutex
+	out  io.Writer
+	last ChainedAuditRecord
+}
+
+// Create a new ChainedAuditSink writing to out, signing with key if it is
+// not nil.
+func NewChainedAuditSink(out io.Writer, key ed25519.PrivateKey) *ChainedAuditSink {
+	return &ChainedAuditSink{Key: key, out: out}
+}
+
+// Continue the chain after last, the final record of an existing log as
+// returned by VerifyAuditLog(), when appending to it.
+func (cs *ChainedAuditSink) Resume(last ChainedAuditRecord) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.last = last
+}
+
+// Get the sequence number and hash of the last record written.
+func (cs *ChainedAuditSink) Checkpoint() (uint64, string) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.last.Seq, cs.last.Hash
+}
+
+func (cs *ChainedAuditSink) Record(event AuditEvent) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	record := ChainedAuditRecord{
+		AuditEvent: event,
+		Seq:        cs.last.Seq + 1,
+		Prev:       cs.last.Hash,
+	}
+	digest, err := record.digest()
+	if err != nil {
+		return
+	}
+	record.Hash = hex.EncodeToString(digest)
+	if cs.Key != nil {
+		record.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(cs.Key, digest))
+	}
+	line, err := json.Marshal(&record)
+	if err != nil {
+		return
+	}
+	_, err = cs.out.Write(append(line, '\n'))
+	if err != nil {
+		return
+	}
+	cs.last = record
+}
+
+// Check a log written by ChainedAuditSink, returning the last record. If
+// key is not nil every record must carry a valid signature from it. The
+// first record must start the chain unless the log was rotated, in which
+// case pass the last record of the previous log as prev.
+func VerifyAuditLog(in io.Reader, key ed25519.PublicKey, prev *ChainedAuditRecord) (*ChainedAuditRecord, error) {
+	var last ChainedAuditRecord
+
+	if prev != nil {
+		last = *prev
+	}
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(nil, 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var record ChainedAuditRecord
+
+		err := json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d is not a record: %v", ErrAuditChainBroken, line, err)
+		}
+		if record.Seq != last.Seq+1 || record.Prev != last.Hash {
+			return nil, fmt.Errorf("%w: line %d does not follow record %d", ErrAuditChainBroken, line, last.Seq)
+		}
+		digest, err := record.digest()
+		if err != nil {
+			return nil, err
+		}
+		if record.Hash != hex.EncodeToString(digest) {
+			return nil, fmt.Errorf("%w: record %d was modified", ErrAuditChainBroken, record.Seq)
+		}
+		if key != nil {
+			sig, err := base64.StdEncoding.DecodeString(record.Signature)
+			if err != nil || !ed25519.Verify(key, digest, sig) {
+				return nil, fmt.Errorf("%w: record %d has no valid signature", ErrAuditChainBroken, record.Seq)
+			}
+		}
+		last = record
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &last, nil
+}
@@ -0,0 +1,103 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChainedAuditSink(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	otherPub, _, _ := ed25519.GenerateKey(nil)
+
+	var buf bytes.Buffer
+	sink := NewChainedAuditSink(&buf, priv)
+	ss := Audited(newMemStore(), sink)
+	ss.Store("x0c0s1b0", creds{Username: "root", Password: "pw"})
+	ss.Lookup("x0c0s1b0", &creds{})
+	ss.Lookup("x0c0s2b0", &creds{})
+	ss.Delete("x0c0s1b0")
+	lines := strings.SplitAfter(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected 4 records but got %q", lines)
+	}
+	join := func(l ...string) string {
+		return strings.Join(l, "")
+	}
+
+	var tests = []struct {
+		log     string
+		key     ed25519.PublicKey
+		respErr bool
+	}{
+		{log: buf.String(), key: pub},
+		{log: buf.String()},
+		{log: buf.String(), key: otherPub, respErr: true},
+		{log: join(lines[0], lines[2], lines[3]), respErr: true},
+		{log: join(lines[1], lines[2], lines[3]), respErr: true},
+		{log: join(lines[0], lines[2], lines[1], lines[3]), respErr: true},
+		{log: strings.Replace(buf.String(), `"operation":"delete"`, `"operation":"lookup"`, 1), respErr: true},
+		{log: join(lines[0], "not json\n", lines[1]), respErr: true},
+	}
+
+	for i, test := range tests {
+		last, err := VerifyAuditLog(strings.NewReader(test.log), test.key, nil)
+		if (err != nil) != test.respErr {
+			t.Errorf("Test %v Failed: Unexpected error result - %v", i, err)
+			continue
+		}
+		if err != nil && !errors.Is(err, ErrAuditChainBroken) {
+			t.Errorf("Test %v Failed: Expected ErrAuditChainBroken but got %v", i, err)
+		}
+		if err == nil && (last.Seq != 4 || last.Operation != OpDelete) {
+			t.Errorf("Test %v Failed: Unexpected last record %+v", i, last)
+		}
+	}
+
+	// A rotated log continues the chain from the previous one.
+	last, err := VerifyAuditLog(&buf, pub, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	seq, hash := sink.Checkpoint()
+	if seq != last.Seq || hash != last.Hash {
+		t.Errorf("Expected checkpoint %v/%v but got %v/%v", last.Seq, last.Hash, seq, hash)
+	}
+	var next bytes.Buffer
+	resumed := NewChainedAuditSink(&next, priv)
+	resumed.Resume(*last)
+	resumed.Record(AuditEvent{Time: time.Now(), Operation: OpStore, Key: "x0c0s3b0", Outcome: OutcomeSuccess})
+	if _, err := VerifyAuditLog(bytes.NewReader(next.Bytes()), pub, last); err != nil {
+		t.Errorf("Unexpected error verifying a resumed log - %v", err)
+	}
+	if _, err := VerifyAuditLog(bytes.NewReader(next.Bytes()), pub, nil); err == nil {
+		t.Errorf("Expected an error verifying a resumed log without its predecessor")
+	}
+}
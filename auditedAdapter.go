@@ -0,0 +1,178 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Operations recorded in audit events
+const (
+	OpStore         = "store"
+	OpStoreWithData = "store_with_data"
+	OpLookup        = "lookup"
+	OpDelete        = "delete"
+	OpLookupKeys    = "lookup_keys"
+)
+
+// Audit event outcomes
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// AuditEvent describes one operation on a SecureStorage. Values are never
+// recorded.
+type AuditEvent struct {
+	Time      time.Time     `json:"time"`
+	Operation string        `json:"operation"`
+	Key       string        `json:"key"`
+	Caller    string        `json:"caller"`
+	Latency   time.Duration `json:"latency_ns"`
+	Outcome   string        `json:"outcome"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// AuditSink receives audit events. Record must be safe for concurrent use.
+type AuditSink interface {
+	Record(event AuditEvent)
+}
+
+// AuditSinkFunc adapts a plain function to an AuditSink.
+type AuditSinkFunc func(event AuditEvent)
+
+func (f AuditSinkFunc) Record(event AuditEvent) {
+	f(event)
+}
+
+// JSONAuditSink writes each audit event as a line of JSON.
+type JSONAuditSink struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// Create a new JSONAuditSink writing to out.
+func NewJSONAuditSink(out io.Writer) *JSONAuditSink {
+	return &JSONAuditSink{out: out}
+}
+
+func (js *JSONAuditSink) Record(event AuditEvent) {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	js.out.Write(append(line, '\n'))
+}
+
+// AuditedAdapter sends an AuditEvent to a sink for every operation on the
+// wrapped SecureStorage. Caller identifies the service making the calls and
+// defaults to the program name. Error messages are passed through Redactor,
+// along with the values of the operation, before they are recorded.
+type AuditedAdapter struct {
+	Inner    SecureStorage
+	Sink     AuditSink
+	Caller   string
+	Redactor *Redactor
+	now      func() time.Time
+}
+
+// Create a new AuditedAdapter wrapping store.
+func Audited(store SecureStorage, sink AuditSink) *AuditedAdapter {
+	return &AuditedAdapter{
+		Inner:    store,
+		Sink:     sink,
+		Caller:   filepath.Base(os.Args[0]),
+		Redactor: DefaultRedactor,
+		now:      time.Now,
+	}
+}
+
+func (aa *AuditedAdapter) record(op string, key string, start time.Time, err error, values ...interface{}) {
+	event := AuditEvent{
+		Time:      start.UTC(),
+		Operation: op,
+		Key:       key,
+		Caller:    aa.Caller,
+		Latency:   aa.now().Sub(start),
+		Outcome:   OutcomeSuccess,
+	}
+	if err != nil {
+		event.Outcome = OutcomeFailure
+		redactor := aa.Redactor
+		if redactor == nil {
+			redactor = DefaultRedactor
+		}
+		event.Error = redactor.Redact(err.Error(), values...)
+	}
+	aa.Sink.Record(event)
+}
+
+func (aa *AuditedAdapter) Store(key string, value interface{}) error {
+	start := aa.now()
+	err := aa.Inner.Store(key, value)
+	aa.record(OpStore, key, start, err, value)
+	return err
+}
+
+func (aa *AuditedAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	start := aa.now()
+	err := aa.Inner.StoreWithData(key, value, output)
+	aa.record(OpStoreWithData, key, start, err, value, output)
+	return err
+}
+
+func (aa *AuditedAdapter) Lookup(key string, output interface{}) error {
+	start := aa.now()
+	err := aa.Inner.Lookup(key, output)
+	aa.record(OpLookup, key, start, err, output)
+	return err
+}
+
+func (aa *AuditedAdapter) Delete(key string) error {
+	start := aa.now()
+	err := aa.Inner.Delete(key)
+	aa.record(OpDelete, key, start, err)
+	return err
+}
+
+func (aa *AuditedAdapter) LookupKeys(keyPath string) ([]string, error) {
+	start := aa.now()
+	klist, err := aa.Inner.LookupKeys(keyPath)
+	aa.record(OpLookupKeys, keyPath, start, err)
+	return klist, err
+}
+
+func (aa *AuditedAdapter) Close() error {
+	return Close(aa.Inner)
+}
+
+func (aa *AuditedAdapter) Health() error {
+	return Health(aa.Inner)
+}
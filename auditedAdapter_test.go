@@ -0,0 +1,79 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAuditedAdapter(t *testing.T) {
+	var buf bytes.Buffer
+	ms := newMemStore()
+	aa := Audited(ms, NewJSONAuditSink(&buf))
+	aa.Caller = "hms-test"
+
+	aa.Store("x0c0s1b0", creds{Username: "root", Password: "secret-pw"})
+	var r creds
+	aa.Lookup("x0c0s1b0", &r)
+	aa.LookupKeys("")
+	aa.Delete("x0c0s1b0")
+	Audited(&failStore{err: fmt.Errorf("Code: 503")}, NewJSONAuditSink(&buf)).Lookup("x0c0s2b0", &r)
+
+	if strings.Contains(buf.String(), "secret-pw") {
+		t.Errorf("Audit log must not contain values: %s", buf.String())
+	}
+
+	expected := []AuditEvent{
+		{Operation: OpStore, Key: "x0c0s1b0", Caller: "hms-test", Outcome: OutcomeSuccess},
+		{Operation: OpLookup, Key: "x0c0s1b0", Caller: "hms-test", Outcome: OutcomeSuccess},
+		{Operation: OpLookupKeys, Key: "", Caller: "hms-test", Outcome: OutcomeSuccess},
+		{Operation: OpDelete, Key: "x0c0s1b0", Caller: "hms-test", Outcome: OutcomeSuccess},
+		{Operation: OpLookup, Key: "x0c0s2b0", Outcome: OutcomeFailure, Error: "Code: 503"},
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %v audit events but got %v", len(expected), len(lines))
+	}
+	for i, line := range lines {
+		var event AuditEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Errorf("Test %v Failed: Cannot decode event - %v", i, err)
+			continue
+		}
+		if event.Time.IsZero() {
+			t.Errorf("Test %v Failed: Expected a timestamp", i)
+		}
+		if i == 4 {
+			expected[i].Caller = event.Caller
+		}
+		event.Time = expected[i].Time
+		event.Latency = 0
+		if event != expected[i] {
+			t.Errorf("Test %v Failed: Expected event %+v but got %+v", i, expected[i], event)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"encoding/json"
+)
+
+// EventPublisher sends a message to a subject or topic on an event bus.
+// This module does not depend on any bus client; a NATS connection's
+// Publish method already has this signature, and a Kafka producer needs
+// only a small wrapper.
+type EventPublisher interface {
+	Publish(subject string, data []byte) error
+}
+
+// EventPublisherFunc adapts a plain function to an EventPublisher.
+type EventPublisherFunc func(subject string, data []byte) error
+
+func (f EventPublisherFunc) Publish(subject string, data []byte) error {
+	return f(subject, data)
+}
+
+// BusNotifier is a ChangeNotifier publishing each ChangeEvent as JSON on an
+// event bus, on the subject "<Prefix>.<operation>". Publish is called on the
+// write path so it should only hand the message to the client's buffer.
+type BusNotifier struct {
+	Publisher EventPublisher
+	Prefix    string
+	// Called when an event could not be published.
+	OnError func(event ChangeEvent, err error)
+}
+
+// Create a new BusNotifier publishing below the subject prefix.
+func NewBusNotifier(publisher EventPublisher, prefix string) *BusNotifier {
+	return &BusNotifier{
+		Publisher: publisher,
+		Prefix:    prefix,
+	}
+}
+
+func (bn *BusNotifier) Notify(event ChangeEvent) {
+	data, err := json.Marshal(event)
+	if err == nil {
+		err = bn.Publisher.Publish(bn.Prefix+"."+event.Operation, data)
+	}
+	if err != nil && bn.OnError != nil {
+		bn.OnError(event, err)
+	}
+}
@@ -0,0 +1,65 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestBusNotifier(t *testing.T) {
+	var subjects []string
+	var events []ChangeEvent
+	publisher := EventPublisherFunc(func(subject string, data []byte) error {
+		var event ChangeEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return err
+		}
+		subjects = append(subjects, subject)
+		events = append(events, event)
+		return nil
+	})
+	na := NewNotifyingAdapter(newMemStore(), NewBusNotifier(publisher, "hms.securestorage"))
+
+	na.Store("x0c0s1b0", creds{Password: "pw"})
+	na.Delete("x0c0s1b0")
+
+	expected := []string{"hms.securestorage.store", "hms.securestorage.delete"}
+	if len(subjects) != 2 || subjects[0] != expected[0] || subjects[1] != expected[1] {
+		t.Errorf("Expected subjects %v but got %v", expected, subjects)
+	}
+	if len(events) != 2 || events[0].Key != "x0c0s1b0" {
+		t.Errorf("Expected events for x0c0s1b0 but got %+v", events)
+	}
+
+	var failed error
+	bn := NewBusNotifier(EventPublisherFunc(func(string, []byte) error {
+		return errors.New("nats: connection closed")
+	}), "hms")
+	bn.OnError = func(event ChangeEvent, err error) { failed = err }
+	bn.Notify(ChangeEvent{Key: "x0c0s1b0", Operation: OpStore})
+	if failed == nil {
+		t.Errorf("Expected the publish error to be reported")
+	}
+}
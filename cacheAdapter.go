@@ -0,0 +1,256 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CacheAdapter keeps the results of Lookup() in memory so repeated lookups
+// of the same key do not go to the backend. Each entry expires TTL after it
+// was read; TTLFunc can be set to give individual keys a different TTL.
+// Once MaxEntries is reached the least recently used entry is evicted.
+// Store() and Delete() invalidate the cached entry for their key, and a
+// lookup that was reading the key from the backend at the time does not
+// cache what it read.
+//
+// Missing keys are not cached unless NegativeTTL is set, in which case a
+// lookup that finds nothing is remembered for NegativeTTL. Keep it short,
+// since a key created by another client is not seen until the entry
+// expires; writes through this adapter invalidate it at once, including
+// while the lookup is in flight.
+type CacheAdapter struct {
+	Inner       SecureStorage
+	TTL         time.Duration
+	MaxEntries  int
+	NegativeTTL time.Duration
+	// Optional. Returns the TTL for key; a value <= 0 disables caching for
+	// that key.
+	TTLFunc func(key string) time.Duration
+
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[string]*list.Element
+	fills   map[string]*cacheFill
+	epoch   uint64
+	now     func() time.Time
+}
+
+type cacheEntry struct {
+	key     string
+	value   map[string]interface{}
+	expires time.Time
+}
+
+// cacheFill tracks the lookups of a key that are reading the backend. gen
+// is bumped whenever the key is invalidated while they run, so they do not
+// cache a value read before the invalidation.
+type cacheFill struct {
+	lookups int
+	gen     uint64
+}
+
+// Create a new CacheAdapter. A maxEntries <= 0 does not bound the cache.
+func NewCacheAdapter(inner SecureStorage, ttl time.Duration, maxEntries int) *CacheAdapter {
+	return &CacheAdapter{
+		Inner:      inner,
+		TTL:        ttl,
+		MaxEntries: maxEntries,
+		lru:        list.New(),
+		entries:    map[string]*list.Element{},
+		fills:      map[string]*cacheFill{},
+		now:        time.Now,
+	}
+}
+
+func (ca *CacheAdapter) keyTTL(key string) time.Duration {
+	if ca.TTLFunc != nil {
+		return ca.TTLFunc(key)
+	}
+	return ca.TTL
+}
+
+// Get a cached value, which is nil for a cached missing key. Must be
+// called with ca.mu held.
+func (ca *CacheAdapter) get(key string) (map[string]interface{}, bool) {
+	elem, ok := ca.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if !ca.now().Before(entry.expires) {
+		ca.remove(elem)
+		return nil, false
+	}
+	ca.lru.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Add a value to the cache for ttl. Must be called with ca.mu held.
+func (ca *CacheAdapter) put(key string, value map[string]interface{}, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	entry := &cacheEntry{
+		key:     key,
+		value:   value,
+		expires: ca.now().Add(ttl),
+	}
+	if elem, ok := ca.entries[key]; ok {
+		elem.Value = entry
+		ca.lru.MoveToFront(elem)
+		return
+	}
+	ca.entries[key] = ca.lru.PushFront(entry)
+	for ca.MaxEntries > 0 && ca.lru.Len() > ca.MaxEntries {
+		ca.remove(ca.lru.Back())
+	}
+}
+
+// Start a backend read of key, returning its fill and the generation and
+// epoch to pass to endFill(). Must be called with ca.mu held.
+func (ca *CacheAdapter) startFill(key string) (*cacheFill, uint64, uint64) {
+	fill, ok := ca.fills[key]
+	if !ok {
+		fill = &cacheFill{}
+		ca.fills[key] = fill
+	}
+	fill.lookups++
+	return fill, fill.gen, ca.epoch
+}
+
+// Finish a backend read of key, returning true if the key was not
+// invalidated since startFill() so the value read may be cached. Must be
+// called with ca.mu held.
+func (ca *CacheAdapter) endFill(key string, fill *cacheFill, gen uint64, epoch uint64) bool {
+	fill.lookups--
+	if fill.lookups == 0 {
+		delete(ca.fills, key)
+	}
+	return fill.gen == gen && ca.epoch == epoch
+}
+
+// Must be called with ca.mu held.
+func (ca *CacheAdapter) remove(elem *list.Element) {
+	ca.lru.Remove(elem)
+	delete(ca.entries, elem.Value.(*cacheEntry).key)
+}
+
+// Drop the cached entry for key, if any.
+func (ca *CacheAdapter) Invalidate(key string) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	if elem, ok := ca.entries[key]; ok {
+		ca.remove(elem)
+	}
+	if fill, ok := ca.fills[key]; ok {
+		fill.gen++
+	}
+}
+
+// Drop every cached entry.
+func (ca *CacheAdapter) InvalidateAll() {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	ca.lru.Init()
+	ca.entries = map[string]*list.Element{}
+	ca.epoch++
+}
+
+// Get the number of cached entries, including any that have expired but
+// not yet been evicted.
+func (ca *CacheAdapter) Len() int {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	return ca.lru.Len()
+}
+
+func (ca *CacheAdapter) Store(key string, value interface{}) error {
+	defer ca.Invalidate(key)
+	return ca.Inner.Store(key, value)
+}
+
+func (ca *CacheAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	defer ca.Invalidate(key)
+	return ca.Inner.StoreWithData(key, value, output)
+}
+
+// Read a value from the cache, or from the backend if it is not cached.
+// Missing keys are cached only if NegativeTTL is set.
+func (ca *CacheAdapter) Lookup(key string, output interface{}) error {
+	if output == nil {
+		return fmt.Errorf("output interface was nil")
+	}
+
+	ca.mu.Lock()
+	data, ok := ca.get(key)
+	if ok {
+		ca.mu.Unlock()
+		if data == nil {
+			return nil
+		}
+		return decodeValue(data, output)
+	}
+	fill, gen, epoch := ca.startFill(key)
+	ca.mu.Unlock()
+
+	err := ca.Inner.Lookup(key, &data)
+	ca.mu.Lock()
+	current := ca.endFill(key, fill, gen, epoch)
+	if err != nil {
+		ca.mu.Unlock()
+		return err
+	}
+	if current && data == nil {
+		ca.put(key, nil, ca.NegativeTTL)
+	} else if current {
+		ca.put(key, data, ca.keyTTL(key))
+	}
+	ca.mu.Unlock()
+	if data == nil {
+		return nil
+	}
+	return decodeValue(data, output)
+}
+
+func (ca *CacheAdapter) Delete(key string) error {
+	defer ca.Invalidate(key)
+	return ca.Inner.Delete(key)
+}
+
+func (ca *CacheAdapter) LookupKeys(keyPath string) ([]string, error) {
+	return ca.Inner.LookupKeys(keyPath)
+}
+
+// Drop every cached entry and close the wrapped store.
+func (ca *CacheAdapter) Close() error {
+	ca.InvalidateAll()
+	return Close(ca.Inner)
+}
+
+func (ca *CacheAdapter) Health() error {
+	return Health(ca.Inner)
+}
@@ -0,0 +1,260 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"testing"
+	"time"
+)
+
+// countingStore counts the lookups that reach the wrapped memStore.
+type countingStore struct {
+	*memStore
+	lookups int
+}
+
+func (cs *countingStore) Lookup(key string, output interface{}) error {
+	cs.lookups++
+	return cs.memStore.Lookup(key, output)
+}
+
+// pausingStore holds each lookup after it has read the wrapped memStore
+// until release is closed.
+type pausingStore struct {
+	*memStore
+	read    chan struct{}
+	release chan struct{}
+}
+
+func (ps *pausingStore) Lookup(key string, output interface{}) error {
+	err := ps.memStore.Lookup(key, output)
+	ps.read <- struct{}{}
+	<-ps.release
+	return err
+}
+
+func TestCacheAdapter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cs := &countingStore{memStore: newMemStore()}
+	for _, key := range []string{"a", "b", "c", "nocache"} {
+		cs.memStore.Store(key, map[string]interface{}{"Username": key})
+	}
+	ca := NewCacheAdapter(cs, time.Minute, 2)
+	ca.TTLFunc = func(key string) time.Duration {
+		if key == "nocache" {
+			return 0
+		}
+		return time.Minute
+	}
+	ca.now = func() time.Time { return now }
+
+	var tests = []struct {
+		op      string
+		key     string
+		advance time.Duration
+		lookups int
+	}{
+		{op: "lookup", key: "a", lookups: 1},
+		{op: "lookup", key: "a", lookups: 1},
+		{op: "lookup", key: "a", advance: 2 * time.Minute, lookups: 2},
+		{op: "lookup", key: "b", lookups: 3},
+		{op: "lookup", key: "c", lookups: 4},
+		{op: "lookup", key: "a", lookups: 5},
+		{op: "lookup", key: "c", lookups: 5},
+		{op: "store", key: "c", lookups: 5},
+		{op: "lookup", key: "c", lookups: 6},
+		{op: "invalidate", key: "c", lookups: 6},
+		{op: "lookup", key: "c", lookups: 7},
+		{op: "lookup", key: "nocache", lookups: 8},
+		{op: "lookup", key: "nocache", lookups: 9},
+		{op: "lookup", key: "missing", lookups: 10},
+		{op: "lookup", key: "missing", lookups: 11},
+	}
+
+	for i, test := range tests {
+		now = now.Add(test.advance)
+		switch test.op {
+		case "lookup":
+			var r creds
+			if err := ca.Lookup(test.key, &r); err != nil {
+				t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+			}
+			if test.key != "missing" && r.Username != test.key {
+				t.Errorf("Test %v Failed: Expected username %v but got %v", i, test.key, r.Username)
+			}
+		case "store":
+			ca.Store(test.key, map[string]interface{}{"Username": test.key})
+		case "invalidate":
+			ca.Invalidate(test.key)
+		}
+		if cs.lookups != test.lookups {
+			t.Errorf("Test %v Failed: Expected %v backend lookups but got %v", i, test.lookups, cs.lookups)
+		}
+		if ca.Len() > 2 {
+			t.Errorf("Test %v Failed: Cache grew to %v entries", i, ca.Len())
+		}
+	}
+}
+
+func TestCacheAdapterNegative(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cs := &countingStore{memStore: newMemStore()}
+	ca := NewCacheAdapter(cs, time.Minute, 0)
+	ca.NegativeTTL = 10 * time.Second
+	ca.now = func() time.Time { return now }
+
+	var tests = []struct {
+		op       string
+		advance  time.Duration
+		username string
+		lookups  int
+	}{
+		{op: "lookup", lookups: 1},
+		{op: "lookup", advance: 5 * time.Second, lookups: 1},
+		{op: "lookup", advance: 5 * time.Second, lookups: 2},
+		{op: "lookup", lookups: 2},
+		{op: "store", lookups: 2},
+		{op: "lookup", username: "root", lookups: 3},
+		{op: "lookup", username: "root", lookups: 3},
+		{op: "delete", lookups: 3},
+		{op: "lookup", lookups: 4},
+		{op: "lookup", lookups: 4},
+	}
+
+	for i, test := range tests {
+		now = now.Add(test.advance)
+		switch test.op {
+		case "lookup":
+			var r creds
+			if err := ca.Lookup("x0c0s1b0", &r); err != nil {
+				t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+			}
+			if r.Username != test.username {
+				t.Errorf("Test %v Failed: Expected username '%v' but got '%v'", i, test.username, r.Username)
+			}
+		case "store":
+			ca.Store("x0c0s1b0", map[string]interface{}{"Username": "root"})
+		case "delete":
+			ca.Delete("x0c0s1b0")
+		}
+		if cs.lookups != test.lookups {
+			t.Errorf("Test %v Failed: Expected %v backend lookups but got %v", i, test.lookups, cs.lookups)
+		}
+	}
+}
+
+func TestCacheAdapterConcurrentStore(t *testing.T) {
+	ms := newMemStore()
+	ms.Store("x0c0s1b0", map[string]interface{}{"Username": "old"})
+
+	var tests = []struct {
+		op string
+	}{
+		{op: "store"},
+		{op: "delete"},
+		{op: "invalidate"},
+		{op: "invalidateAll"},
+	}
+
+	for i, test := range tests {
+		ms.Store("x0c0s1b0", map[string]interface{}{"Username": "old"})
+		ps := &pausingStore{memStore: ms, read: make(chan struct{}), release: make(chan struct{})}
+		ca := NewCacheAdapter(ps, time.Minute, 0)
+
+		// A lookup reads the old value, then the key is changed before
+		// the lookup caches it.
+		done := make(chan error)
+		go func() {
+			var r creds
+			done <- ca.Lookup("x0c0s1b0", &r)
+		}()
+		<-ps.read
+		switch test.op {
+		case "store":
+			ms.Store("x0c0s1b0", map[string]interface{}{"Username": "new"})
+			ca.Invalidate("x0c0s1b0")
+		case "delete":
+			ms.Delete("x0c0s1b0")
+			ca.Invalidate("x0c0s1b0")
+		case "invalidate":
+			ca.Invalidate("x0c0s1b0")
+		case "invalidateAll":
+			ca.InvalidateAll()
+		}
+		close(ps.release)
+		if err := <-done; err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		if ca.Len() != 0 {
+			t.Errorf("Test %v Failed: Expected the value read before %v not to be cached", i, test.op)
+		}
+	}
+}
+
+func TestCacheAdapterNegativeConcurrentStore(t *testing.T) {
+	var tests = []struct {
+		op string
+	}{
+		{op: "store"},
+		{op: "storeWithData"},
+		{op: "invalidate"},
+	}
+
+	for i, test := range tests {
+		ms := newMemStore()
+		ps := &pausingStore{memStore: ms, read: make(chan struct{}), release: make(chan struct{})}
+		ca := NewCacheAdapter(ps, time.Minute, 0)
+		ca.NegativeTTL = time.Minute
+
+		// A lookup finds nothing, then the key is created before the
+		// lookup caches the miss.
+		done := make(chan error)
+		go func() {
+			var r creds
+			done <- ca.Lookup("x0c0s1b0", &r)
+		}()
+		<-ps.read
+		switch test.op {
+		case "store":
+			err := ca.Store("x0c0s1b0", map[string]interface{}{"Username": "root"})
+			if err != nil {
+				t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+			}
+		case "storeWithData":
+			err := ca.StoreWithData("x0c0s1b0", map[string]interface{}{"Username": "root"}, nil)
+			if err != nil {
+				t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+			}
+		case "invalidate":
+			ms.Store("x0c0s1b0", map[string]interface{}{"Username": "root"})
+			ca.Invalidate("x0c0s1b0")
+		}
+		close(ps.release)
+		if err := <-done; err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		if ca.Len() != 0 {
+			t.Errorf("Test %v Failed: Expected the miss read before %v not to be cached", i, test.op)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ErrInjected is returned (wrapped with the operation and key) by
+// ChaosAdapter when it injects a failure.
+var ErrInjected = errors.New("injected fault")
+
+// Fault describes the faults ChaosAdapter injects into one operation type.
+// Rates are probabilities between 0 and 1.
+type Fault struct {
+	// Added before every call, plus a random amount up to Jitter.
+	Latency time.Duration
+	Jitter  time.Duration
+	// Fail the call without reaching the wrapped store.
+	ErrorRate float64
+	// Reach the wrapped store but still report a failure, as when a write
+	// is applied and the response is lost. LookupKeys instead returns only
+	// part of the listing.
+	PartialRate float64
+	// Error to return; defaults to ErrInjected.
+	Err error
+}
+
+// ChaosAdapter injects latency and failures into the operations of a
+// wrapped SecureStorage, so services can test how they cope with a flaky
+// secret store. Faults are keyed by operation (OpStore, OpLookup, ...); the
+// random source is seeded so failures are reproducible.
+type ChaosAdapter struct {
+	Inner  SecureStorage
+	Faults map[string]Fault
+
+	mu    sync.Mutex
+	rand  *rand.Rand
+	sleep func(time.Duration)
+}
+
+// Create a new ChaosAdapter wrapping store. No faults are injected until
+// they are added to Faults.
+func NewChaosAdapter(store SecureStorage, seed int64) *ChaosAdapter {
+	return &ChaosAdapter{
+		Inner:  store,
+		Faults: map[string]Fault{},
+		rand:   rand.New(rand.NewSource(seed)),
+		sleep:  time.Sleep,
+	}
+}
+
+// Roll the dice for one call, sleeping for any latency. The first return is
+// true if the call should fail outright, the second if it should partially
+// fail.
+func (ca *ChaosAdapter) inject(op string) (bool, bool) {
+	fault, ok := ca.Faults[op]
+	if !ok {
+		return false, false
+	}
+	ca.mu.Lock()
+	delay := fault.Latency
+	if fault.Jitter > 0 {
+		delay += time.Duration(ca.rand.Int63n(int64(fault.Jitter)))
+	}
+	fail := ca.rand.Float64() < fault.ErrorRate
+	partial := !fail && ca.rand.Float64() < fault.PartialRate
+	ca.mu.Unlock()
+
+	if delay > 0 {
+		ca.sleep(delay)
+	}
+	return fail, partial
+}
+
+func (ca *ChaosAdapter) faultErr(op string, key string) error {
+	err := ca.Faults[op].Err
+	if err == nil {
+		err = ErrInjected
+	}
+	return fmt.Errorf("%w: %s %s", err, op, key)
+}
+
+func (ca *ChaosAdapter) Store(key string, value interface{}) error {
+	fail, partial := ca.inject(OpStore)
+	if fail {
+		return ca.faultErr(OpStore, key)
+	}
+	err := ca.Inner.Store(key, value)
+	if err == nil && partial {
+		err = ca.faultErr(OpStore, key)
+	}
+	return err
+}
+
+func (ca *ChaosAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	fail, partial := ca.inject(OpStoreWithData)
+	if fail {
+		return ca.faultErr(OpStoreWithData, key)
+	}
+	err := ca.Inner.StoreWithData(key, value, output)
+	if err == nil && partial {
+		err = ca.faultErr(OpStoreWithData, key)
+	}
+	return err
+}
+
+func (ca *ChaosAdapter) Lookup(key string, output interface{}) error {
+	fail, partial := ca.inject(OpLookup)
+	if fail || partial {
+		return ca.faultErr(OpLookup, key)
+	}
+	return ca.Inner.Lookup(key, output)
+}
+
+func (ca *ChaosAdapter) Delete(key string) error {
+	fail, partial := ca.inject(OpDelete)
+	if fail {
+		return ca.faultErr(OpDelete, key)
+	}
+	err := ca.Inner.Delete(key)
+	if err == nil && partial {
+		err = ca.faultErr(OpDelete, key)
+	}
+	return err
+}
+
+func (ca *ChaosAdapter) LookupKeys(keyPath string) ([]string, error) {
+	fail, partial := ca.inject(OpLookupKeys)
+	if fail {
+		return nil, ca.faultErr(OpLookupKeys, keyPath)
+	}
+	keys, err := ca.Inner.LookupKeys(keyPath)
+	if err == nil && partial && len(keys) > 0 {
+		ca.mu.Lock()
+		n := ca.rand.Intn(len(keys))
+		ca.mu.Unlock()
+		keys = keys[:n]
+	}
+	return keys, err
+}
+
+func (ca *ChaosAdapter) Health() error {
+	return Health(ca.Inner)
+}
+
+func (ca *ChaosAdapter) Close() error {
+	return Close(ca.Inner)
+}
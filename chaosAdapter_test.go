@@ -0,0 +1,94 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestChaosAdapter(t *testing.T) {
+	var tests = []struct {
+		fault     Fault
+		failures  int
+		stored    int
+		latencies time.Duration
+	}{
+		{fault: Fault{}, failures: 0, stored: 100},
+		{fault: Fault{ErrorRate: 1}, failures: 100, stored: 0},
+		{fault: Fault{PartialRate: 1}, failures: 100, stored: 100},
+		{fault: Fault{Latency: time.Millisecond}, failures: 0, stored: 100, latencies: 100 * time.Millisecond},
+	}
+
+	for i, test := range tests {
+		store := newMemStore()
+		ca := NewChaosAdapter(store, 1)
+		var slept time.Duration
+		ca.sleep = func(d time.Duration) { slept += d }
+		ca.Faults[OpStore] = test.fault
+
+		failures := 0
+		for n := 0; n < 100; n++ {
+			err := ca.Store(fmt.Sprintf("x0c0/s%db0", n), creds{Password: "pw"})
+			if err != nil {
+				if !errors.Is(err, ErrInjected) {
+					t.Errorf("Test %v Failed: Expected ErrInjected but got %v", i, err)
+				}
+				failures++
+			}
+		}
+		keys, _ := store.LookupKeys("x0c0")
+		if failures != test.failures || len(keys) != test.stored || slept != test.latencies {
+			t.Errorf("Test %v Failed: Expected %d failures, %d stored, %v latency but got %d, %d, %v",
+				i, test.failures, test.stored, test.latencies, failures, len(keys), slept)
+		}
+	}
+
+	// With a 50% error rate some calls fail and some succeed, and the same
+	// seed gives the same sequence.
+	sequence := func() []bool {
+		ca := NewChaosAdapter(newMemStore(), 42)
+		ca.Faults[OpLookup] = Fault{ErrorRate: 0.5}
+		var results []bool
+		for n := 0; n < 50; n++ {
+			var r creds
+			results = append(results, ca.Lookup("x0c0s1b0", &r) == nil)
+		}
+		return results
+	}
+	first, second := sequence(), sequence()
+	failed := 0
+	for n := range first {
+		if first[n] != second[n] {
+			t.Fatalf("Expected the same sequence of failures for the same seed")
+		}
+		if !first[n] {
+			failed++
+		}
+	}
+	if failed == 0 || failed == len(first) {
+		t.Errorf("Expected some but not all lookups to fail but %d of %d failed", failed, len(first))
+	}
+}
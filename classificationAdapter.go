@@ -0,0 +1,167 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrClassification is returned (wrapped) when a value's classification is
+// not allowed in a store.
+var ErrClassification = errors.New("classification not allowed")
+
+// Classifications, from least to most sensitive
+const (
+	ClassPublic   = "public"
+	ClassInternal = "internal"
+	ClassSecret   = "secret"
+	ClassCritical = "critical"
+)
+
+var classificationRank = map[string]int{
+	ClassPublic:   0,
+	ClassInternal: 1,
+	ClassSecret:   2,
+	ClassCritical: 3,
+}
+
+// Default value field holding a value's classification
+const DefaultClassificationField = "classification"
+
+// ClassificationAdapter only lets values up to classification Max into the
+// wrapped store, so for example critical credentials never reach a local
+// cache. A value's classification is read from its Field, or is Default if
+// it has none, and is written to Field so it travels with the value when
+// it is copied or migrated. Policy, if set, is called after the Max check
+// and may reject a write by returning an error. Name identifies the store
+// in errors. Only writes through the adapter are checked, so the
+// destination of CopyBetween() or a Migrator must be the adapter, not its
+// Inner store.
+type ClassificationAdapter struct {
+	Inner   SecureStorage
+	Name    string
+	Max     string
+	Field   string
+	Default string
+	Policy  func(key string, classification string) error
+}
+
+// Create a new ClassificationAdapter wrapping inner and allowing values up
+// to classification max. Values without a classification are treated as
+// secret.
+func NewClassificationAdapter(inner SecureStorage, name string, max string) *ClassificationAdapter {
+	return &ClassificationAdapter{
+		Inner:   inner,
+		Name:    name,
+		Max:     max,
+		Field:   DefaultClassificationField,
+		Default: ClassSecret,
+	}
+}
+
+// Get the classification of a value as stored by a ClassificationAdapter.
+func Classification(value map[string]interface{}, field string) (string, error) {
+	class, ok := value[field]
+	if !ok || class == nil {
+		return "", nil
+	}
+	s, ok := class.(string)
+	if _, known := classificationRank[s]; !ok || !known {
+		return "", fmt.Errorf("%w: unknown classification %v", ErrValidation, class)
+	}
+	return s, nil
+}
+
+// Check value against the policy, returning it with its classification
+// set.
+func (ca *ClassificationAdapter) classify(key string, value interface{}) (map[string]interface{}, error) {
+	data, err := toMap(value)
+	if err != nil {
+		return nil, err
+	}
+	class, err := Classification(data, ca.Field)
+	if err != nil {
+		return nil, fmt.Errorf("%w for %s", err, key)
+	}
+	if class == "" {
+		class = ca.Default
+	}
+	rank, ok := classificationRank[class]
+	max, maxOk := classificationRank[ca.Max]
+	if !ok || !maxOk {
+		return nil, fmt.Errorf("Invalid classification policy for %s: %q/%q", ca.Name, class, ca.Max)
+	}
+	if rank > max {
+		return nil, fmt.Errorf("%w: %s values such as %s may not be stored in %s", ErrClassification, class, key, ca.Name)
+	}
+	if ca.Policy != nil {
+		err = ca.Policy(key, class)
+		if err != nil {
+			if errors.Is(err, ErrClassification) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %s in %s: %v", ErrClassification, key, ca.Name, err)
+		}
+	}
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	data[ca.Field] = class
+	return data, nil
+}
+
+func (ca *ClassificationAdapter) Store(key string, value interface{}) error {
+	data, err := ca.classify(key, value)
+	if err != nil {
+		return err
+	}
+	return ca.Inner.Store(key, data)
+}
+
+func (ca *ClassificationAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	data, err := ca.classify(key, value)
+	if err != nil {
+		return err
+	}
+	return ca.Inner.StoreWithData(key, data, output)
+}
+
+func (ca *ClassificationAdapter) Lookup(key string, output interface{}) error {
+	return ca.Inner.Lookup(key, output)
+}
+
+func (ca *ClassificationAdapter) Delete(key string) error {
+	return ca.Inner.Delete(key)
+}
+
+func (ca *ClassificationAdapter) LookupKeys(keyPath string) ([]string, error) {
+	return ca.Inner.LookupKeys(keyPath)
+}
+
+func (ca *ClassificationAdapter) Close() error {
+	return Close(ca.Inner)
+}
+
+func (ca *ClassificationAdapter) Health() error {
+	return Health(ca.Inner)
+}
@@ -0,0 +1,112 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type classifiedCreds struct {
+	Username       string
+	Password       string
+	Classification string `mapstructure:"classification,omitempty"`
+}
+
+func TestClassificationAdapter(t *testing.T) {
+	ms := newMemStore()
+	ca := NewClassificationAdapter(ms, "local-cache", ClassSecret)
+	ca.Policy = func(key string, class string) error {
+		if strings.HasPrefix(key, "public/") && class != ClassPublic {
+			return fmt.Errorf("only public values belong below public/")
+		}
+		return nil
+	}
+
+	var tests = []struct {
+		key     string
+		value   interface{}
+		class   string
+		respErr error
+	}{
+		{key: "bmc/x0c0s1b0", value: classifiedCreds{Username: "root", Password: "pw"}, class: ClassSecret},
+		{key: "bmc/x0c0s2b0", value: classifiedCreds{Username: "root", Classification: ClassInternal}, class: ClassInternal},
+		{key: "bmc/x0c0s3b0", value: classifiedCreds{Username: "root", Classification: ClassCritical}, respErr: ErrClassification},
+		{key: "bmc/x0c0s4b0", value: classifiedCreds{Username: "root", Classification: "top-secret"}, respErr: ErrValidation},
+		{key: "public/motd", value: map[string]interface{}{"text": "hi", "classification": ClassPublic}, class: ClassPublic},
+		{key: "public/other", value: map[string]interface{}{"text": "hi"}, respErr: ErrClassification},
+	}
+
+	for i, test := range tests {
+		err := ca.Store(test.key, test.value)
+		if test.respErr != nil {
+			if !errors.Is(err, test.respErr) {
+				t.Errorf("Test %v Failed: Expected %v but got %v", i, test.respErr, err)
+			}
+			var r map[string]interface{}
+			ms.Lookup(test.key, &r)
+			if r != nil {
+				t.Errorf("Test %v Failed: Rejected value was written", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+			continue
+		}
+		var r map[string]interface{}
+		ca.Lookup(test.key, &r)
+		if class, err := Classification(r, DefaultClassificationField); err != nil || class != test.class {
+			t.Errorf("Test %v Failed: Expected classification %v but got %v (%v)", i, test.class, class, err)
+		}
+	}
+
+	// Migrations into a restricted store skip values it may not hold.
+	vault := newMemStore()
+	vault.Store("bmc/x0c0s1b0", classifiedCreds{Username: "root", Classification: ClassCritical})
+	vault.Store("bmc/x0c0s2b0", classifiedCreds{Username: "root", Classification: ClassInternal})
+	result, err := NewMigrator(vault, NewClassificationAdapter(newMemStore(), "local-cache", ClassInternal), "bmc").Run()
+	if err == nil || result == nil {
+		t.Fatalf("Expected an incomplete migration but got %+v (%v)", result, err)
+	}
+	if result.Copied != 1 || len(result.Failed) != 1 || !errors.Is(result.Failed["bmc/x0c0s1b0"], ErrClassification) {
+		t.Errorf("Unexpected migration result %+v", result)
+	}
+
+	// Critical values are kept out of a store allowing secrets.
+	cache := newMemStore()
+	result, err = NewMigrator(vault, NewClassificationAdapter(cache, "local-cache", ClassSecret), "bmc").Run()
+	if err == nil || result == nil || !errors.Is(result.Failed["bmc/x0c0s1b0"], ErrClassification) {
+		t.Errorf("Expected ErrClassification migrating a critical value but got %+v (%v)", result, err)
+	}
+	var r map[string]interface{}
+	cache.Lookup("bmc/x0c0s1b0", &r)
+	if r != nil {
+		t.Errorf("Critical value was migrated: %v", r)
+	}
+	err = CopyBetween(vault, "bmc/x0c0s1b0", NewClassificationAdapter(cache, "local-cache", ClassSecret), "bmc/x0c0s1b0")
+	if !errors.Is(err, ErrClassification) {
+		t.Errorf("Expected ErrClassification copying a critical value but got %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"fmt"
+	"sync"
+)
+
+// CoalescingAdapter merges concurrent Lookup() calls for the same key into
+// a single read of the backend, so a burst of goroutines asking for one
+// key at service startup costs Vault one request. Every caller gets its
+// own copy of the result. Store() and Delete() detach any read in flight
+// for their key, so lookups made after a write never see the value from
+// before it.
+type CoalescingAdapter struct {
+	Inner SecureStorage
+
+	mu       sync.Mutex
+	inflight map[string]*lookupCall
+}
+
+type lookupCall struct {
+	done chan struct{}
+	data map[string]interface{}
+	err  error
+}
+
+// Create a new CoalescingAdapter.
+func NewCoalescingAdapter(inner SecureStorage) *CoalescingAdapter {
+	return &CoalescingAdapter{
+		Inner:    inner,
+		inflight: map[string]*lookupCall{},
+	}
+}
+
+// Stop new lookups of key from joining the read in flight, if any.
+func (ca *CoalescingAdapter) forget(key string) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	delete(ca.inflight, key)
+}
+
+func (ca *CoalescingAdapter) Store(key string, value interface{}) error {
+	defer ca.forget(key)
+	return ca.Inner.Store(key, value)
+}
+
+func (ca *CoalescingAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	defer ca.forget(key)
+	return ca.Inner.StoreWithData(key, value, output)
+}
+
+// Read a value from the backend, joining a read of the same key already in
+// flight if there is one.
+func (ca *CoalescingAdapter) Lookup(key string, output interface{}) error {
+	if output == nil {
+		return fmt.Errorf("output interface was nil")
+	}
+
+	ca.mu.Lock()
+	call, ok := ca.inflight[key]
+	if ok {
+		ca.mu.Unlock()
+		<-call.done
+	} else {
+		// Callers that joined get this error if the read panics.
+		call = &lookupCall{
+			done: make(chan struct{}),
+			err:  fmt.Errorf("Lookup of %s was aborted", key),
+		}
+		ca.inflight[key] = call
+		ca.mu.Unlock()
+		ca.read(key, call)
+	}
+
+	if call.err != nil || call.data == nil {
+		return call.err
+	}
+	return decodeValue(call.data, output)
+}
+
+// Read key from the backend for call, then release the callers waiting
+// for it, even if the backend panics.
+func (ca *CoalescingAdapter) read(key string, call *lookupCall) {
+	defer func() {
+		ca.mu.Lock()
+		if ca.inflight[key] == call {
+			delete(ca.inflight, key)
+		}
+		ca.mu.Unlock()
+		close(call.done)
+	}()
+	var data map[string]interface{}
+	err := ca.Inner.Lookup(key, &data)
+	call.data, call.err = data, err
+}
+
+func (ca *CoalescingAdapter) Delete(key string) error {
+	defer ca.forget(key)
+	return ca.Inner.Delete(key)
+}
+
+func (ca *CoalescingAdapter) LookupKeys(keyPath string) ([]string, error) {
+	return ca.Inner.LookupKeys(keyPath)
+}
+
+func (ca *CoalescingAdapter) Close() error {
+	return Close(ca.Inner)
+}
+
+func (ca *CoalescingAdapter) Health() error {
+	return Health(ca.Inner)
+}
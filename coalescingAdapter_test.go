@@ -0,0 +1,205 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// gatedStore blocks every Lookup() until release is closed.
+type gatedStore struct {
+	*memStore
+	lookups atomic.Int32
+	release chan struct{}
+}
+
+func (gs *gatedStore) Lookup(key string, output interface{}) error {
+	gs.lookups.Add(1)
+	<-gs.release
+	return gs.memStore.Lookup(key, output)
+}
+
+// panickingStore is a gatedStore whose first Lookup() panics once
+// released.
+type panickingStore struct {
+	*gatedStore
+	once sync.Once
+}
+
+func (ps *panickingStore) Lookup(key string, output interface{}) error {
+	ps.lookups.Add(1)
+	<-ps.release
+	ps.once.Do(func() { panic("backend failure") })
+	return ps.memStore.Lookup(key, output)
+}
+
+// Wait until n lookups have reached the backend.
+func waitForLookups(gs *gatedStore, n int32) {
+	for gs.lookups.Load() < n {
+		runtime.Gosched()
+	}
+}
+
+func TestCoalescingAdapterLookup(t *testing.T) {
+	var tests = []struct {
+		key     string
+		callers int
+		resp    map[string]interface{}
+	}{
+		{
+			key:     "x0c0s1b0",
+			callers: 10,
+			resp:    map[string]interface{}{"Username": "root"},
+		}, {
+			key:     "missing",
+			callers: 5,
+			resp:    map[string]interface{}{},
+		},
+	}
+
+	for i, test := range tests {
+		gs := &gatedStore{memStore: newMemStore(), release: make(chan struct{})}
+		gs.memStore.Store("x0c0s1b0", map[string]interface{}{"Username": "root"})
+		ca := NewCoalescingAdapter(gs)
+
+		results := make([]map[string]interface{}, test.callers)
+		errs := make([]error, test.callers)
+		var started, wg sync.WaitGroup
+		for c := 0; c < test.callers; c++ {
+			started.Add(1)
+			wg.Add(1)
+			go func(c int) {
+				defer wg.Done()
+				results[c] = map[string]interface{}{}
+				started.Done()
+				errs[c] = ca.Lookup(test.key, &results[c])
+			}(c)
+		}
+		// Give every caller time to join the read before it completes.
+		started.Wait()
+		waitForLookups(gs, 1)
+		time.Sleep(10 * time.Millisecond)
+		close(gs.release)
+		wg.Wait()
+
+		if n := gs.lookups.Load(); n < 1 || int(n) >= test.callers {
+			t.Errorf("Test %v Failed: Expected the lookups to be coalesced, got %v backend lookups", i, n)
+		}
+		for c := range results {
+			if errs[c] != nil {
+				t.Errorf("Test %v Failed: Unexpected error - %v", i, errs[c])
+			}
+			if len(results[c]) != len(test.resp) || results[c]["Username"] != test.resp["Username"] {
+				t.Errorf("Test %v Failed: Expected %v, got %v", i, test.resp, results[c])
+			}
+		}
+		// Callers must not share the decoded map.
+		if len(results) > 1 && len(results[0]) > 0 {
+			results[0]["Username"] = "changed"
+			if results[1]["Username"] == "changed" {
+				t.Errorf("Test %v Failed: Callers share one result", i)
+			}
+		}
+	}
+}
+
+func TestCoalescingAdapterStore(t *testing.T) {
+	gs := &gatedStore{memStore: newMemStore(), release: make(chan struct{})}
+	gs.memStore.Store("x0c0s1b0", map[string]interface{}{"Username": "root"})
+	ca := NewCoalescingAdapter(gs)
+
+	done := make(chan struct{})
+	go func() {
+		var data map[string]interface{}
+		ca.Lookup("x0c0s1b0", &data)
+		close(done)
+	}()
+	waitForLookups(gs, 1)
+
+	// A write must detach the read in flight so later lookups read again.
+	err := ca.Store("x0c0s1b0", map[string]interface{}{"Username": "admin"})
+	if err != nil {
+		t.Fatalf("Test Failed: Unexpected error - %v", err)
+	}
+	var data map[string]interface{}
+	after := make(chan error)
+	go func() {
+		after <- ca.Lookup("x0c0s1b0", &data)
+	}()
+	waitForLookups(gs, 2)
+	close(gs.release)
+	<-done
+
+	err = <-after
+	if err != nil || data["Username"] != "admin" {
+		t.Errorf("Test Failed: Expected the stored value, got %v, %v", data, err)
+	}
+	if n := gs.lookups.Load(); n != 2 {
+		t.Errorf("Test Failed: Expected 2 backend lookups, got %v", n)
+	}
+}
+
+func TestCoalescingAdapterPanic(t *testing.T) {
+	ps := &panickingStore{gatedStore: &gatedStore{memStore: newMemStore(), release: make(chan struct{})}}
+	ps.memStore.Store("x0c0s1b0", map[string]interface{}{"Username": "root"})
+	ca := NewCoalescingAdapter(ps)
+
+	panicked := make(chan interface{})
+	go func() {
+		defer func() {
+			panicked <- recover()
+		}()
+		var data map[string]interface{}
+		ca.Lookup("x0c0s1b0", &data)
+	}()
+	waitForLookups(ps.gatedStore, 1)
+
+	// A caller waiting on the read gets an error instead of hanging.
+	joined := make(chan error)
+	go func() {
+		var data map[string]interface{}
+		joined <- ca.Lookup("x0c0s1b0", &data)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(ps.release)
+	if p := <-panicked; p == nil {
+		t.Errorf("Test Failed: Expected the backend panic to reach the reader")
+	}
+	select {
+	case err := <-joined:
+		if err == nil && ps.lookups.Load() == 1 {
+			t.Errorf("Test Failed: Expected an error for the caller that joined the read")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Test Failed: Caller that joined the read is still waiting")
+	}
+
+	// Later lookups read the backend again.
+	var data map[string]interface{}
+	if err := ca.Lookup("x0c0s1b0", &data); err != nil || data["Username"] != "root" {
+		t.Errorf("Test Failed: Expected the value after the panic, got %v, %v", data, err)
+	}
+}
@@ -0,0 +1,348 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// Backend types understood by NewFromConfig()
+const BackendVault = "vault"
+
+// Env vars read by Config.ReadEnvironment() in addition to the CRAY_VAULT_*
+// and VAULT_* variables described above.
+const EnvBackend = "SECURESTORAGE_BACKEND"
+const EnvBasePath = "SECURESTORAGE_BASE_PATH"
+const EnvRetry = "SECURESTORAGE_RETRY"
+const EnvRole = "SECURESTORAGE_ROLE"
+
+// Config is the single configuration schema for creating a SecureStorage.
+// It can be loaded from a JSON file, the environment, or both. YAML files
+// are not supported.
+//
+//	{
+//	    "backend": "vault",
+//	    "base_path": "secret",
+//	    "retry": 1,
+//	    "auth": {
+//	        "role": "",
+//	        "jwt_file": "/var/run/secrets/kubernetes.io/serviceaccount/token",
+//	        "role_file": "/var/run/secrets/kubernetes.io/serviceaccount/namespace",
+//	        "path": "auth/kubernetes/login"
+//	    },
+//	    "vault": {
+//	        "address": "http://cray-vault.vault:8200",
+//	        "pool": {
+//	            "max_idle_conns": 100,
+//	            "max_idle_conns_per_host": 32,
+//	            "max_conns_per_host": 0,
+//	            "idle_conn_timeout": "90s",
+//	            "disable_http2": false
+//	        }
+//	    },
+//	    "tls": {
+//	        "ca_cert": "",
+//	        "ca_path": "",
+//	        "client_cert": "",
+//	        "client_key": "",
+//	        "server_name": "",
+//	        "insecure": false
+//	    }
+//	}
+type Config struct {
+	Backend  string      `json:"backend"`
+	BasePath string      `json:"base_path"`
+	Retry    int         `json:"retry"`
+	Auth     ConfigAuth  `json:"auth"`
+	Vault    ConfigVault `json:"vault"`
+	TLS      ConfigTLS   `json:"tls"`
+}
+
+// ConfigAuth holds the authentication settings. An empty Role uses the
+// contents of RoleFile. If JWTEnv is set, the JWT is read from that
+// environment variable instead of JWTFile. For Vault's jwt/oidc auth method
+// set Path to "auth/<mount>/login", Role to the Vault role and RoleFile to
+// "".
+type ConfigAuth struct {
+	Role     string `json:"role"`
+	JWTFile  string `json:"jwt_file"`
+	JWTEnv   string `json:"jwt_env,omitempty"`
+	RoleFile string `json:"role_file"`
+	Path     string `json:"path"`
+}
+
+// ConfigVault holds Vault connection settings. An empty Address uses the
+// vault api default (VAULT_ADDR or https://127.0.0.1:8200).
+type ConfigVault struct {
+	Address string     `json:"address"`
+	Pool    ConfigPool `json:"pool"`
+}
+
+// ConfigPool holds the connection pool settings described by PoolConfig.
+// IdleConnTimeout is a duration such as "90s". Zero values keep the vault
+// api defaults.
+type ConfigPool struct {
+	MaxIdleConns        int    `json:"max_idle_conns,omitempty"`
+	MaxIdleConnsPerHost int    `json:"max_idle_conns_per_host,omitempty"`
+	MaxConnsPerHost     int    `json:"max_conns_per_host,omitempty"`
+	IdleConnTimeout     string `json:"idle_conn_timeout,omitempty"`
+	DisableHTTP2        bool   `json:"disable_http2,omitempty"`
+}
+
+// Convert a ConfigPool into the PoolConfig it describes.
+func (cp ConfigPool) poolConfig() (*PoolConfig, error) {
+	pool := &PoolConfig{
+		MaxIdleConns:        cp.MaxIdleConns,
+		MaxIdleConnsPerHost: cp.MaxIdleConnsPerHost,
+		MaxConnsPerHost:     cp.MaxConnsPerHost,
+		DisableHTTP2:        cp.DisableHTTP2,
+	}
+	if cp.IdleConnTimeout != "" {
+		d, err := time.ParseDuration(cp.IdleConnTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid idle_conn_timeout: %v", err)
+		}
+		pool.IdleConnTimeout = d
+	}
+	return pool, pool.Validate()
+}
+
+// ConfigTLS holds TLS settings for the backend connection.
+type ConfigTLS struct {
+	CACert     string `json:"ca_cert"`
+	CAPath     string `json:"ca_path"`
+	ClientCert string `json:"client_cert"`
+	ClientKey  string `json:"client_key"`
+	ServerName string `json:"server_name"`
+	Insecure   bool   `json:"insecure"`
+}
+
+// DefaultConfig Create the default config that will work for almost all
+// scenarios.
+func DefaultConfig() *Config {
+	authConfig := DefaultAuthConfig()
+	return &Config{
+		Backend:  BackendVault,
+		BasePath: DefaultBasePath,
+		Retry:    1,
+		Auth: ConfigAuth{
+			JWTFile:  authConfig.JWTFile,
+			RoleFile: authConfig.RoleFile,
+			Path:     authConfig.Path,
+		},
+	}
+}
+
+// Load a Config from a JSON file. Values not present in the file keep their
+// defaults. An empty path returns the defaults. Files without a .json
+// extension, including YAML files, are rejected.
+func LoadConfig(path string) (*Config, error) {
+	cfg := DefaultConfig()
+	if path == "" {
+		return cfg, nil
+	}
+
+	ext := filepath.Ext(path)
+	if ext != ".json" {
+		return nil, fmt.Errorf("Unsupported config file format %q, expected .json", ext)
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(contents, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot parse config file %s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// ReadEnvironment Update a Config with environment variables. Variables that
+// are unset or empty leave the current value alone.
+func (cfg *Config) ReadEnvironment() error {
+	if v := os.Getenv(EnvBackend); v != "" {
+		cfg.Backend = v
+	}
+	if v := os.Getenv(EnvBasePath); v != "" {
+		cfg.BasePath = v
+	}
+	if v := os.Getenv(EnvRetry); v != "" {
+		retry, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("Invalid %s: %v", EnvRetry, err)
+		}
+		cfg.Retry = retry
+	}
+	if v := os.Getenv(EnvRole); v != "" {
+		cfg.Auth.Role = v
+	}
+	if v := os.Getenv(EnvVaultJWTFile); v != "" {
+		cfg.Auth.JWTFile = v
+	}
+	if v := os.Getenv(EnvVaultRoleFile); v != "" {
+		cfg.Auth.RoleFile = v
+	}
+	if v := os.Getenv(EnvVaultAuthPath); v != "" {
+		cfg.Auth.Path = v
+	}
+	if v := os.Getenv(api.EnvVaultAddress); v != "" {
+		cfg.Vault.Address = v
+	}
+	pool, err := cfg.Vault.Pool.poolConfig()
+	if err != nil {
+		return err
+	}
+	err = pool.ReadEnvironment()
+	if err != nil {
+		return err
+	}
+	cfg.Vault.Pool = ConfigPool{
+		MaxIdleConns:        pool.MaxIdleConns,
+		MaxIdleConnsPerHost: pool.MaxIdleConnsPerHost,
+		MaxConnsPerHost:     pool.MaxConnsPerHost,
+		DisableHTTP2:        pool.DisableHTTP2,
+	}
+	if pool.IdleConnTimeout != 0 {
+		cfg.Vault.Pool.IdleConnTimeout = pool.IdleConnTimeout.String()
+	}
+	if v := os.Getenv(api.EnvVaultCACert); v != "" {
+		cfg.TLS.CACert = v
+	}
+	if v := os.Getenv(api.EnvVaultCAPath); v != "" {
+		cfg.TLS.CAPath = v
+	}
+	if v := os.Getenv(api.EnvVaultClientCert); v != "" {
+		cfg.TLS.ClientCert = v
+	}
+	if v := os.Getenv(api.EnvVaultClientKey); v != "" {
+		cfg.TLS.ClientKey = v
+	}
+	if v := os.Getenv(api.EnvVaultTLSServerName); v != "" {
+		cfg.TLS.ServerName = v
+	}
+	if v := os.Getenv(api.EnvVaultInsecure); v != "" {
+		insecure, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("Invalid %s: %v", api.EnvVaultInsecure, err)
+		}
+		cfg.TLS.Insecure = insecure
+	}
+	return nil
+}
+
+// Check a Config for values that cannot work.
+func (cfg *Config) Validate() error {
+	switch cfg.Backend {
+	case BackendVault:
+	default:
+		return fmt.Errorf("Unknown backend %q", cfg.Backend)
+	}
+	if cfg.BasePath == "" {
+		return fmt.Errorf("base_path must not be empty")
+	}
+	if cfg.Retry < 0 {
+		return fmt.Errorf("retry must not be negative")
+	}
+	if (cfg.TLS.ClientCert == "") != (cfg.TLS.ClientKey == "") {
+		return fmt.Errorf("both client_cert and client_key must be provided")
+	}
+	_, err := cfg.Vault.Pool.poolConfig()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Create a new SecureStorage for the backend described by cfg. For Vault
+// this connects and authenticates just like NewVaultAdapter().
+func NewFromConfig(cfg *Config) (SecureStorage, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Backend {
+	case BackendVault:
+		return newVaultAdapterFromConfig(cfg)
+	}
+	return nil, fmt.Errorf("Unknown backend %q", cfg.Backend)
+}
+
+func newVaultAdapterFromConfig(cfg *Config) (SecureStorage, error) {
+	ss := &VaultAdapter{
+		BasePath:   cfg.BasePath,
+		VaultRetry: cfg.Retry,
+		Role:       cfg.Auth.Role,
+		AuthConfig: &AuthConfig{
+			JWTFile:  cfg.Auth.JWTFile,
+			RoleFile: cfg.Auth.RoleFile,
+			Path:     cfg.Auth.Path,
+		},
+	}
+	if cfg.Auth.JWTEnv != "" {
+		ss.AuthConfig.JWTFunc = JWTFromEnv(cfg.Auth.JWTEnv)
+	}
+
+	config := api.DefaultConfig()
+	if config.Error != nil {
+		return ss, config.Error
+	}
+	if cfg.Vault.Address != "" {
+		config.Address = cfg.Vault.Address
+	}
+	err := config.ConfigureTLS(&api.TLSConfig{
+		CACert:        cfg.TLS.CACert,
+		CAPath:        cfg.TLS.CAPath,
+		ClientCert:    cfg.TLS.ClientCert,
+		ClientKey:     cfg.TLS.ClientKey,
+		TLSServerName: cfg.TLS.ServerName,
+		Insecure:      cfg.TLS.Insecure,
+	})
+	if err != nil {
+		return ss, err
+	}
+	pool, err := cfg.Vault.Pool.poolConfig()
+	if err != nil {
+		return ss, err
+	}
+	err = pool.Apply(config)
+	if err != nil {
+		return ss, err
+	}
+
+	ss.Config = config
+
+	err = ss.connect()
+	if err != nil {
+		return ss, err
+	}
+
+	return ss, nil
+}
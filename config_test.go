@@ -0,0 +1,132 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	jsonFile := filepath.Join(dir, "config.json")
+	os.WriteFile(jsonFile, []byte(`{"base_path": "hms-creds", "retry": 3, "vault": {"address": "http://cray-vault.vault:8200"}}`), 0600)
+	badFile := filepath.Join(dir, "bad.json")
+	os.WriteFile(badFile, []byte(`{"base_path": `), 0600)
+	yamlFile := filepath.Join(dir, "config.yaml")
+	os.WriteFile(yamlFile, []byte("base_path: hms-creds\n"), 0600)
+
+	var tests = []struct {
+		path     string
+		env      map[string]string
+		basePath string
+		retry    int
+		address  string
+		role     string
+		respErr  bool
+	}{
+		{
+			path:     "",
+			basePath: DefaultBasePath,
+			retry:    1,
+		}, {
+			path:     jsonFile,
+			basePath: "hms-creds",
+			retry:    3,
+			address:  "http://cray-vault.vault:8200",
+		}, {
+			path:     jsonFile,
+			env:      map[string]string{EnvRetry: "0", EnvRole: "hms", "VAULT_ADDR": "http://localhost:8200"},
+			basePath: "hms-creds",
+			retry:    0,
+			address:  "http://localhost:8200",
+			role:     "hms",
+		}, {
+			path:    jsonFile,
+			env:     map[string]string{EnvRetry: "many"},
+			respErr: true,
+		}, {
+			path:    jsonFile,
+			env:     map[string]string{EnvVaultIdleConnTimeout: "soon"},
+			respErr: true,
+		}, {
+			path:    jsonFile,
+			env:     map[string]string{EnvVaultMaxConnsPerHost: "-1"},
+			respErr: true,
+		}, {
+			path:    badFile,
+			respErr: true,
+		}, {
+			path:    yamlFile,
+			respErr: true,
+		},
+	}
+
+	for i, test := range tests {
+		for k, v := range test.env {
+			t.Setenv(k, v)
+		}
+		cfg, err := LoadConfig(test.path)
+		if err == nil {
+			err = cfg.ReadEnvironment()
+		}
+		if err == nil {
+			err = cfg.Validate()
+		}
+		for k := range test.env {
+			os.Unsetenv(k)
+		}
+		if (err != nil) != test.respErr {
+			if test.respErr {
+				t.Errorf("Test %v Failed: Expected an error.", i)
+			} else {
+				t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+			}
+			continue
+		}
+		if test.respErr {
+			continue
+		}
+		if cfg.BasePath != test.basePath || cfg.Retry != test.retry ||
+			cfg.Vault.Address != test.address || cfg.Auth.Role != test.role {
+			t.Errorf("Test %v Failed: Unexpected config %+v", i, cfg)
+		}
+		if cfg.Auth.Path != "auth/kubernetes/login" {
+			t.Errorf("Test %v Failed: Expected default auth path but got %v", i, cfg.Auth.Path)
+		}
+	}
+}
+
+func TestNewFromConfigInvalid(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Backend = "consul"
+	if _, err := NewFromConfig(cfg); err == nil {
+		t.Errorf("Expected an error for an unknown backend")
+	}
+	cfg = DefaultConfig()
+	cfg.TLS.ClientCert = "client.pem"
+	if _, err := NewFromConfig(cfg); err == nil {
+		t.Errorf("Expected an error for a client cert without a key")
+	}
+}
@@ -0,0 +1,74 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Copy the value stored at src to dst within a single SecureStorage. The
+// value is read and written back through the storage interface so callers
+// never need to decode or re-encode the payload themselves.
+func Copy(ss SecureStorage, src string, dst string) error {
+	return CopyBetween(ss, src, ss, dst)
+}
+
+// Copy the value stored at src in one SecureStorage to dst in another. The
+// two stores may be different backends.
+func CopyBetween(from SecureStorage, src string, to SecureStorage, dst string) error {
+	var data map[string]interface{}
+
+	err := from.Lookup(src, &data)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, src)
+	}
+	return to.Store(dst, data)
+}
+
+// Move the value stored at src to dst within a single SecureStorage. This is
+// also how a key is renamed. The source is only removed once the copy has
+// been written successfully.
+func Move(ss SecureStorage, src string, dst string) error {
+	return MoveBetween(ss, src, ss, dst)
+}
+
+// Move the value stored at src in one SecureStorage to dst in another. The
+// source is only removed once the copy has been written successfully.
+func MoveBetween(from SecureStorage, src string, to SecureStorage, dst string) error {
+	if src == dst && sameStore(from, to) {
+		return nil
+	}
+	if src == dst && reflect.TypeOf(from) == reflect.TypeOf(to) && !reflect.TypeOf(from).Comparable() {
+		// Copying a key onto itself and deleting it would lose it.
+		return fmt.Errorf("Cannot move %s: unable to tell whether the stores are the same", src)
+	}
+	err := CopyBetween(from, src, to, dst)
+	if err != nil {
+		return err
+	}
+	return from.Delete(src)
+}
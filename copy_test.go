@@ -0,0 +1,119 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"reflect"
+	"testing"
+)
+
+// taggedStore is a memStore used by value, with a field that makes it
+// not comparable.
+type taggedStore struct {
+	*memStore
+	tags []string
+}
+
+func TestCopyBetween(t *testing.T) {
+	value := creds{
+		Xname:    "x0c0s1b0",
+		URL:      "10.4.0.21/redfish/v1/UpdateService",
+		Username: "test1",
+		Password: "123",
+	}
+	var tests = []struct {
+		src     string
+		dst     string
+		move    bool
+		respErr bool
+	}{
+		{src: "x0c0s1b0", dst: "x0c0s2b0", move: false, respErr: false},
+		{src: "x0c0s1b0", dst: "x0c0s2b0", move: true, respErr: false},
+		{src: "x0c0s9b0", dst: "x0c0s2b0", move: false, respErr: true},
+	}
+
+	for i, test := range tests {
+		from := newMemStore()
+		to := newMemStore()
+		from.Store("x0c0s1b0", value)
+		var err error
+		if test.move {
+			err = MoveBetween(from, test.src, to, test.dst)
+		} else {
+			err = CopyBetween(from, test.src, to, test.dst)
+		}
+		if (err != nil) != test.respErr {
+			if test.respErr {
+				t.Errorf("Test %v Failed: Expected an error.", i)
+			} else {
+				t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+			}
+			continue
+		}
+		if test.respErr {
+			continue
+		}
+		var r creds
+		to.Lookup(test.dst, &r)
+		if !reflect.DeepEqual(r, value) {
+			t.Errorf("Test %v Failed: Expected credentials %v but got %v", i, value, r)
+		}
+		var s map[string]interface{}
+		from.Lookup(test.src, &s)
+		if test.move && s != nil {
+			t.Errorf("Test %v Failed: Expected source %v to be removed", i, test.src)
+		} else if !test.move && s == nil {
+			t.Errorf("Test %v Failed: Expected source %v to remain", i, test.src)
+		}
+	}
+}
+
+func TestMoveUncomparable(t *testing.T) {
+	ts := taggedStore{memStore: newMemStore(), tags: []string{"bmc"}}
+	ts.Store("x0c0s1b0", creds{Username: "root"})
+
+	// Stores that cannot be compared are moved by copying.
+	if err := Move(ts, "x0c0s1b0", "x0c0s2b0"); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	var r creds
+	if err := ts.Lookup("x0c0s2b0", &r); err != nil || r.Username != "root" {
+		t.Errorf("Expected the moved value but got %v (%v)", r, err)
+	}
+	if err := Move(ts, "x0c0s2b0", "x0c0s2b0"); err == nil {
+		t.Errorf("Expected an error moving a key onto itself in a store that cannot be compared")
+	}
+	if err := ts.Lookup("x0c0s2b0", &r); err != nil || r.Username != "root" {
+		t.Errorf("Expected the value to stay in place but got %v (%v)", r, err)
+	}
+
+	// Moving a key onto itself in a comparable store leaves it in place.
+	ms := newMemStore()
+	ms.Store("x0c0s1b0", creds{Username: "root"})
+	if err := Move(ms, "x0c0s1b0", "x0c0s1b0"); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	if err := ms.Lookup("x0c0s1b0", &r); err != nil || r.Username != "root" {
+		t.Errorf("Expected the value to stay in place but got %v (%v)", r, err)
+	}
+}
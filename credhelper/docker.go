@@ -0,0 +1,172 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+// Package credhelper implements the credential helper protocols used by
+// Docker and Git on top of any SecureStorage, so registry credentials and
+// Git tokens on management nodes live in the same store as everything
+// else. A helper binary only needs to create the store and call Run().
+package credhelper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	securestorage "github.com/Cray-HPE/hms-securestorage"
+)
+
+// ErrCredentialsNotFound is returned by a Docker "get" or "erase" for an
+// unknown server. The message is the one Docker looks for.
+var ErrCredentialsNotFound = errors.New("credentials not found in native keychain")
+
+// DefaultDockerPath is the store path Docker credentials are kept under.
+const DefaultDockerPath = "docker-credentials"
+
+// DockerCredentials is the message exchanged with Docker.
+type DockerCredentials struct {
+	ServerURL string `json:"ServerURL" mapstructure:"ServerURL"`
+	Username  string `json:"Username" mapstructure:"Username"`
+	Secret    string `json:"Secret" mapstructure:"Secret"`
+}
+
+// Docker implements the docker-credential-helpers protocol. Docker runs
+// the helper as "docker-credential-<name> <action>" and talks to it over
+// stdin and stdout.
+type Docker struct {
+	Store securestorage.SecureStorage
+	Path  string
+}
+
+// Create a new Docker helper keeping credentials under path.
+func NewDocker(store securestorage.SecureStorage, path string) *Docker {
+	return &Docker{Store: store, Path: path}
+}
+
+// Server URLs contain "/", so they are encoded to make a single key.
+func (d *Docker) key(serverURL string) string {
+	return d.Path + "/" + base64.RawURLEncoding.EncodeToString([]byte(serverURL))
+}
+
+// Run one action ("get", "store", "erase" or "list") reading its input
+// from in and writing its output to out. On error the helper should write
+// the error message to stdout and exit with status 1.
+func (d *Docker) Run(action string, in io.Reader, out io.Writer) error {
+	switch action {
+	case "get":
+		return d.get(in, out)
+	case "store":
+		return d.store(in)
+	case "erase":
+		return d.erase(in)
+	case "list":
+		return d.list(out)
+	}
+	return fmt.Errorf("Unknown credential action: %s", action)
+}
+
+func readServerURL(in io.Reader) (string, error) {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return "", err
+	}
+	serverURL := strings.TrimSpace(string(data))
+	if serverURL == "" {
+		return "", fmt.Errorf("no credentials server URL")
+	}
+	return serverURL, nil
+}
+
+func (d *Docker) lookup(serverURL string) (*DockerCredentials, error) {
+	var creds DockerCredentials
+
+	err := d.Store.Lookup(d.key(serverURL), &creds)
+	if err != nil {
+		return nil, err
+	}
+	if creds.ServerURL == "" {
+		return nil, ErrCredentialsNotFound
+	}
+	return &creds, nil
+}
+
+func (d *Docker) get(in io.Reader, out io.Writer) error {
+	serverURL, err := readServerURL(in)
+	if err != nil {
+		return err
+	}
+	creds, err := d.lookup(serverURL)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(out).Encode(creds)
+}
+
+func (d *Docker) store(in io.Reader) error {
+	var creds DockerCredentials
+
+	err := json.NewDecoder(in).Decode(&creds)
+	if err != nil {
+		return fmt.Errorf("Invalid credentials: %v", err)
+	}
+	if creds.ServerURL == "" {
+		return fmt.Errorf("no credentials server URL")
+	}
+	return d.Store.Store(d.key(creds.ServerURL), creds)
+}
+
+func (d *Docker) erase(in io.Reader) error {
+	serverURL, err := readServerURL(in)
+	if err != nil {
+		return err
+	}
+	_, err = d.lookup(serverURL)
+	if err != nil {
+		return err
+	}
+	return d.Store.Delete(d.key(serverURL))
+}
+
+func (d *Docker) list(out io.Writer) error {
+	keys, err := d.Store.LookupKeys(d.Path)
+	if err != nil {
+		return err
+	}
+	servers := map[string]string{}
+	for _, name := range keys {
+		serverURL, err := base64.RawURLEncoding.DecodeString(name)
+		if err != nil {
+			continue
+		}
+		creds, err := d.lookup(string(serverURL))
+		if errors.Is(err, ErrCredentialsNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		servers[creds.ServerURL] = creds.Username
+	}
+	return json.NewEncoder(out).Encode(servers)
+}
@@ -0,0 +1,123 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package credhelper
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// memStore is a minimal in-memory SecureStorage listing keys one level at a
+// time like Vault.
+type memStore struct {
+	mu   sync.Mutex
+	data map[string]map[string]interface{}
+}
+
+func newMemStore() *memStore {
+	return &memStore{data: map[string]map[string]interface{}{}}
+}
+
+func (ms *memStore) Store(key string, value interface{}) error {
+	var data map[string]interface{}
+	if err := mapstructure.Decode(value, &data); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.data[key] = data
+	return nil
+}
+
+func (ms *memStore) StoreWithData(key string, value interface{}, output interface{}) error {
+	return ms.Store(key, value)
+}
+
+func (ms *memStore) Lookup(key string, output interface{}) error {
+	ms.mu.Lock()
+	data, ok := ms.data[key]
+	ms.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return mapstructure.Decode(data, output)
+}
+
+func (ms *memStore) Delete(key string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delete(ms.data, key)
+	return nil
+}
+
+func (ms *memStore) LookupKeys(keyPath string) ([]string, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	var keys []string
+	for k := range ms.data {
+		if rest, ok := strings.CutPrefix(k, keyPath+"/"); ok && !strings.Contains(rest, "/") {
+			keys = append(keys, rest)
+		}
+	}
+	return keys, nil
+}
+
+func TestDocker(t *testing.T) {
+	d := NewDocker(newMemStore(), DefaultDockerPath)
+
+	tests := []struct {
+		action string
+		input  string
+		output string
+		err    error
+	}{
+		{"get", "https://registry.local/v2/", "", ErrCredentialsNotFound},
+		{"store", `{"ServerURL":"https://registry.local/v2/","Username":"admin","Secret":"pw"}`, "", nil},
+		{"store", `{"ServerURL":"artifactory.local","Username":"ci","Secret":"token"}`, "", nil},
+		{"get", "https://registry.local/v2/\n", `{"ServerURL":"https://registry.local/v2/","Username":"admin","Secret":"pw"}`, nil},
+		{"list", "", `{"artifactory.local":"ci","https://registry.local/v2/":"admin"}`, nil},
+		{"erase", "artifactory.local", "", nil},
+		{"erase", "artifactory.local", "", ErrCredentialsNotFound},
+		{"list", "", `{"https://registry.local/v2/":"admin"}`, nil},
+	}
+
+	for i, test := range tests {
+		var out bytes.Buffer
+		err := d.Run(test.action, strings.NewReader(test.input), &out)
+		if !errors.Is(err, test.err) {
+			t.Errorf("Test %v Failed: Expected error %v but got %v", i, test.err, err)
+		}
+		if got := strings.TrimSpace(out.String()); got != test.output {
+			t.Errorf("Test %v Failed: Expected output %s but got %s", i, test.output, got)
+		}
+	}
+
+	if err := d.Run("version", strings.NewReader(""), &bytes.Buffer{}); err == nil {
+		t.Errorf("Test Failed: Expected error for unknown action")
+	}
+}
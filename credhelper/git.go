@@ -0,0 +1,138 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package credhelper
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	securestorage "github.com/Cray-HPE/hms-securestorage"
+)
+
+// DefaultGitPath is the store path Git credentials are kept under.
+const DefaultGitPath = "git-credentials"
+
+// GitCredentials holds the attributes Git exchanges with a helper.
+type GitCredentials struct {
+	Protocol string `mapstructure:"protocol"`
+	Host     string `mapstructure:"host"`
+	Path     string `mapstructure:"path"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+// Git implements the git-credential helper protocol. Git runs the helper
+// as "git-credential-<name> <action>" and sends key=value lines on stdin.
+type Git struct {
+	Store securestorage.SecureStorage
+	Path  string
+}
+
+// Create a new Git helper keeping credentials under path.
+func NewGit(store securestorage.SecureStorage, path string) *Git {
+	return &Git{Store: store, Path: path}
+}
+
+// Credentials are kept per protocol, host and, if Git sends one because
+// credential.useHttpPath is set, path.
+func (g *Git) key(creds *GitCredentials) string {
+	url := creds.Protocol + "://" + creds.Host
+	if creds.Path != "" {
+		url += "/" + creds.Path
+	}
+	return g.Path + "/" + base64.RawURLEncoding.EncodeToString([]byte(url))
+}
+
+// Read key=value lines up to a blank line or the end of input.
+func readGitCredentials(in io.Reader) (*GitCredentials, error) {
+	creds := &GitCredentials{}
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("Invalid credential line: %s", line)
+		}
+		switch name {
+		case "protocol":
+			creds.Protocol = value
+		case "host":
+			creds.Host = value
+		case "path":
+			creds.Path = value
+		case "username":
+			creds.Username = value
+		case "password":
+			creds.Password = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if creds.Host == "" {
+		return nil, fmt.Errorf("no credentials host")
+	}
+	return creds, nil
+}
+
+// Run one action ("get", "store" or "erase") reading its input from in and
+// writing its output to out. As Git expects, an unknown action and a "get"
+// with no matching credentials produce no output and no error.
+func (g *Git) Run(action string, in io.Reader, out io.Writer) error {
+	if action != "get" && action != "store" && action != "erase" {
+		return nil
+	}
+	query, err := readGitCredentials(in)
+	if err != nil {
+		return err
+	}
+	if action == "store" {
+		if query.Username == "" || query.Password == "" {
+			return nil
+		}
+		return g.Store.Store(g.key(query), query)
+	}
+
+	var stored GitCredentials
+	err = g.Store.Lookup(g.key(query), &stored)
+	if err != nil || stored.Host == "" {
+		return err
+	}
+	if query.Username != "" && query.Username != stored.Username {
+		return nil
+	}
+	if action == "erase" {
+		if query.Password != "" && query.Password != stored.Password {
+			return nil
+		}
+		return g.Store.Delete(g.key(query))
+	}
+	_, err = fmt.Fprintf(out, "username=%s\npassword=%s\n", stored.Username, stored.Password)
+	return err
+}
@@ -0,0 +1,67 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package credhelper
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestGit(t *testing.T) {
+	g := NewGit(newMemStore(), DefaultGitPath)
+
+	tests := []struct {
+		action string
+		input  string
+		output string
+	}{
+		{"get", "protocol=https\nhost=github.com\n\n", ""},
+		{"store", "protocol=https\nhost=github.com\nusername=admin\npassword=token\n", ""},
+		{"store", "protocol=https\nhost=github.com\npath=Cray-HPE/repo.git\nusername=ci\npassword=repo-token\n", ""},
+		{"get", "protocol=https\nhost=github.com\n\n", "username=admin\npassword=token\n"},
+		{"get", "protocol=https\nhost=github.com\npath=Cray-HPE/repo.git\n", "username=ci\npassword=repo-token\n"},
+		{"get", "protocol=https\nhost=github.com\nusername=other\n", ""},
+		{"get", "protocol=http\nhost=github.com\n", ""},
+		{"erase", "protocol=https\nhost=github.com\nusername=admin\npassword=stale\n", ""},
+		{"get", "protocol=https\nhost=github.com\n", "username=admin\npassword=token\n"},
+		{"erase", "protocol=https\nhost=github.com\nusername=admin\npassword=token\n", ""},
+		{"get", "protocol=https\nhost=github.com\n", ""},
+		{"capabilities", "", ""},
+	}
+
+	for i, test := range tests {
+		var out bytes.Buffer
+		err := g.Run(test.action, strings.NewReader(test.input), &out)
+		if err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		if out.String() != test.output {
+			t.Errorf("Test %v Failed: Expected output %q but got %q", i, test.output, out.String())
+		}
+	}
+
+	if err := g.Run("get", strings.NewReader("protocol=https\n"), &bytes.Buffer{}); err == nil {
+		t.Errorf("Test Failed: Expected error for missing host")
+	}
+}
@@ -0,0 +1,131 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+)
+
+// CryptoProvider performs the symmetric cryptography used for data at
+// rest by EncryptedAdapter, DedupAdapter, TenantManager and archives, so
+// sites can supply certified or hardware backed implementations. Keys are
+// MasterKeySize bytes.
+type CryptoProvider interface {
+	// Name of the cipher, recorded with encrypted values.
+	Algorithm() string
+	// Get the cipher for key. Nonces are generated by the caller from the
+	// entropy source.
+	NewAEAD(key []byte) (cipher.AEAD, error)
+	// Derive a MasterKeySize key for purpose from key.
+	DeriveKey(key []byte, purpose string) ([]byte, error)
+	// Compute a MAC of data under key.
+	MAC(key []byte, data []byte) ([]byte, error)
+}
+
+// AESGCMProvider is the default CryptoProvider: AES-256-GCM, with
+// HMAC-SHA-256 for key derivation and MACs.
+type AESGCMProvider struct{}
+
+func (AESGCMProvider) Algorithm() string {
+	return AlgAES256GCM
+}
+
+func (AESGCMProvider) NewAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (p AESGCMProvider) DeriveKey(key []byte, purpose string) ([]byte, error) {
+	return p.MAC(key, []byte(purpose))
+}
+
+func (AESGCMProvider) MAC(key []byte, data []byte) ([]byte, error) {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil), nil
+}
+
+var (
+	cryptoMu       sync.RWMutex
+	cryptoProvider CryptoProvider = AESGCMProvider{}
+)
+
+// FIPSApprover may be implemented by a CryptoProvider whose algorithms are
+// FIPS approved and run in a validated module. Only AESGCMProvider and
+// providers that return true from FIPSApproved may be used in FIPS mode.
+type FIPSApprover interface {
+	FIPSApproved() bool
+}
+
+// Set the CryptoProvider used from now on. A nil provider restores
+// AESGCMProvider. Values written with a provider of a different
+// Algorithm() can no longer be read, so this must be set before any
+// store is used. In FIPS mode the provider must be FIPS approved.
+func SetCryptoProvider(provider CryptoProvider) error {
+	if provider == nil {
+		provider = AESGCMProvider{}
+	}
+	if fipsEnabled() && !fipsApproved(provider) {
+		return fmt.Errorf("%w: crypto provider %s is not FIPS approved", ErrNotCompliant, provider.Algorithm())
+	}
+	cryptoMu.Lock()
+	defer cryptoMu.Unlock()
+	cryptoProvider = provider
+	return nil
+}
+
+// Get the current CryptoProvider, checking that crypto may be used. In
+// FIPS mode this fails if the provider is not FIPS approved, as it may
+// have been set before FIPS mode was turned on.
+func currentCrypto() (CryptoProvider, error) {
+	err := checkCompliance()
+	if err != nil {
+		return nil, err
+	}
+	cryptoMu.RLock()
+	provider := cryptoProvider
+	cryptoMu.RUnlock()
+	if fipsEnabled() && !fipsApproved(provider) {
+		return nil, fmt.Errorf("%w: crypto provider %s is not FIPS approved", ErrNotCompliant, provider.Algorithm())
+	}
+	return provider, nil
+}
+
+// Check if provider may be used in FIPS mode. Providers that embed
+// AESGCMProvider are not approved unless they say so.
+func fipsApproved(provider CryptoProvider) bool {
+	switch p := provider.(type) {
+	case AESGCMProvider, *AESGCMProvider:
+		return true
+	case FIPSApprover:
+		return p.FIPSApproved()
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"crypto/cipher"
+	"errors"
+	"testing"
+)
+
+// countingProvider is AESGCMProvider under another name, counting calls.
+type countingProvider struct {
+	AESGCMProvider
+	aeads, derives, macs int
+}
+
+func (cp *countingProvider) Algorithm() string {
+	return "TEST-AEAD"
+}
+
+func (cp *countingProvider) NewAEAD(key []byte) (cipher.AEAD, error) {
+	cp.aeads++
+	return cp.AESGCMProvider.NewAEAD(key)
+}
+
+func (cp *countingProvider) DeriveKey(key []byte, purpose string) ([]byte, error) {
+	cp.derives++
+	return cp.AESGCMProvider.DeriveKey(key, purpose)
+}
+
+func (cp *countingProvider) MAC(key []byte, data []byte) ([]byte, error) {
+	cp.macs++
+	return cp.AESGCMProvider.MAC(key, data)
+}
+
+func TestSetCryptoProvider(t *testing.T) {
+	defer SetCryptoProvider(nil)
+
+	key, _ := GenerateMasterKey()
+	kp, err := NewStaticKeyProvider(key)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	cp := &countingProvider{}
+	if err := SetCryptoProvider(cp); err != nil && !fipsBuild {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	if fipsBuild {
+		t.Skip("countingProvider is not FIPS approved")
+	}
+
+	var tests = []struct {
+		ss      SecureStorage
+		alg     string
+		aeads   int
+		derives int
+		macs    int
+	}{
+		{ss: Encrypted(newMemStore(), kp), alg: "TEST-AEAD", aeads: 2},
+		{ss: &EncryptedAdapter{Inner: newMemStore(), Keys: kp, RekeyAfter: 10}, alg: "TEST-AEAD-DK", aeads: 2, derives: 2},
+		{ss: NewDedupAdapter(newMemStore(), kp), aeads: 0, derives: 1, macs: 1},
+	}
+
+	for i, test := range tests {
+		*cp = countingProvider{}
+		if err := test.ss.Store("x0c0s1b0", creds{Username: "root", Password: "pw"}); err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+			continue
+		}
+		var r creds
+		if err := test.ss.Lookup("x0c0s1b0", &r); err != nil || r.Password != "pw" {
+			t.Errorf("Test %v Failed: Expected to read the value back but got %v (%v)", i, r, err)
+		}
+		if cp.aeads != test.aeads || cp.derives != test.derives || cp.macs != test.macs {
+			t.Errorf("Test %v Failed: Expected %v/%v/%v provider calls but got %v/%v/%v",
+				i, test.aeads, test.derives, test.macs, cp.aeads, cp.derives, cp.macs)
+		}
+		if ea, ok := test.ss.(*EncryptedAdapter); ok {
+			var raw map[string]interface{}
+			ea.Inner.Lookup("x0c0s1b0", &raw)
+			if raw["alg"] != test.alg {
+				t.Errorf("Test %v Failed: Expected algorithm %v but got %v", i, test.alg, raw["alg"])
+			}
+		}
+	}
+
+	// Values sealed by one provider cannot be read with another algorithm.
+	ms := newMemStore()
+	Encrypted(ms, kp).Store("x0c0s1b0", creds{Username: "root", Password: "pw"})
+	SetCryptoProvider(nil)
+	var r creds
+	if err := Encrypted(ms, kp).Lookup("x0c0s1b0", &r); err == nil {
+		t.Errorf("Expected an error reading a value sealed with another algorithm")
+	}
+}
+
+// approvedProvider is countingProvider declaring itself FIPS approved.
+type approvedProvider struct {
+	countingProvider
+}
+
+func (ap *approvedProvider) FIPSApproved() bool {
+	return true
+}
+
+func TestCryptoProviderFIPS(t *testing.T) {
+	defer func() {
+		fipsMu.Lock()
+		fipsMode = fipsBuild
+		fipsMu.Unlock()
+		SetCryptoProvider(nil)
+	}()
+	active := ComplianceInfo().ProviderActive
+
+	// A provider set before FIPS mode is turned on cannot be used after.
+	fipsMu.Lock()
+	fipsMode = false
+	fipsMu.Unlock()
+	if err := SetCryptoProvider(&countingProvider{}); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	if err := SetFIPSMode(true); !errors.Is(err, ErrNotCompliant) {
+		t.Errorf("Test Failed: Expected ErrNotCompliant enabling FIPS mode but got %v", err)
+	}
+	fipsMu.Lock()
+	fipsMode = true
+	fipsMu.Unlock()
+	if _, err := currentCrypto(); !errors.Is(err, ErrNotCompliant) {
+		t.Errorf("Test Failed: Expected ErrNotCompliant from an unapproved provider but got %v", err)
+	}
+
+	var tests = []struct {
+		provider CryptoProvider
+		approved bool
+	}{
+		{provider: nil, approved: true},
+		{provider: AESGCMProvider{}, approved: true},
+		{provider: &AESGCMProvider{}, approved: true},
+		{provider: &countingProvider{}, approved: false},
+		{provider: &approvedProvider{}, approved: true},
+	}
+
+	for i, test := range tests {
+		err := SetCryptoProvider(test.provider)
+		if test.approved && err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		if !test.approved && !errors.Is(err, ErrNotCompliant) {
+			t.Errorf("Test %v Failed: Expected ErrNotCompliant but got %v", i, err)
+		}
+		_, err = currentCrypto()
+		if active && err != nil {
+			t.Errorf("Test %v Failed: Unexpected error from currentCrypto - %v", i, err)
+		}
+	}
+}
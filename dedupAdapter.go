@@ -0,0 +1,243 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Default key path below which DedupAdapter keeps shared values
+const DefaultContentPath = ".content"
+
+// DedupAdapter stores each distinct value once, no matter how many keys
+// hold it, which shrinks stores where thousands of BMCs share the same
+// default credentials. Values are identified by an HMAC-SHA256 of their JSON
+// encoding, keyed from the KeyProvider so identical values cannot be
+// spotted by anyone without the key. Each key holds a reference to the
+// shared value, which records how many keys refer to it and is removed
+// with the last one.
+//
+// Wrap an EncryptedAdapter to have the shared values encrypted. Reference
+// counts are only kept consistent when every writer goes through the same
+// DedupAdapter.
+type DedupAdapter struct {
+	Inner       SecureStorage
+	Keys        KeyProvider
+	ContentPath string
+
+	mu sync.Mutex
+}
+
+type dedupRef struct {
+	Content string `mapstructure:"content"`
+}
+
+type dedupContent struct {
+	Value map[string]interface{} `mapstructure:"value"`
+	Refs  int                    `mapstructure:"refs"`
+}
+
+// Create a new DedupAdapter wrapping inner.
+func NewDedupAdapter(inner SecureStorage, keyProvider KeyProvider) *DedupAdapter {
+	return &DedupAdapter{
+		Inner:       inner,
+		Keys:        keyProvider,
+		ContentPath: DefaultContentPath,
+	}
+}
+
+func (da *DedupAdapter) digest(data map[string]interface{}) (string, error) {
+	provider, err := currentCrypto()
+	if err != nil {
+		return "", err
+	}
+	master, err := da.Keys.MasterKey()
+	if err != nil {
+		return "", err
+	}
+	// Derive a separate key so the master key is never used directly for
+	// more than one purpose.
+	derived, err := provider.DeriveKey(master, "securestorage dedup")
+	if err != nil {
+		return "", err
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	mac, err := provider.MAC(derived, encoded)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(mac), nil
+}
+
+func (da *DedupAdapter) contentKey(digest string) string {
+	return joinKey(da.ContentPath, digest)
+}
+
+func (da *DedupAdapter) lookupRef(key string) (string, error) {
+	var ref *dedupRef
+
+	err := da.Inner.Lookup(key, &ref)
+	if err != nil || ref == nil {
+		return "", err
+	}
+	return ref.Content, nil
+}
+
+func (da *DedupAdapter) lookupContent(digest string) (*dedupContent, error) {
+	var content *dedupContent
+
+	err := da.Inner.Lookup(da.contentKey(digest), &content)
+	return content, err
+}
+
+// Add delta to the reference count of a shared value, removing it once no
+// key refers to it.
+func (da *DedupAdapter) addRef(digest string, data map[string]interface{}, delta int) error {
+	content, err := da.lookupContent(digest)
+	if err != nil {
+		return err
+	}
+	if content == nil {
+		if delta < 0 {
+			return nil
+		}
+		content = &dedupContent{Value: data}
+	}
+	content.Refs += delta
+	if content.Refs <= 0 {
+		return da.Inner.Delete(da.contentKey(digest))
+	}
+	return da.Inner.Store(da.contentKey(digest), content)
+}
+
+func (da *DedupAdapter) store(key string, value interface{}, write func(ref *dedupRef) error) error {
+	data, err := toMap(value)
+	if err != nil {
+		return err
+	}
+	digest, err := da.digest(data)
+	if err != nil {
+		return err
+	}
+	da.mu.Lock()
+	defer da.mu.Unlock()
+	old, err := da.lookupRef(key)
+	if err != nil {
+		return err
+	}
+	if old == digest {
+		return nil
+	}
+	err = da.addRef(digest, data, 1)
+	if err != nil {
+		return err
+	}
+	err = write(&dedupRef{Content: digest})
+	if err != nil {
+		da.addRef(digest, nil, -1)
+		return err
+	}
+	if old != "" {
+		return da.addRef(old, nil, -1)
+	}
+	return nil
+}
+
+func (da *DedupAdapter) Store(key string, value interface{}) error {
+	return da.store(key, value, func(ref *dedupRef) error {
+		return da.Inner.Store(key, ref)
+	})
+}
+
+func (da *DedupAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	return da.store(key, value, func(ref *dedupRef) error {
+		return da.Inner.StoreWithData(key, ref, output)
+	})
+}
+
+func (da *DedupAdapter) Lookup(key string, output interface{}) error {
+	if output == nil {
+		return fmt.Errorf("output interface was nil")
+	}
+	digest, err := da.lookupRef(key)
+	if err != nil || digest == "" {
+		return err
+	}
+	content, err := da.lookupContent(digest)
+	if err != nil {
+		return err
+	}
+	if content == nil {
+		return fmt.Errorf("Shared value for %s is missing", key)
+	}
+	return decodeValue(content.Value, output)
+}
+
+func (da *DedupAdapter) Delete(key string) error {
+	da.mu.Lock()
+	defer da.mu.Unlock()
+	digest, err := da.lookupRef(key)
+	if err != nil {
+		return err
+	}
+	err = da.Inner.Delete(key)
+	if err != nil || digest == "" {
+		return err
+	}
+	return da.addRef(digest, nil, -1)
+}
+
+// The shared values are left out of the top level listing.
+func (da *DedupAdapter) LookupKeys(keyPath string) ([]string, error) {
+	keys, err := da.Inner.LookupKeys(keyPath)
+	if err != nil || keyPath != "" {
+		return keys, err
+	}
+	filtered := keys[:0]
+	for _, key := range keys {
+		if key != da.ContentPath+"/" {
+			filtered = append(filtered, key)
+		}
+	}
+	return filtered, nil
+}
+
+// Get the number of distinct values stored.
+func (da *DedupAdapter) ContentCount() (int, error) {
+	keys, err := da.Inner.LookupKeys(da.ContentPath)
+	return len(keys), err
+}
+
+func (da *DedupAdapter) Health() error {
+	return Health(da.Inner)
+}
+
+func (da *DedupAdapter) Close() error {
+	return Close(da.Inner)
+}
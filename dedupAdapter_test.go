@@ -0,0 +1,90 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDedupAdapter(t *testing.T) {
+	key, _ := GenerateMasterKey()
+	kp, _ := NewStaticKeyProvider(key)
+	store := newMemStore()
+	da := NewDedupAdapter(store, kp)
+
+	for i := 0; i < 10; i++ {
+		err := da.Store(fmt.Sprintf("x0c0s%db0", i), creds{Username: "root", Password: "initial0"})
+		if err != nil {
+			t.Fatalf("Unexpected error - %v", err)
+		}
+	}
+	da.Store("x0c0s0b0", creds{Username: "root", Password: "changed"})
+
+	var tests = []struct {
+		key      string
+		password string
+	}{
+		{key: "x0c0s0b0", password: "changed"},
+		{key: "x0c0s5b0", password: "initial0"},
+		{key: "x0c0s99b0", password: ""},
+	}
+	for i, test := range tests {
+		var r creds
+		err := da.Lookup(test.key, &r)
+		if err != nil || r.Password != test.password {
+			t.Errorf("Test %v Failed: Expected password %q but got %q (%v)", i, test.password, r.Password, err)
+		}
+	}
+
+	if n, _ := da.ContentCount(); n != 2 {
+		t.Errorf("Expected 2 shared values but got %d", n)
+	}
+	keys, _ := da.LookupKeys("")
+	if len(keys) != 10 {
+		t.Errorf("Expected the shared values to be hidden but got %v", keys)
+	}
+
+	// Shared values go away with the last key referring to them.
+	da.Delete("x0c0s0b0")
+	for i := 1; i < 9; i++ {
+		da.Delete(fmt.Sprintf("x0c0s%db0", i))
+	}
+	if n, _ := da.ContentCount(); n != 1 {
+		t.Errorf("Expected 1 shared value but got %d", n)
+	}
+	da.Delete("x0c0s9b0")
+	if n, _ := da.ContentCount(); n != 0 {
+		t.Errorf("Expected no shared values but got %d", n)
+	}
+
+	// The digest depends on the key, not just the value.
+	otherKey, _ := GenerateMasterKey()
+	other, _ := NewStaticKeyProvider(otherKey)
+	value := map[string]interface{}{"Password": "initial0"}
+	d1, _ := da.digest(value)
+	d2, _ := NewDedupAdapter(store, other).digest(value)
+	if d1 == d2 {
+		t.Errorf("Expected different digests for different keys")
+	}
+}
@@ -0,0 +1,189 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// PlannedChange is one write that a DryRunAdapter did not commit. Value is
+// held so the plan can be applied later but is never printed.
+type PlannedChange struct {
+	Operation string
+	Key       string
+	// Whether the key existed in the store when the change was planned.
+	Exists bool
+	// For stores, the fields whose value would be added, changed or
+	// removed.
+	Fields []string
+	Value  map[string]interface{} `json:"-"`
+}
+
+func (pc PlannedChange) String() string {
+	switch {
+	case pc.Operation == OpDelete && !pc.Exists:
+		return fmt.Sprintf("delete %s (does not exist)", pc.Key)
+	case pc.Operation == OpDelete:
+		return fmt.Sprintf("delete %s", pc.Key)
+	case !pc.Exists:
+		return fmt.Sprintf("create %s: %s", pc.Key, strings.Join(pc.Fields, ", "))
+	case len(pc.Fields) == 0:
+		return fmt.Sprintf("store %s (unchanged)", pc.Key)
+	}
+	return fmt.Sprintf("update %s: %s", pc.Key, strings.Join(pc.Fields, ", "))
+}
+
+// DryRunAdapter records Store and Delete operations in a change plan instead
+// of committing them, so the effect of a bulk update can be previewed.
+// Reads are served by the wrapped store and do not reflect the plan.
+type DryRunAdapter struct {
+	Inner SecureStorage
+
+	mu   sync.Mutex
+	plan []PlannedChange
+}
+
+// Create a new DryRunAdapter wrapping store.
+func NewDryRunAdapter(store SecureStorage) *DryRunAdapter {
+	return &DryRunAdapter{Inner: store}
+}
+
+func (da *DryRunAdapter) Store(key string, value interface{}) error {
+	data, err := toMap(value)
+	if err != nil {
+		return err
+	}
+	var current map[string]interface{}
+	err = da.Inner.Lookup(key, &current)
+	if err != nil {
+		return err
+	}
+	da.add(PlannedChange{
+		Operation: OpStore,
+		Key:       key,
+		Exists:    current != nil,
+		Fields:    changedFields(current, data),
+		Value:     data,
+	})
+	return nil
+}
+
+// The output is left untouched since nothing is written.
+func (da *DryRunAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	return da.Store(key, value)
+}
+
+func (da *DryRunAdapter) Lookup(key string, output interface{}) error {
+	return da.Inner.Lookup(key, output)
+}
+
+func (da *DryRunAdapter) Delete(key string) error {
+	var current map[string]interface{}
+
+	err := da.Inner.Lookup(key, &current)
+	if err != nil {
+		return err
+	}
+	da.add(PlannedChange{
+		Operation: OpDelete,
+		Key:       key,
+		Exists:    current != nil,
+	})
+	return nil
+}
+
+func (da *DryRunAdapter) LookupKeys(keyPath string) ([]string, error) {
+	return da.Inner.LookupKeys(keyPath)
+}
+
+func (da *DryRunAdapter) add(change PlannedChange) {
+	da.mu.Lock()
+	defer da.mu.Unlock()
+	da.plan = append(da.plan, change)
+}
+
+// Get the changes recorded so far, in the order they were made.
+func (da *DryRunAdapter) Plan() []PlannedChange {
+	da.mu.Lock()
+	defer da.mu.Unlock()
+	return append([]PlannedChange(nil), da.plan...)
+}
+
+// Discard the recorded changes.
+func (da *DryRunAdapter) Reset() {
+	da.mu.Lock()
+	defer da.mu.Unlock()
+	da.plan = nil
+}
+
+// Apply the recorded changes to the wrapped store, in order. Applied changes
+// are removed from the plan; on error the remaining ones are kept.
+func (da *DryRunAdapter) Commit() error {
+	da.mu.Lock()
+	defer da.mu.Unlock()
+	for len(da.plan) > 0 {
+		change := da.plan[0]
+		var err error
+		if change.Operation == OpDelete {
+			err = da.Inner.Delete(change.Key)
+		} else {
+			err = da.Inner.Store(change.Key, change.Value)
+		}
+		if err != nil {
+			return err
+		}
+		da.plan = da.plan[1:]
+	}
+	return nil
+}
+
+func (da *DryRunAdapter) Health() error {
+	return Health(da.Inner)
+}
+
+func (da *DryRunAdapter) Close() error {
+	return Close(da.Inner)
+}
+
+// Get the sorted names of the fields that differ between two values. Values
+// are compared by their printed form since backends may decode numbers as
+// different types.
+func changedFields(current map[string]interface{}, next map[string]interface{}) []string {
+	var fields []string
+	for name, value := range next {
+		old, ok := current[name]
+		if !ok || fmt.Sprint(old) != fmt.Sprint(value) {
+			fields = append(fields, name)
+		}
+	}
+	for name := range current {
+		if _, ok := next[name]; !ok {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
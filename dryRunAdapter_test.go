@@ -0,0 +1,71 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDryRunAdapter(t *testing.T) {
+	store := newMemStore()
+	store.Store("x0c0s1b0", creds{Xname: "x0c0s1b0", Username: "root", Password: "old"})
+	store.Store("x0c0s2b0", creds{Xname: "x0c0s2b0", Username: "root", Password: "same"})
+	da := NewDryRunAdapter(store)
+
+	da.Store("x0c0s1b0", creds{Xname: "x0c0s1b0", Username: "root", Password: "new"})
+	da.Store("x0c0s2b0", creds{Xname: "x0c0s2b0", Username: "root", Password: "same"})
+	da.Store("x0c0s3b0", map[string]interface{}{"Username": "root"})
+	da.Delete("x0c0s2b0")
+	da.Delete("x0c0s9b0")
+
+	expected := []string{
+		"update x0c0s1b0: Password",
+		"store x0c0s2b0 (unchanged)",
+		"create x0c0s3b0: Username",
+		"delete x0c0s2b0",
+		"delete x0c0s9b0 (does not exist)",
+	}
+	var actual []string
+	for _, change := range da.Plan() {
+		actual = append(actual, change.String())
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected plan %v but got %v", expected, actual)
+	}
+
+	// Nothing is written until the plan is committed.
+	var r creds
+	da.Lookup("x0c0s1b0", &r)
+	if r.Password != "old" {
+		t.Errorf("Expected the store to be unchanged but got %v", r.Password)
+	}
+	if err := da.Commit(); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	store.Lookup("x0c0s1b0", &r)
+	keys, _ := store.LookupKeys("")
+	if r.Password != "new" || len(keys) != 2 || len(da.Plan()) != 0 {
+		t.Errorf("Expected the plan to be applied but got %v, %v", r.Password, keys)
+	}
+}
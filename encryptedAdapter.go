@@ -0,0 +1,317 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Algorithms recorded in entries written by EncryptedAdapter with the
+// default CryptoProvider. Entries sealed under a data key have the
+// provider's algorithm followed by "-DK".
+const (
+	AlgAES256GCM        = "AES-256-GCM"
+	AlgAES256GCMDataKey = AlgAES256GCM + dataKeySuffix
+)
+
+const (
+	dataKeySuffix  = "-DK"
+	dataKeyPurpose = "securestorage data key "
+)
+
+// DefaultRekeyThreshold keeps the number of random nonces used with one
+// AES-GCM key far below the 2^32 limit recommended by NIST SP 800-38D.
+const DefaultRekeyThreshold = 1 << 28
+
+// KeyUsage reports how much an EncryptedAdapter has used its keys.
+type KeyUsage struct {
+	DataKeyID   string // Current data key, empty if data keys are off
+	Encryptions uint64 // Encryptions under the current key
+	Total       uint64 // Encryptions since the adapter was created
+	Rekeys      int    // Data keys retired after reaching RekeyAfter
+}
+
+// EncryptedAdapter encrypts values before handing them to any SecureStorage
+// backend so the backend only ever sees ciphertext. Each value is encoded
+// as JSON and sealed with the CryptoProvider, AES-256-GCM by default, under
+// the master key from the KeyProvider, using a random nonce and the key
+// name as additional data so an entry copied to a different key fails to
+// decrypt. Key names are not encrypted.
+//
+// When RekeyAfter is set, values are sealed under data keys derived from
+// the master key and a random key ID instead of the master key itself, and
+// a new data key is started after RekeyAfter encryptions. The key ID is
+// stored with each entry so every data key can still be re-derived for
+// decryption.
+//
+// Fields listed in SearchableFields are also sealed deterministically so
+// FindByField() can search them; see FindByField() for the tradeoff.
+type EncryptedAdapter struct {
+	Inner            SecureStorage
+	Keys             KeyProvider
+	RekeyAfter       uint64
+	SearchableFields []string
+
+	mu    sync.Mutex
+	usage KeyUsage
+}
+
+type encryptedEntry struct {
+	Alg        string            `mapstructure:"alg"`
+	KeyID      string            `mapstructure:"key_id,omitempty"`
+	Ciphertext string            `mapstructure:"ciphertext"`
+	Fields     map[string]string `mapstructure:"fields,omitempty"`
+}
+
+// Create a new EncryptedAdapter wrapping inner.
+func Encrypted(inner SecureStorage, keyProvider KeyProvider) *EncryptedAdapter {
+	return &EncryptedAdapter{
+		Inner: inner,
+		Keys:  keyProvider,
+	}
+}
+
+// Seal plaintext with provider, returning the nonce followed by the
+// ciphertext.
+func seal(provider CryptoProvider, key []byte, plaintext []byte, additional []byte) ([]byte, error) {
+	aead, err := provider.NewAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	err = readEntropy(nonce)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additional), nil
+}
+
+// Open a value produced by seal() into a buffer that is locked if
+// memory locking is on. The caller must destroy the buffer. Locking only
+// protects keys; a value decoded from the buffer is ordinary memory.
+func unseal(provider CryptoProvider, key []byte, sealed []byte, additional []byte) (*LockedBuffer, error) {
+	aead, err := provider.NewAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize()+aead.Overhead() {
+		return nil, fmt.Errorf("Ciphertext too short")
+	}
+	nonce := sealed[:aead.NonceSize()]
+	ciphertext := sealed[aead.NonceSize():]
+	buf, err := newSecretBuffer(len(ciphertext) - aead.Overhead())
+	if err != nil {
+		return nil, err
+	}
+	_, err = aead.Open(buf.Bytes()[:0], nonce, ciphertext, additional)
+	if err != nil {
+		buf.Destroy()
+		return nil, err
+	}
+	return buf, nil
+}
+
+// Count an encryption and get the data key ID to use for it, starting a
+// new data key when the current one has reached RekeyAfter. The ID is
+// empty if data keys are off.
+func (ea *EncryptedAdapter) nextKeyID() (string, error) {
+	ea.mu.Lock()
+	defer ea.mu.Unlock()
+	if ea.RekeyAfter == 0 {
+		ea.usage.DataKeyID = ""
+	} else if ea.usage.DataKeyID == "" || ea.usage.Encryptions >= ea.RekeyAfter {
+		id := make([]byte, 16)
+		err := readEntropy(id)
+		if err != nil {
+			return "", err
+		}
+		if ea.usage.DataKeyID != "" {
+			ea.usage.Rekeys++
+		}
+		ea.usage.DataKeyID = base64.RawURLEncoding.EncodeToString(id)
+		ea.usage.Encryptions = 0
+	}
+	ea.usage.Encryptions++
+	ea.usage.Total++
+	return ea.usage.DataKeyID, nil
+}
+
+// Get the key usage counters.
+func (ea *EncryptedAdapter) Usage() KeyUsage {
+	ea.mu.Lock()
+	defer ea.mu.Unlock()
+	return ea.usage
+}
+
+func (ea *EncryptedAdapter) encrypt(key string, value interface{}) (*encryptedEntry, error) {
+	data, err := toMap(value)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	masterKey, err := ea.Keys.MasterKey()
+	if err != nil {
+		return nil, err
+	}
+	provider, err := currentCrypto()
+	if err != nil {
+		return nil, err
+	}
+	fields, err := ea.searchTokens(provider, masterKey, data)
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := ea.nextKeyID()
+	if err != nil {
+		return nil, err
+	}
+	entry := &encryptedEntry{Alg: provider.Algorithm(), Fields: fields}
+	if keyID != "" {
+		masterKey, err = provider.DeriveKey(masterKey, dataKeyPurpose+keyID)
+		if err != nil {
+			return nil, err
+		}
+		entry.Alg += dataKeySuffix
+		entry.KeyID = keyID
+	}
+	sealed, err := seal(provider, masterKey, plaintext, []byte(key))
+	if err != nil {
+		return nil, err
+	}
+	entry.Ciphertext = base64.StdEncoding.EncodeToString(sealed)
+	return entry, nil
+}
+
+func (ea *EncryptedAdapter) decrypt(key string, entry *encryptedEntry) (map[string]interface{}, error) {
+	var data map[string]interface{}
+
+	provider, err := currentCrypto()
+	if err != nil {
+		return nil, err
+	}
+	alg := provider.Algorithm()
+	if entry.Alg != alg && entry.Alg != alg+dataKeySuffix {
+		return nil, fmt.Errorf("Unsupported encryption algorithm %q for %s", entry.Alg, key)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(entry.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot decode ciphertext for %s: %v", key, err)
+	}
+	masterKey, err := ea.Keys.MasterKey()
+	if err != nil {
+		return nil, err
+	}
+	if entry.Alg == alg+dataKeySuffix {
+		if entry.KeyID == "" {
+			return nil, fmt.Errorf("Missing data key ID for %s", key)
+		}
+		masterKey, err = provider.DeriveKey(masterKey, dataKeyPurpose+entry.KeyID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	plaintext, err := unseal(provider, masterKey, sealed, []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("Cannot decrypt %s: %v", key, err)
+	}
+	defer plaintext.Destroy()
+	err = json.Unmarshal(plaintext.Bytes(), &data)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot decode value for %s: %v", key, err)
+	}
+	return data, nil
+}
+
+func (ea *EncryptedAdapter) Store(key string, value interface{}) error {
+	entry, err := ea.encrypt(key, value)
+	if err != nil {
+		return err
+	}
+	return ea.Inner.Store(key, entry)
+}
+
+func (ea *EncryptedAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	entry, err := ea.encrypt(key, value)
+	if err != nil {
+		return err
+	}
+	return ea.Inner.StoreWithData(key, entry, output)
+}
+
+func (ea *EncryptedAdapter) Lookup(key string, output interface{}) error {
+	var entry *encryptedEntry
+
+	if output == nil {
+		return fmt.Errorf("output interface was nil")
+	}
+	err := ea.Inner.Lookup(key, &entry)
+	if err != nil || entry == nil {
+		return err
+	}
+	data, err := ea.decrypt(key, entry)
+	if err != nil {
+		return err
+	}
+	return decodeValue(data, output)
+}
+
+func (ea *EncryptedAdapter) Delete(key string) error {
+	return ea.Inner.Delete(key)
+}
+
+func (ea *EncryptedAdapter) LookupKeys(keyPath string) ([]string, error) {
+	return ea.Inner.LookupKeys(keyPath)
+}
+
+// Close the wrapped store, then the KeyProvider if it can be closed so the
+// master key is wiped from memory.
+func (ea *EncryptedAdapter) Close() error {
+	err := Close(ea.Inner)
+	if c, ok := ea.Keys.(io.Closer); ok {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Check that the master key is available and valid, then the health of the
+// wrapped store.
+func (ea *EncryptedAdapter) Health() error {
+	masterKey, err := ea.Keys.MasterKey()
+	if err != nil {
+		return err
+	}
+	err = checkMasterKey(masterKey)
+	if err != nil {
+		return err
+	}
+	return Health(ea.Inner)
+}
@@ -0,0 +1,152 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestEncryptedAdapter(t *testing.T) {
+	value := creds{
+		Xname:    "x0c0s1b0",
+		URL:      "10.4.0.21/redfish/v1/UpdateService",
+		Username: "test1",
+		Password: "123",
+	}
+	key, err := GenerateMasterKey()
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "master.key")
+	os.WriteFile(keyFile, []byte(hex.EncodeToString(key)+"\n"), 0600)
+	kp := NewFileKeyProvider(keyFile)
+
+	ms := newMemStore()
+	ea := Encrypted(ms, kp)
+	if err := ea.Store("x0c0s1b0", value); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+
+	// The backend must never see the plaintext.
+	var raw map[string]interface{}
+	ms.Lookup("x0c0s1b0", &raw)
+	if len(raw) != 2 || raw["alg"] != AlgAES256GCM || raw["ciphertext"] == "" {
+		t.Errorf("Expected only an encrypted entry but got %v", raw)
+	}
+
+	var r creds
+	if err := ea.Lookup("x0c0s1b0", &r); err != nil || !reflect.DeepEqual(r, value) {
+		t.Errorf("Expected credentials %v but got %v (%v)", value, r, err)
+	}
+
+	// Moving the ciphertext to another key must fail to decrypt.
+	ms.Store("x0c0s2b0", raw)
+	if err := ea.Lookup("x0c0s2b0", &r); err == nil {
+		t.Errorf("Expected an error decrypting a moved entry")
+	}
+
+	// A different master key must fail to decrypt.
+	other, _ := GenerateMasterKey()
+	skp, err := NewStaticKeyProvider(other)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	if err := Encrypted(ms, skp).Lookup("x0c0s1b0", &r); err == nil {
+		t.Errorf("Expected an error decrypting with the wrong key")
+	}
+
+	if _, err := NewStaticKeyProvider(key[:16]); err == nil {
+		t.Errorf("Expected an error for a short master key")
+	}
+}
+
+func TestEncryptedAdapterRekey(t *testing.T) {
+	key, _ := GenerateMasterKey()
+	kp, err := NewStaticKeyProvider(key)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	ms := newMemStore()
+	ea := Encrypted(ms, kp)
+	ea.RekeyAfter = 2
+
+	var tests = []struct {
+		key   string
+		usage KeyUsage
+	}{
+		{key: "x0c0s1b0", usage: KeyUsage{Encryptions: 1, Total: 1}},
+		{key: "x0c0s2b0", usage: KeyUsage{Encryptions: 2, Total: 2}},
+		{key: "x0c0s3b0", usage: KeyUsage{Encryptions: 1, Total: 3, Rekeys: 1}},
+		{key: "x0c0s1b0", usage: KeyUsage{Encryptions: 2, Total: 4, Rekeys: 1}},
+		{key: "x0c0s4b0", usage: KeyUsage{Encryptions: 1, Total: 5, Rekeys: 2}},
+	}
+
+	ids := map[string]bool{}
+	for i, test := range tests {
+		value := creds{Xname: test.key, Username: "root", Password: test.key}
+		if err := ea.Store(test.key, value); err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+			continue
+		}
+		usage := ea.Usage()
+		ids[usage.DataKeyID] = true
+		test.usage.DataKeyID = usage.DataKeyID
+		if usage != test.usage || usage.DataKeyID == "" {
+			t.Errorf("Test %v Failed: Expected usage %+v but got %+v", i, test.usage, usage)
+		}
+		var raw map[string]interface{}
+		ms.Lookup(test.key, &raw)
+		if raw["alg"] != AlgAES256GCMDataKey || raw["key_id"] != usage.DataKeyID {
+			t.Errorf("Test %v Failed: Unexpected entry %v", i, raw)
+		}
+	}
+	if len(ids) != 3 {
+		t.Errorf("Expected 3 data keys but got %v", len(ids))
+	}
+
+	// Entries sealed under every data key, and under the master key, must
+	// still decrypt.
+	if err := Encrypted(ms, kp).Store("legacy", creds{Username: "legacy"}); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	for _, k := range []string{"x0c0s1b0", "x0c0s2b0", "x0c0s3b0", "x0c0s4b0", "legacy"} {
+		var r creds
+		if err := ea.Lookup(k, &r); err != nil || r.Username == "" {
+			t.Errorf("Expected to decrypt %v but got %v (%v)", k, r, err)
+		}
+	}
+
+	// Changing the key ID must fail to decrypt.
+	var raw map[string]interface{}
+	ms.Lookup("x0c0s2b0", &raw)
+	raw["key_id"] = "AAAAAAAAAAAAAAAAAAAAAA"
+	ms.Store("x0c0s2b0", raw)
+	var r creds
+	if err := ea.Lookup("x0c0s2b0", &r); err == nil {
+		t.Errorf("Expected an error decrypting with the wrong data key")
+	}
+}
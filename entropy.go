@@ -0,0 +1,72 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"sync"
+)
+
+var (
+	entropyMu     sync.RWMutex
+	entropySource io.Reader = rand.Reader
+)
+
+// Set the entropy source used to generate master keys, data key IDs,
+// Shamir shares, hybrid archive keys, AES-GCM nonces and rotated
+// passwords, for example a hardware RNG or an HSM. A nil source restores
+// crypto/rand. Ephemeral keys generated inside the standard library, such
+// as for ECDH and ML-KEM, always use crypto/rand.
+func SetEntropySource(source io.Reader) {
+	if source == nil {
+		source = rand.Reader
+	}
+	entropyMu.Lock()
+	defer entropyMu.Unlock()
+	entropySource = source
+}
+
+// Fill b from the entropy source.
+func readEntropy(b []byte) error {
+	entropyMu.RLock()
+	source := entropySource
+	entropyMu.RUnlock()
+	_, err := io.ReadFull(source, b)
+	if err != nil {
+		return fmt.Errorf("Cannot read from entropy source: %v", err)
+	}
+	return nil
+}
+
+// entropyReader reads from the entropy source, for functions that take an
+// io.Reader.
+type entropyReader struct{}
+
+func (entropyReader) Read(b []byte) (int, error) {
+	err := readEntropy(b)
+	if err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
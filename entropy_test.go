@@ -0,0 +1,95 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+type countingReader struct {
+	n   int
+	err error
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	if cr.err != nil {
+		return 0, cr.err
+	}
+	for i := range p {
+		p[i] = byte(cr.n)
+		cr.n++
+	}
+	return len(p), nil
+}
+
+func TestSetEntropySource(t *testing.T) {
+	defer SetEntropySource(nil)
+
+	var tests = []struct {
+		source  io.Reader
+		respErr bool
+		want    []byte
+	}{
+		{source: &countingReader{}, want: []byte{0, 1, 2, 3}},
+		{source: &countingReader{err: fmt.Errorf("RNG failure")}, respErr: true},
+		{source: bytes.NewReader([]byte{1, 2}), respErr: true},
+		{source: nil},
+	}
+
+	for i, test := range tests {
+		SetEntropySource(test.source)
+		key, err := GenerateMasterKey()
+		if (err != nil) != test.respErr {
+			t.Errorf("Test %v Failed: Unexpected error result - %v", i, err)
+			continue
+		}
+		if test.want != nil && !bytes.HasPrefix(key, test.want) {
+			t.Errorf("Test %v Failed: Expected key from the entropy source but got %x", i, key)
+		}
+	}
+
+	// Nonces come from the entropy source too.
+	SetEntropySource(&countingReader{n: 100})
+	key, _ := GenerateMasterKey()
+	sealed, err := seal(AESGCMProvider{}, key, []byte("secret"), nil)
+	if err != nil || sealed[0] != 132 {
+		t.Errorf("Expected a nonce from the entropy source but got %x (%v)", sealed, err)
+	}
+	SetEntropySource(&countingReader{err: fmt.Errorf("RNG failure")})
+	if _, err := seal(AESGCMProvider{}, key, []byte("secret"), nil); err == nil {
+		t.Errorf("Expected an error when the entropy source fails")
+	}
+
+	// So are rotated passwords.
+	generate := RandomPasswordGenerator("Password", 4, "abcd")
+	if _, err := generate("x0c0s1b0", nil); err == nil {
+		t.Errorf("Expected an error generating a password when the entropy source fails")
+	}
+	SetEntropySource(&countingReader{n: 1})
+	value, err := generate("x0c0s1b0", nil)
+	if pw := value.(map[string]interface{})["Password"]; err != nil || pw != "bcda" {
+		t.Errorf("Expected a password from the entropy source but got %v (%v)", pw, err)
+	}
+}
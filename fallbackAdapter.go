@@ -0,0 +1,132 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+)
+
+// FallbackAdapter reads from a secondary SecureStorage whenever the primary
+// is unavailable or does not have the key, so a service can keep running
+// through brief Vault outages using a local cache store. Writes always go
+// to the primary. With Backfill set, values written to or read from the
+// primary are also copied into the secondary to keep it warm.
+type FallbackAdapter struct {
+	Primary   SecureStorage
+	Secondary SecureStorage
+	Backfill  bool
+}
+
+// Create a new FallbackAdapter. Backfill is off by default.
+func Fallback(primary SecureStorage, secondary SecureStorage) *FallbackAdapter {
+	return &FallbackAdapter{
+		Primary:   primary,
+		Secondary: secondary,
+	}
+}
+
+// Copy a value into the secondary store. Failures here never fail the
+// operation against the primary.
+func (fa *FallbackAdapter) backfill(key string, value interface{}) {
+	if fa.Backfill {
+		fa.Secondary.Store(key, value)
+	}
+}
+
+func (fa *FallbackAdapter) Store(key string, value interface{}) error {
+	err := fa.Primary.Store(key, value)
+	if err != nil {
+		return err
+	}
+	fa.backfill(key, value)
+	return nil
+}
+
+func (fa *FallbackAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	err := fa.Primary.StoreWithData(key, value, output)
+	if err != nil {
+		return err
+	}
+	fa.backfill(key, value)
+	return nil
+}
+
+// Read from the primary, falling back to the secondary if the primary
+// fails or has no value for key. The primary's error is returned if
+// neither store has the key.
+func (fa *FallbackAdapter) Lookup(key string, output interface{}) error {
+	var data map[string]interface{}
+
+	if output == nil {
+		return fmt.Errorf("output interface was nil")
+	}
+	perr := fa.Primary.Lookup(key, &data)
+	if perr == nil && data != nil {
+		fa.backfill(key, data)
+		return decodeValue(data, output)
+	}
+
+	serr := fa.Secondary.Lookup(key, &data)
+	if serr != nil || data == nil {
+		if perr != nil {
+			return perr
+		}
+		return serr
+	}
+	return decodeValue(data, output)
+}
+
+// Delete from both stores so the secondary never serves a deleted value.
+func (fa *FallbackAdapter) Delete(key string) error {
+	err := fa.Primary.Delete(key)
+	if err != nil {
+		return err
+	}
+	return fa.Secondary.Delete(key)
+}
+
+func (fa *FallbackAdapter) LookupKeys(keyPath string) ([]string, error) {
+	klist, err := fa.Primary.LookupKeys(keyPath)
+	if err == nil {
+		return klist, nil
+	}
+	klist, serr := fa.Secondary.LookupKeys(keyPath)
+	if serr != nil {
+		return nil, err
+	}
+	return klist, nil
+}
+
+// Close both stores.
+func (fa *FallbackAdapter) Close() error {
+	return closeAll(fa.Primary, fa.Secondary)
+}
+
+// The adapter is healthy as long as either store is.
+func (fa *FallbackAdapter) Health() error {
+	err := Health(fa.Primary)
+	if err == nil || Health(fa.Secondary) == nil {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,95 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFallbackLookup(t *testing.T) {
+	value := creds{
+		Xname:    "x0c0s1b0",
+		URL:      "10.4.0.21/redfish/v1/UpdateService",
+		Username: "test1",
+		Password: "123",
+	}
+	stale := value
+	stale.Password = "old"
+
+	var tests = []struct {
+		primaryDown bool
+		inPrimary   bool
+		inSecondary bool
+		backfill    bool
+		resp        creds
+		respErr     bool
+	}{
+		{inPrimary: true, inSecondary: true, resp: value},
+		{primaryDown: true, inSecondary: true, resp: stale},
+		{inPrimary: false, inSecondary: true, resp: stale},
+		{primaryDown: true, inSecondary: false, respErr: true},
+		{inPrimary: false, inSecondary: false, resp: creds{}},
+		{inPrimary: true, inSecondary: false, backfill: true, resp: value},
+	}
+
+	for i, test := range tests {
+		var primary SecureStorage
+		ms := newMemStore()
+		if test.inPrimary {
+			ms.Store("x0c0s1b0", value)
+		}
+		primary = ms
+		if test.primaryDown {
+			primary = &failStore{err: fmt.Errorf("Code: 503")}
+		}
+		secondary := newMemStore()
+		if test.inSecondary {
+			secondary.Store("x0c0s1b0", stale)
+		}
+		fa := Fallback(primary, secondary)
+		fa.Backfill = test.backfill
+
+		var r creds
+		err := fa.Lookup("x0c0s1b0", &r)
+		if (err != nil) != test.respErr {
+			if test.respErr {
+				t.Errorf("Test %v Failed: Expected an error.", i)
+			} else {
+				t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(r, test.resp) {
+			t.Errorf("Test %v Failed: Expected credentials %v but got %v", i, test.resp, r)
+		}
+		if test.backfill {
+			var b creds
+			secondary.Lookup("x0c0s1b0", &b)
+			if !reflect.DeepEqual(b, value) {
+				t.Errorf("Test %v Failed: Expected secondary to be backfilled but got %v", i, b)
+			}
+		}
+	}
+}
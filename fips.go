@@ -0,0 +1,129 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrNotCompliant is returned (wrapped) by cryptographic operations in FIPS
+// mode when the binary is not using a FIPS 140 validated provider.
+var ErrNotCompliant = errors.New("FIPS 140 compliance required")
+
+// Compliance describes the cryptography this binary uses, for sites that
+// must show FIPS 140 compliance.
+type Compliance struct {
+	// FIPS mode is on: only approved algorithms may be used and only with
+	// a validated provider.
+	FIPSMode bool
+	// Built with the securestorage_fips tag, which turns FIPS mode on and
+	// prevents it from being turned off.
+	FIPSBuild bool
+	// The validated provider in use, if any.
+	Provider       string
+	ProviderActive bool
+	// Algorithms this module uses for data at rest, from the current
+	// CryptoProvider. Outside FIPS mode this includes the hybrid archive
+	// key exchange.
+	Algorithms []string
+}
+
+var (
+	fipsMu   sync.RWMutex
+	fipsMode = fipsBuild
+)
+
+// Get the compliance state of this binary.
+func ComplianceInfo() Compliance {
+	fipsMu.RLock()
+	defer fipsMu.RUnlock()
+	provider, active := fipsProvider()
+	cryptoMu.RLock()
+	algorithms := providerAlgorithms(cryptoProvider)
+	cryptoMu.RUnlock()
+	if !fipsMode {
+		algorithms = append(algorithms, hybridAlg)
+	}
+	return Compliance{
+		FIPSMode:       fipsMode,
+		FIPSBuild:      fipsBuild,
+		Provider:       provider,
+		ProviderActive: active,
+		Algorithms:     algorithms,
+	}
+}
+
+// Get the algorithms used by provider. Only the cipher of a site supplied
+// provider is known.
+func providerAlgorithms(provider CryptoProvider) []string {
+	switch provider.(type) {
+	case AESGCMProvider, *AESGCMProvider:
+		return []string{AlgAES256GCM, "HMAC-SHA-256"}
+	}
+	return []string{provider.Algorithm()}
+}
+
+// Turn FIPS mode on or off. Turning it on fails unless a validated provider
+// is active (GOFIPS140 or GODEBUG=fips140=on with Go 1.24 and later, or
+// GOEXPERIMENT=boringcrypto) and the CryptoProvider is FIPS approved.
+// Binaries built with the securestorage_fips tag cannot turn it off.
+func SetFIPSMode(on bool) error {
+	fipsMu.Lock()
+	defer fipsMu.Unlock()
+	if !on && fipsBuild {
+		return fmt.Errorf("FIPS mode cannot be turned off in a securestorage_fips build")
+	}
+	if on {
+		if provider, active := fipsProvider(); !active {
+			return fmt.Errorf("%w: no validated provider is active (%s)", ErrNotCompliant, provider)
+		}
+		cryptoMu.RLock()
+		current := cryptoProvider
+		cryptoMu.RUnlock()
+		if !fipsApproved(current) {
+			return fmt.Errorf("%w: crypto provider %s is not FIPS approved", ErrNotCompliant, current.Algorithm())
+		}
+	}
+	fipsMode = on
+	return nil
+}
+
+// Check if FIPS mode is on.
+func fipsEnabled() bool {
+	fipsMu.RLock()
+	defer fipsMu.RUnlock()
+	return fipsMode
+}
+
+// Check that crypto may be used, returning an error in FIPS mode if the
+// validated provider is not active. This can only happen in a
+// securestorage_fips build.
+func checkCompliance() error {
+	info := ComplianceInfo()
+	if info.FIPSMode && !info.ProviderActive {
+		return fmt.Errorf("%w: no validated provider is active (%s)", ErrNotCompliant, info.Provider)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestComplianceInfo(t *testing.T) {
+	info := ComplianceInfo()
+	if info.FIPSMode != fipsBuild || info.FIPSBuild != fipsBuild || info.Provider == "" {
+		t.Errorf("Test Failed: Unexpected compliance info %+v", info)
+	}
+	if !fipsBuild && !reflect.DeepEqual(info.Algorithms, []string{AlgAES256GCM, "HMAC-SHA-256", hybridAlg}) {
+		t.Errorf("Test Failed: Unexpected algorithms %v", info.Algorithms)
+	}
+	if !fipsBuild {
+		SetCryptoProvider(&countingProvider{})
+		algorithms := ComplianceInfo().Algorithms
+		SetCryptoProvider(nil)
+		if !reflect.DeepEqual(algorithms, []string{"TEST-AEAD", hybridAlg}) {
+			t.Errorf("Test Failed: Expected the provider's algorithm but got %v", algorithms)
+		}
+	}
+
+	err := SetFIPSMode(true)
+	if info.ProviderActive && err != nil {
+		t.Errorf("Test Failed: Unexpected error enabling FIPS mode - %v", err)
+	}
+	if !info.ProviderActive && !errors.Is(err, ErrNotCompliant) {
+		t.Errorf("Test Failed: Expected ErrNotCompliant enabling FIPS mode but got %v", err)
+	}
+
+	// Force FIPS mode as a securestorage_fips build would.
+	fipsMu.Lock()
+	fipsMode = true
+	fipsMu.Unlock()
+	defer func() {
+		fipsMu.Lock()
+		fipsMode = fipsBuild
+		fipsMu.Unlock()
+	}()
+	key, _ := GenerateMasterKey()
+	kp, _ := NewStaticKeyProvider(key)
+	err = Encrypted(newMemStore(), kp).Store("key", creds{Password: "pw"})
+	if info.ProviderActive && err != nil {
+		t.Errorf("Test Failed: Unexpected error in FIPS mode - %v", err)
+	}
+	if !info.ProviderActive && !errors.Is(err, ErrNotCompliant) {
+		t.Errorf("Test Failed: Expected ErrNotCompliant without a provider but got %v", err)
+	}
+	if info := ComplianceInfo(); !reflect.DeepEqual(info.Algorithms, []string{AlgAES256GCM, "HMAC-SHA-256"}) {
+		t.Errorf("Test Failed: Unexpected algorithms in FIPS mode %v", info.Algorithms)
+	}
+	if !fipsBuild && SetFIPSMode(false) != nil {
+		t.Errorf("Test Failed: Expected FIPS mode to be turned off")
+	}
+}
@@ -0,0 +1,104 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+)
+
+type mockHealthVaultApi struct {
+	*MockVaultApi
+	resp *api.HealthResponse
+	err  error
+}
+
+func (v *mockHealthVaultApi) Health() (*api.HealthResponse, error) {
+	return v.resp, v.err
+}
+
+func TestVaultAdapterHealth(t *testing.T) {
+	var tests = []struct {
+		resp    *api.HealthResponse
+		err     error
+		vRData  []MockVRead
+		respErr bool
+	}{
+		{
+			resp:   &api.HealthResponse{Initialized: true},
+			vRData: []MockVRead{{Output: OutputVRead{S: &api.Secret{}}}},
+		}, {
+			resp:    &api.HealthResponse{Initialized: true, Sealed: true},
+			respErr: true,
+		}, {
+			resp:    &api.HealthResponse{Initialized: false},
+			respErr: true,
+		}, {
+			err:     fmt.Errorf("connection refused"),
+			respErr: true,
+		}, {
+			resp:    &api.HealthResponse{Initialized: true},
+			vRData:  []MockVRead{{Output: OutputVRead{Err: fmt.Errorf("Code: 400")}}},
+			respErr: true,
+		},
+	}
+
+	for i, test := range tests {
+		ss := &VaultAdapter{
+			BasePath:   "secret/hms-cred",
+			VaultRetry: 1,
+		}
+		_, vmock := NewMockVaultApi()
+		vmock.ReadData = test.vRData
+		ss.Client = &mockHealthVaultApi{MockVaultApi: vmock, resp: test.resp, err: test.err}
+		err := ss.Health()
+		if (err != nil) != test.respErr {
+			t.Errorf("Test %v Failed: Unexpected health result - %v", i, err)
+		}
+		if len(test.vRData) > 0 && vmock.ReadData[0].Input.Path != "auth/token/lookup-self" {
+			t.Errorf("Test %v Failed: Expected token lookup but got %v", i, vmock.ReadData[0].Input.Path)
+		}
+	}
+}
+
+func TestHealth(t *testing.T) {
+	down := &failStore{err: fmt.Errorf("Code: 503")}
+	var tests = []struct {
+		ss      SecureStorage
+		respErr bool
+	}{
+		{ss: newMemStore()},
+		{ss: down, respErr: true},
+		{ss: NewCacheAdapter(down, 0, 0), respErr: true},
+		{ss: Fallback(down, newMemStore())},
+		{ss: Fallback(down, down), respErr: true},
+		{ss: ReadOnly(WithPrefix(newMemStore(), "hms-creds"))},
+	}
+	for i, test := range tests {
+		if err := Health(test.ss); (err != nil) != test.respErr {
+			t.Errorf("Test %v Failed: Unexpected health result - %v", i, err)
+		}
+	}
+}
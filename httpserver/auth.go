@@ -0,0 +1,121 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package httpserver
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	securestorage "github.com/Cray-HPE/hms-securestorage"
+)
+
+// Get Middleware accepting requests with an "Authorization: Bearer" header
+// carrying one of the given tokens. tokens maps each token to the principal
+// it identifies.
+func BearerTokenAuth(tokens map[string]string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if ok {
+				for token, principal := range tokens {
+					if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1 {
+						next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+						return
+					}
+				}
+			}
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		})
+	}
+}
+
+// Get the SecureStorage operation and key that a request to a Server maps
+// to. ok is false for requests that do not match any endpoint or method,
+// such as HEAD, and which must not be allowed. err is set
+// (wrapping securestorage.ErrInvalidKey) for requests whose key fails
+// securestorage.CheckKey(), is empty, or contains an escaped "/"; such
+// requests must be rejected, since the backend would resolve the key to a
+// different one than the one authorized. The Server uses the same key for
+// the store call.
+func RequestOperation(r *http.Request) (op string, key string, ok bool, err error) {
+	if key, found := strings.CutPrefix(r.URL.Path, "/secrets/"); found {
+		switch r.Method {
+		case http.MethodGet:
+			op = securestorage.OpLookup
+		case http.MethodPut:
+			op = securestorage.OpStore
+		case http.MethodDelete:
+			op = securestorage.OpDelete
+		default:
+			return "", "", false, nil
+		}
+		if key == "" {
+			return op, key, true, fmt.Errorf("%w: key must not be empty", securestorage.ErrInvalidKey)
+		}
+		return op, key, true, checkRequestKey(r, key)
+	}
+	if r.URL.Path == "/keys" && r.Method == http.MethodGet {
+		key := r.URL.Query().Get("path")
+		return securestorage.OpLookupKeys, key, true, securestorage.CheckKey(key)
+	}
+	return "", "", false, nil
+}
+
+// Check a key taken from the request path. An escaped "/" would make the
+// key differ from the path the request was routed by.
+func checkRequestKey(r *http.Request, key string) error {
+	if strings.Contains(strings.ToLower(r.URL.EscapedPath()), "%2f") {
+		return fmt.Errorf("%w %q: must not contain an escaped \"/\"", securestorage.ErrInvalidKey, key)
+	}
+	return securestorage.CheckKey(key)
+}
+
+// Get Middleware checking each request with authorize, given the principal
+// recorded by the authentication middleware before it and the operation
+// and key requested. Requests are rejected with 403 when authorize returns
+// an error or they do not map to an operation, and with 400 when their key
+// is invalid.
+func Authorize(authorize func(principal string, op string, key string) error) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			op, key, ok, err := RequestOperation(r)
+			if err != nil {
+				WriteError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if !ok {
+				WriteError(w, http.StatusForbidden, fmt.Sprintf("%s %s is not allowed", r.Method, r.URL.Path))
+				return
+			}
+			err = authorize(PrincipalFromContext(r.Context()), op, key)
+			if err != nil {
+				WriteError(w, http.StatusForbidden, err.Error())
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
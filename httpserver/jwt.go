@@ -0,0 +1,155 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package httpserver
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	xed25519 "golang.org/x/crypto/ed25519"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// Default claim used as the principal by JWTAuth()
+const DefaultJWTClaim = "sub"
+
+// JWTConfig describes the tokens accepted by JWTAuth(). Tokens must be
+// signed with RS256/384/512, ES256/384/512 or EdDSA by one of Keys, and
+// must not be expired. ECDSA keys must be on the curve named by the
+// algorithm. Issuer and Audience are checked when set. The principal is
+// the string value of Claim, DefaultJWTClaim if empty.
+type JWTConfig struct {
+	Keys     []crypto.PublicKey
+	Issuer   string
+	Audience string
+	Claim    string
+	Leeway   time.Duration
+
+	now func() time.Time
+}
+
+// Curves required of ECDSA keys for each algorithm.
+var jwtCurves = map[jose.SignatureAlgorithm]elliptic.Curve{
+	jose.ES256: elliptic.P256(),
+	jose.ES384: elliptic.P384(),
+	jose.ES512: elliptic.P521(),
+}
+
+// Get the key to verify a token signed with alg, or nil if key cannot be
+// used with alg.
+func jwtKey(alg jose.SignatureAlgorithm, key crypto.PublicKey) interface{} {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if alg == jose.RS256 || alg == jose.RS384 || alg == jose.RS512 {
+			return k
+		}
+	case *ecdsa.PublicKey:
+		if curve, ok := jwtCurves[alg]; ok && k.Curve == curve {
+			return k
+		}
+	case ed25519.PublicKey:
+		if alg == jose.EdDSA {
+			return xed25519.PublicKey(k)
+		}
+	}
+	return nil
+}
+
+// Verify token and get its principal.
+func (cfg *JWTConfig) verify(token string) (string, error) {
+	var (
+		std    jwt.Claims
+		claims map[string]interface{}
+	)
+
+	tok, err := jwt.ParseSigned(token)
+	if err != nil || len(tok.Headers) != 1 {
+		return "", fmt.Errorf("Malformed token")
+	}
+	alg := jose.SignatureAlgorithm(tok.Headers[0].Algorithm)
+	verified := false
+	for _, key := range cfg.Keys {
+		k := jwtKey(alg, key)
+		if k != nil && tok.Claims(k, &std, &claims) == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return "", fmt.Errorf("Invalid token signature")
+	}
+
+	now := time.Now
+	if cfg.now != nil {
+		now = cfg.now
+	}
+	if std.Expiry == nil {
+		return "", fmt.Errorf("Token has no exp claim")
+	}
+	expected := jwt.Expected{Issuer: cfg.Issuer, Time: now()}
+	if cfg.Audience != "" {
+		expected.Audience = jwt.Audience{cfg.Audience}
+	}
+	if err := std.ValidateWithLeeway(expected, cfg.Leeway); err != nil {
+		return "", fmt.Errorf("Invalid token claims: %v", err)
+	}
+	claim := cfg.Claim
+	if claim == "" {
+		claim = DefaultJWTClaim
+	}
+	principal, _ := claims[claim].(string)
+	if principal == "" {
+		return "", fmt.Errorf("Token has no %s claim", claim)
+	}
+	return principal, nil
+}
+
+// Get Middleware identifying callers by a JWT in an "Authorization:
+// Bearer" header, for use with Authorize() or RBAC(). Requests without a
+// valid token get 401.
+func JWTAuth(cfg JWTConfig) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				WriteError(w, http.StatusUnauthorized, "Unauthorized")
+				return
+			}
+			principal, err := cfg.verify(token)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				WriteError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package httpserver
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Sign claims as a JWT with key.
+func signJWT(t *testing.T, alg string, key crypto.Signer, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	var (
+		sig []byte
+		err error
+	)
+	hash, size := crypto.SHA256, 32
+	switch alg[2:] {
+	case "384":
+		hash, size = crypto.SHA384, 48
+	case "512":
+		hash, size = crypto.SHA512, 66
+	}
+	h := hash.New()
+	h.Write([]byte(signed))
+	sum := h.Sum(nil)
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(k, []byte(signed))
+	case *ecdsa.PrivateKey:
+		r, s, serr := ecdsa.Sign(rand.Reader, k, sum)
+		err = serr
+		size = max(size, (k.Curve.Params().BitSize+7)/8)
+		sig = append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...)
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, hash, sum)
+	}
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestJWTAuth(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	p384Key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	cfg := JWTConfig{
+		Keys:     []crypto.PublicKey{edKey.Public(), ecKey.Public(), p384Key.Public(), rsaKey.Public()},
+		Issuer:   "https://issuer",
+		Audience: "securestorage",
+		now:      func() time.Time { return now },
+	}
+	rbac := &RBACConfig{
+		Roles:    map[string][]Permission{"reader": {{Prefix: "hms-creds", Operations: []string{"lookup"}}}},
+		Bindings: map[string][]string{"system:serviceaccount:services:cray-smd": {"reader"}},
+	}
+	store := newMemStore()
+	store.Store("hms-creds/x0c0s1b0", map[string]interface{}{"Password": "pw"})
+	server := NewServer(store, JWTAuth(cfg), RBAC(rbac))
+
+	claims := func(changes map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"iss": "https://issuer",
+			"aud": []string{"other", "securestorage"},
+			"sub": "system:serviceaccount:services:cray-smd",
+			"exp": now.Add(time.Hour).Unix(),
+		}
+		for k, v := range changes {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+
+	var tests = []struct {
+		token  string
+		status int
+	}{
+		{token: signJWT(t, "EdDSA", edKey, claims(nil)), status: 200},
+		{token: signJWT(t, "ES256", ecKey, claims(nil)), status: 200},
+		{token: signJWT(t, "ES384", p384Key, claims(nil)), status: 200},
+		{token: signJWT(t, "RS512", rsaKey, claims(nil)), status: 200},
+		{token: signJWT(t, "RS256", rsaKey, claims(map[string]interface{}{"aud": "securestorage"})), status: 200},
+		{token: signJWT(t, "EdDSA", edKey, claims(map[string]interface{}{"sub": "someone-else"})), status: 403},
+		{token: signJWT(t, "EdDSA", otherKey, claims(nil)), status: 401},
+		{token: signJWT(t, "ES256", edKey, claims(nil)), status: 401},
+		{token: signJWT(t, "ES384", ecKey, claims(nil)), status: 401},
+		{token: signJWT(t, "ES256", p384Key, claims(nil)), status: 401},
+		{token: signJWT(t, "HS256", edKey, claims(nil)), status: 401},
+		{token: signJWT(t, "EdDSA", edKey, claims(map[string]interface{}{"exp": now.Add(-time.Minute).Unix()})), status: 401},
+		{token: signJWT(t, "EdDSA", edKey, claims(map[string]interface{}{"exp": nil})), status: 401},
+		{token: signJWT(t, "EdDSA", edKey, claims(map[string]interface{}{"nbf": now.Add(time.Minute).Unix()})), status: 401},
+		{token: signJWT(t, "EdDSA", edKey, claims(map[string]interface{}{"iss": "https://evil"})), status: 401},
+		{token: signJWT(t, "EdDSA", edKey, claims(map[string]interface{}{"aud": "other"})), status: 401},
+		{token: signJWT(t, "EdDSA", edKey, claims(map[string]interface{}{"sub": nil})), status: 401},
+		{token: "not.a.token", status: 401},
+		{token: "", status: 401},
+	}
+
+	for i, test := range tests {
+		req := httptest.NewRequest("GET", "/secrets/hms-creds/x0c0s1b0", nil)
+		if test.token != "" {
+			req.Header.Set("Authorization", "Bearer "+test.token)
+		}
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		if rec.Code != test.status {
+			t.Errorf("Test %v Failed: Expected status %d but got %d (%s)", i, test.status, rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package httpserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	securestorage "github.com/Cray-HPE/hms-securestorage"
+)
+
+// Create a server tls.Config that requires clients to present a
+// certificate signed by one of the CAs in clientCAFile.
+func NewTLSConfig(certFile string, keyFile string, clientCAFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	pem, err := os.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("No certificates found in %s", clientCAFile)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// Get Middleware identifying callers by the subject common name of their
+// verified client certificate, for use with Authorize() or RBAC(). Callers
+// without a verified certificate get 401. Use with a tls.Config from
+// NewTLSConfig().
+func ClientCertIdentity() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+				WriteError(w, http.StatusUnauthorized, "A verified client certificate is required")
+				return
+			}
+			subject := r.TLS.VerifiedChains[0][0].Subject.CommonName
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), subject)))
+		})
+	}
+}
+
+// Get Middleware identifying callers with ClientCertIdentity() and
+// authorizing each request with the ACL for their name. Callers without a
+// verified certificate get 401, those without an ACL or denied by it get
+// 403 and those with a key rejected by RequestOperation() get 400.
+func ClientCertAuth(policies map[string]*securestorage.ACL) Middleware {
+	identify := ClientCertIdentity()
+	authorize := Authorize(func(principal string, op string, key string) error {
+		acl, ok := policies[principal]
+		if !ok {
+			return fmt.Errorf("%w: no policy for %s", securestorage.ErrAccessDenied, principal)
+		}
+		return acl.Check(op, key)
+	})
+	return func(next http.Handler) http.Handler {
+		return identify(authorize(next))
+	}
+}
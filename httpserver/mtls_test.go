@@ -0,0 +1,166 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package httpserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	securestorage "github.com/Cray-HPE/hms-securestorage"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCert(t *testing.T, cn string, parent *testCert, isCA bool) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		IsCA:         isCA,
+
+		BasicConstraintsValid: true,
+	}
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+func (tc *testCert) tlsCert() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{tc.der}, PrivateKey: tc.key}
+}
+
+func (tc *testCert) writeFiles(t *testing.T, dir string, name string) (string, string) {
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	keyDER, _ := x509.MarshalECPrivateKey(tc.key)
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tc.der}), 0600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	return certFile, keyFile
+}
+
+func TestClientCertAuth(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "test-ca", nil, true)
+	otherCA := newTestCert(t, "other-ca", nil, true)
+	serverCert := newTestCert(t, "127.0.0.1", ca, false)
+	caFile, _ := ca.writeFiles(t, dir, "ca")
+	certFile, keyFile := serverCert.writeFiles(t, dir, "server")
+
+	tlsConfig, err := NewTLSConfig(certFile, keyFile, caFile)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	toolsACL, _ := securestorage.NewACL(
+		securestorage.ACLRule{Effect: securestorage.Allow, Pattern: "hms-creds/**", Operations: []string{securestorage.OpLookup}},
+	)
+	store := newMemStore()
+	store.Store("hms-creds/x0c0s1b0", map[string]interface{}{"Password": "pw"})
+	server := httptest.NewUnstartedServer(NewServer(store, ClientCertAuth(map[string]*securestorage.ACL{"hms-tools": toolsACL})))
+	server.TLS = tlsConfig
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	var tests = []struct {
+		client *testCert
+		method string
+		path   string
+		status int
+	}{
+		{client: newTestCert(t, "hms-tools", ca, false), method: "GET", path: "/secrets/hms-creds/x0c0s1b0", status: 200},
+		{client: newTestCert(t, "hms-tools", ca, false), method: "DELETE", path: "/secrets/hms-creds/x0c0s1b0", status: 403},
+		{client: newTestCert(t, "hms-tools", ca, false), method: "GET", path: "/secrets/other", status: 403},
+		{client: newTestCert(t, "intruder", ca, false), method: "GET", path: "/secrets/hms-creds/x0c0s1b0", status: 403},
+		{client: newTestCert(t, "hms-tools", ca, false), method: "GET", path: "/secrets/hms-creds/%2e%2e/other", status: 400},
+		{client: newTestCert(t, "hms-tools", ca, false), method: "GET", path: "/secrets/hms-creds/x%2f..%2f..%2fother", status: 400},
+		{client: newTestCert(t, "hms-tools", otherCA, false), method: "GET", path: "/secrets/hms-creds/x0c0s1b0", status: 0},
+		{client: nil, method: "GET", path: "/secrets/hms-creds/x0c0s1b0", status: 0},
+	}
+
+	for i, test := range tests {
+		clientTLS := &tls.Config{RootCAs: roots}
+		if test.client != nil {
+			clientTLS.Certificates = []tls.Certificate{test.client.tlsCert()}
+		}
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLS}}
+		req, _ := http.NewRequest(test.method, server.URL+test.path, strings.NewReader(""))
+		resp, err := client.Do(req)
+		if test.status == 0 {
+			if err == nil {
+				resp.Body.Close()
+				t.Errorf("Test %v Failed: Expected the TLS handshake to fail but got %d", i, resp.StatusCode)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != test.status {
+			t.Errorf("Test %v Failed: Expected status %d but got %d", i, test.status, resp.StatusCode)
+		}
+	}
+
+	// Without TLS there is no client certificate at all.
+	plain := httptest.NewServer(NewServer(store, ClientCertAuth(nil)))
+	defer plain.Close()
+	resp, err := http.Get(plain.URL + "/keys")
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without TLS but got %v (%v)", resp, err)
+	}
+}
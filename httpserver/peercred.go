@@ -0,0 +1,161 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	securestorage "github.com/Cray-HPE/hms-securestorage"
+)
+
+// PeerCred identifies the process at the other end of a Unix socket.
+type PeerCred struct {
+	PID int32
+	UID uint32
+	GID uint32
+}
+
+type peerCredKey struct{}
+
+// Listen on a Unix socket at path with the given file mode, removing a
+// socket left behind by a previous run.
+func ListenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		os.Remove(path)
+	}
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	err = os.Chmod(path, mode)
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// Record the credentials of Unix socket peers in each request's context.
+// Set as the http.Server ConnContext to use PeerCredAuth.
+func ConnContext(ctx context.Context, c net.Conn) context.Context {
+	if uc, ok := c.(*net.UnixConn); ok {
+		if cred, err := peerCredentials(uc); err == nil {
+			return context.WithValue(ctx, peerCredKey{}, cred)
+		}
+	}
+	return ctx
+}
+
+// Get the Unix socket peer credentials recorded by ConnContext.
+func PeerCredFromContext(ctx context.Context) (*PeerCred, bool) {
+	cred, ok := ctx.Value(peerCredKey{}).(*PeerCred)
+	return cred, ok
+}
+
+// PeerPolicy allows processes running as one of UIDs, or with one of GIDs
+// as their primary group, to perform Operations (all if empty) on the keys
+// below Prefix (every key if empty).
+type PeerPolicy struct {
+	Prefix     string
+	UIDs       []uint32
+	GIDs       []uint32
+	Operations []string
+}
+
+func (pp PeerPolicy) allows(cred *PeerCred, op string, key string) bool {
+	if securestorage.CheckKey(key) != nil {
+		return false
+	}
+	prefix := strings.Trim(pp.Prefix, "/")
+	if prefix != "" && key != prefix && !strings.HasPrefix(key, prefix+"/") {
+		return false
+	}
+	if len(pp.Operations) > 0 && !contains(pp.Operations, op) {
+		return false
+	}
+	for _, uid := range pp.UIDs {
+		if uid == cred.UID {
+			return true
+		}
+	}
+	for _, gid := range pp.GIDs {
+		if gid == cred.GID {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Get Middleware authorizing requests on a Unix socket by the peer's user
+// and group, allowing a request if any policy does. The principal is
+// recorded as "uid:<uid>". Requests without peer credentials, such as those
+// over TCP or without ConnContext set, get 401.
+func PeerCredAuth(policies ...PeerPolicy) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cred, ok := PeerCredFromContext(r.Context())
+			if !ok {
+				WriteError(w, http.StatusUnauthorized, "Peer credentials are required")
+				return
+			}
+			op, key, ok, err := RequestOperation(r)
+			if err != nil {
+				WriteError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if !ok {
+				WriteError(w, http.StatusForbidden, fmt.Sprintf("%s %s is not allowed", r.Method, r.URL.Path))
+				return
+			}
+			allowed := false
+			for _, pp := range policies {
+				if pp.allows(cred, op, key) {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				err = fmt.Errorf("%w: %s %s for uid %d", securestorage.ErrAccessDenied, op, key, cred.UID)
+				WriteError(w, http.StatusForbidden, err.Error())
+				return
+			}
+			principal := "uid:" + strconv.FormatUint(uint64(cred.UID), 10)
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+//go:build linux
+
+package httpserver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	securestorage "github.com/Cray-HPE/hms-securestorage"
+)
+
+func TestPeerCredAuth(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "securestorage.sock")
+	l, err := ListenUnix(socket, 0600)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	info, _ := os.Stat(socket)
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected socket mode 0600 but got %v", info.Mode().Perm())
+	}
+
+	store := newMemStore()
+	store.Store("hms-creds/x0c0s1b0", map[string]interface{}{"Password": "pw"})
+	uid := uint32(os.Getuid())
+	srv := &http.Server{
+		Handler: NewServer(store, PeerCredAuth(
+			PeerPolicy{Prefix: "hms-creds", UIDs: []uint32{uid}, Operations: []string{securestorage.OpLookup}},
+			PeerPolicy{Prefix: "scratch/", GIDs: []uint32{uint32(os.Getgid())}},
+		)),
+		ConnContext: ConnContext,
+	}
+	go srv.Serve(l)
+	defer srv.Close()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network string, addr string) (net.Conn, error) {
+			return net.Dial("unix", socket)
+		},
+	}}
+	var tests = []struct {
+		method string
+		path   string
+		status int
+	}{
+		{method: "GET", path: "/secrets/hms-creds/x0c0s1b0", status: 200},
+		{method: "DELETE", path: "/secrets/hms-creds/x0c0s1b0", status: 403},
+		{method: "PUT", path: "/secrets/scratch/a", status: 204},
+		{method: "GET", path: "/secrets/other", status: 403},
+		{method: "HEAD", path: "/secrets/scratch/a", status: 403},
+		{method: "GET", path: "/secrets/hms-creds/%2e%2e/other", status: 400},
+		{method: "DELETE", path: "/secrets/scratch/%2e%2e/hms-creds/x0c0s1b0", status: 400},
+	}
+	for i, test := range tests {
+		req, _ := http.NewRequest(test.method, "http://unix"+test.path, strings.NewReader(`{"a":"b"}`))
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != test.status {
+			t.Errorf("Test %v Failed: Expected status %d but got %d", i, test.status, resp.StatusCode)
+		}
+	}
+}
+
+func TestPeerPolicyAllows(t *testing.T) {
+	pp := PeerPolicy{Prefix: "hms-creds", UIDs: []uint32{1000}}
+	cred := &PeerCred{UID: 1000, GID: 1000}
+
+	var tests = []struct {
+		key     string
+		allowed bool
+	}{
+		{key: "hms-creds/x0c0s1b0", allowed: true},
+		{key: "hms-creds", allowed: true},
+		{key: "hms-creds-other/x", allowed: false},
+		{key: "hms-creds/../other", allowed: false},
+		{key: "hms-creds/./x0c0s1b0", allowed: false},
+		{key: "/hms-creds/x0c0s1b0", allowed: false},
+	}
+
+	for i, test := range tests {
+		if pp.allows(cred, securestorage.OpLookup, test.key) != test.allowed {
+			t.Errorf("Test %v Failed: Expected allowed %v for %s", i, test.allowed, test.key)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package httpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	securestorage "github.com/Cray-HPE/hms-securestorage"
+)
+
+// Operations that may be granted by a Permission
+var rbacOperations = []string{
+	securestorage.OpStore,
+	securestorage.OpStoreWithData,
+	securestorage.OpLookup,
+	securestorage.OpDelete,
+	securestorage.OpLookupKeys,
+}
+
+// Permission grants Operations (all if empty) on the keys below Prefix
+// (every key if empty).
+type Permission struct {
+	Prefix     string   `json:"prefix"`
+	Operations []string `json:"operations,omitempty"`
+}
+
+func (p Permission) allows(op string, key string) bool {
+	if securestorage.CheckKey(key) != nil {
+		return false
+	}
+	prefix := strings.Trim(p.Prefix, "/")
+	if prefix != "" && key != prefix && !strings.HasPrefix(key, prefix+"/") {
+		return false
+	}
+	return len(p.Operations) == 0 || contains(p.Operations, op)
+}
+
+// RBACConfig maps principals to roles and roles to permissions, so one
+// Server can back several consumers with different privileges. Principals
+// are the names recorded by the authentication middleware: the subject
+// common name for ClientCertIdentity(), the configured claim for JWTAuth(),
+// the configured principal for BearerTokenAuth() and "uid:<uid>" for
+// PeerCredAuth(). A request is allowed if any role bound to its principal
+// allows it.
+//
+//	{
+//	    "roles": {
+//	        "bmc-reader": [
+//	            {"prefix": "hms-creds", "operations": ["lookup", "lookup_keys"]}
+//	        ],
+//	        "admin": [
+//	            {"prefix": ""}
+//	        ]
+//	    },
+//	    "bindings": {
+//	        "cray-smd": ["bmc-reader"],
+//	        "system:serviceaccount:services:cray-hms-admin": ["admin"]
+//	    }
+//	}
+type RBACConfig struct {
+	Roles    map[string][]Permission `json:"roles"`
+	Bindings map[string][]string     `json:"bindings"`
+}
+
+// Load an RBACConfig from a JSON file and validate it.
+func LoadRBACConfig(path string) (*RBACConfig, error) {
+	var cfg RBACConfig
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(contents, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot parse RBAC config file %s: %v", path, err)
+	}
+	err = cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Check that every binding refers to a defined role and every permission
+// to known operations.
+func (cfg *RBACConfig) Validate() error {
+	for role, permissions := range cfg.Roles {
+		for _, p := range permissions {
+			for _, op := range p.Operations {
+				if !contains(rbacOperations, op) {
+					return fmt.Errorf("Unknown operation %q in role %s", op, role)
+				}
+			}
+		}
+	}
+	for principal, roles := range cfg.Bindings {
+		for _, role := range roles {
+			if _, ok := cfg.Roles[role]; !ok {
+				return fmt.Errorf("Unknown role %q bound to %s", role, principal)
+			}
+		}
+	}
+	return nil
+}
+
+// Check whether principal may perform op on key.
+func (cfg *RBACConfig) Check(principal string, op string, key string) error {
+	for _, role := range cfg.Bindings[principal] {
+		for _, p := range cfg.Roles[role] {
+			if p.allows(op, key) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%w: %s %s", securestorage.ErrAccessDenied, op, key)
+}
+
+// Get Middleware enforcing cfg. It must come after the authentication
+// middleware; requests without a principal are denied.
+func RBAC(cfg *RBACConfig) Middleware {
+	return Authorize(cfg.Check)
+}
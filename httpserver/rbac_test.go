@@ -0,0 +1,152 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package httpserver
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRBAC(t *testing.T) {
+	dir := t.TempDir()
+	cfgFile := filepath.Join(dir, "rbac.json")
+	os.WriteFile(cfgFile, []byte(`{
+		"roles": {
+			"bmc-reader": [{"prefix": "hms-creds", "operations": ["lookup", "lookup_keys"]}],
+			"switch-admin": [{"prefix": "switches/"}],
+			"admin": [{"prefix": ""}]
+		},
+		"bindings": {
+			"smd": ["bmc-reader"],
+			"netops": ["bmc-reader", "switch-admin"],
+			"root": ["admin"]
+		}
+	}`), 0600)
+	cfg, err := LoadRBACConfig(cfgFile)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+
+	store := newMemStore()
+	store.Store("hms-creds/x0c0s1b0", map[string]interface{}{"Password": "pw"})
+	store.Store("switches/x3000c0w14", map[string]interface{}{"Password": "pw"})
+	server := NewServer(store,
+		BearerTokenAuth(map[string]string{"t-smd": "smd", "t-netops": "netops", "t-root": "root", "t-none": "nobody"}),
+		RBAC(cfg))
+
+	var tests = []struct {
+		token  string
+		method string
+		path   string
+		status int
+	}{
+		{token: "t-smd", method: "GET", path: "/secrets/hms-creds/x0c0s1b0", status: 200},
+		{token: "t-smd", method: "GET", path: "/keys?path=hms-creds", status: 200},
+		{token: "t-smd", method: "DELETE", path: "/secrets/hms-creds/x0c0s1b0", status: 403},
+		{token: "t-smd", method: "GET", path: "/secrets/switches/x3000c0w14", status: 403},
+		{token: "t-smd", method: "GET", path: "/secrets/hms-creds-other/x", status: 403},
+		{token: "t-netops", method: "PUT", path: "/secrets/switches/x3000c0w14", status: 204},
+		{token: "t-netops", method: "GET", path: "/secrets/hms-creds/x0c0s1b0", status: 200},
+		{token: "t-netops", method: "PUT", path: "/secrets/hms-creds/x0c0s1b0", status: 403},
+		{token: "t-root", method: "DELETE", path: "/secrets/switches/x3000c0w14", status: 204},
+		{token: "t-none", method: "GET", path: "/keys", status: 403},
+		{token: "t-smd", method: "HEAD", path: "/secrets/hms-creds/x0c0s1b0", status: 403},
+		{token: "t-root", method: "HEAD", path: "/secrets/switches/x3000c0w14", status: 403},
+		{token: "t-root", method: "POST", path: "/secrets/switches/x3000c0w14", status: 403},
+		{token: "t-smd", method: "GET", path: "/secrets/hms-creds/%2e%2e/root/x", status: 400},
+		{token: "t-smd", method: "GET", path: "/secrets/hms-creds/%2E%2E/%2E%2E/sys/x", status: 400},
+		{token: "t-smd", method: "GET", path: "/secrets/hms-creds/%2e/x0c0s1b0", status: 400},
+		{token: "t-smd", method: "GET", path: "/secrets/hms-creds%2fx0c0s1b0", status: 400},
+		{token: "t-smd", method: "GET", path: "/secrets/hms-creds/a%2F..%2Fb", status: 400},
+		{token: "t-smd", method: "GET", path: "/keys?path=hms-creds/..", status: 400},
+		{token: "t-smd", method: "GET", path: "/keys?path=/hms-creds", status: 400},
+		{token: "t-root", method: "DELETE", path: "/secrets/", status: 400},
+		{token: "t-root", method: "GET", path: "/secrets/hms-creds/%2e%2e/switches/x3000c0w14", status: 400},
+	}
+
+	for i, test := range tests {
+		req := httptest.NewRequest(test.method, test.path, strings.NewReader(`{"Password": "new"}`))
+		req.Header.Set("Authorization", "Bearer "+test.token)
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		if rec.Code != test.status {
+			t.Errorf("Test %v Failed: Expected status %d but got %d (%s)", i, test.status, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestRBACConfigCheck(t *testing.T) {
+	cfg := &RBACConfig{
+		Roles:    map[string][]Permission{"bmc-reader": {{Prefix: "hms-creds"}}},
+		Bindings: map[string][]string{"smd": {"bmc-reader"}},
+	}
+
+	var tests = []struct {
+		key     string
+		allowed bool
+	}{
+		{key: "hms-creds/x0c0s1b0", allowed: true},
+		{key: "hms-creds", allowed: true},
+		{key: "hms-creds/../root/x", allowed: false},
+		{key: "hms-creds/./x0c0s1b0", allowed: false},
+		{key: "hms-creds//x0c0s1b0", allowed: false},
+		{key: "/hms-creds/x0c0s1b0", allowed: false},
+	}
+
+	for i, test := range tests {
+		err := cfg.Check("smd", "lookup", test.key)
+		if (err == nil) != test.allowed {
+			t.Errorf("Test %v Failed: Expected allowed %v but got %v", i, test.allowed, err)
+		}
+	}
+}
+
+func TestRBACConfigValidate(t *testing.T) {
+	var tests = []struct {
+		cfg     RBACConfig
+		respErr bool
+	}{
+		{cfg: RBACConfig{Roles: map[string][]Permission{"r": {{Prefix: "a", Operations: []string{"lookup"}}}}, Bindings: map[string][]string{"p": {"r"}}}},
+		{cfg: RBACConfig{Roles: map[string][]Permission{"r": {{Prefix: "a", Operations: []string{"read"}}}}}, respErr: true},
+		{cfg: RBACConfig{Bindings: map[string][]string{"p": {"missing"}}}, respErr: true},
+		{cfg: RBACConfig{}},
+	}
+
+	for i, test := range tests {
+		err := test.cfg.Validate()
+		if (err != nil) != test.respErr {
+			t.Errorf("Test %v Failed: Unexpected error result - %v", i, err)
+		}
+	}
+
+	if _, err := LoadRBACConfig(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("Expected an error for a missing file")
+	}
+	bad := filepath.Join(t.TempDir(), "bad.json")
+	os.WriteFile(bad, []byte(`{"roles": [}`), 0600)
+	if _, err := LoadRBACConfig(bad); err == nil {
+		t.Errorf("Expected an error for invalid JSON")
+	}
+}
@@ -0,0 +1,209 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+// Package httpserver exposes a SecureStorage over a small HTTP API:
+//
+//	GET    /secrets/{key}  read a value as a JSON object
+//	PUT    /secrets/{key}  store the JSON object in the request body
+//	DELETE /secrets/{key}  delete a value
+//	GET    /keys?path=p    list the keys below p
+//
+// Authentication and authorization are provided by Middleware wrapped
+// around the handlers.
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	securestorage "github.com/Cray-HPE/hms-securestorage"
+)
+
+// Largest request body accepted by PUT
+const MaxValueSize = 1 << 20
+
+// Middleware wraps the handlers of a Server, typically to authenticate
+// requests.
+type Middleware func(next http.Handler) http.Handler
+
+type principalKey struct{}
+
+// Record the authenticated caller of a request. Used by authentication
+// middleware.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// Get the authenticated caller of a request, or "" if there is none.
+func PrincipalFromContext(ctx context.Context) string {
+	principal, _ := ctx.Value(principalKey{}).(string)
+	return principal
+}
+
+// Server serves the HTTP API for a SecureStorage. It is an http.Handler so
+// it can be mounted into an existing mux.
+type Server struct {
+	Store   securestorage.SecureStorage
+	handler http.Handler
+}
+
+// Create a new Server for store. Middleware is applied in order, the first
+// one seeing each request first.
+func NewServer(store securestorage.SecureStorage, middleware ...Middleware) *Server {
+	s := &Server{Store: store}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /secrets/{key...}", s.getSecret)
+	mux.HandleFunc("PUT /secrets/{key...}", s.putSecret)
+	mux.HandleFunc("DELETE /secrets/{key...}", s.deleteSecret)
+	mux.HandleFunc("GET /keys", s.getKeys)
+
+	var handler http.Handler = mux
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	s.handler = handler
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// Write an error response, mapping the errors returned by this module's
+// adapters to HTTP status codes.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, securestorage.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, securestorage.ErrAccessDenied),
+		errors.Is(err, securestorage.ErrReadOnly),
+		errors.Is(err, securestorage.ErrQuotaExceeded),
+		errors.Is(err, securestorage.ErrClassification):
+		status = http.StatusForbidden
+	case errors.Is(err, securestorage.ErrValidation),
+		errors.Is(err, securestorage.ErrInvalidKey):
+		status = http.StatusBadRequest
+	case errors.Is(err, securestorage.ErrApprovalRequired):
+		status = http.StatusAccepted
+	}
+	WriteError(w, status, securestorage.DefaultRedactor.Redact(err.Error()))
+}
+
+// Write a JSON error response. Exported for use by Middleware.
+func WriteError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// Get the key of a request, writing an error response if it is invalid or
+// the request does not map to an operation, such as a HEAD request matched
+// by a GET pattern. The key is the one checked by Authorize().
+func requestKey(w http.ResponseWriter, r *http.Request) (string, bool) {
+	_, key, ok, err := RequestOperation(r)
+	if !ok {
+		WriteError(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s %s is not allowed", r.Method, r.URL.Path))
+		return "", false
+	}
+	if err != nil {
+		writeError(w, err)
+		return "", false
+	}
+	return key, true
+}
+
+func (s *Server) getSecret(w http.ResponseWriter, r *http.Request) {
+	var data map[string]interface{}
+
+	key, ok := requestKey(w, r)
+	if !ok {
+		return
+	}
+	err := s.Store.Lookup(key, &data)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if data == nil {
+		writeError(w, fmt.Errorf("%w: %s", securestorage.ErrNotFound, key))
+		return
+	}
+	writeJSON(w, http.StatusOK, data)
+}
+
+func (s *Server) putSecret(w http.ResponseWriter, r *http.Request) {
+	var data map[string]interface{}
+
+	key, ok := requestKey(w, r)
+	if !ok {
+		return
+	}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxValueSize)).Decode(&data)
+	if err != nil || data == nil {
+		WriteError(w, http.StatusBadRequest, "Request body must be a JSON object")
+		return
+	}
+	err = s.Store.Store(key, data)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteSecret(w http.ResponseWriter, r *http.Request) {
+	key, ok := requestKey(w, r)
+	if !ok {
+		return
+	}
+	err := s.Store.Delete(key)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getKeys(w http.ResponseWriter, r *http.Request) {
+	keyPath, ok := requestKey(w, r)
+	if !ok {
+		return
+	}
+	keys, err := s.Store.LookupKeys(keyPath)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if keys == nil {
+		keys = []string{}
+	}
+	writeJSON(w, http.StatusOK, map[string][]string{"keys": keys})
+}
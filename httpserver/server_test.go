@@ -0,0 +1,159 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	securestorage "github.com/Cray-HPE/hms-securestorage"
+	"github.com/mitchellh/mapstructure"
+)
+
+// memStore is a minimal in-memory SecureStorage listing keys one level at a
+// time like Vault.
+type memStore struct {
+	mu   sync.Mutex
+	data map[string]map[string]interface{}
+}
+
+func newMemStore() *memStore {
+	return &memStore{data: map[string]map[string]interface{}{}}
+}
+
+func (ms *memStore) Store(key string, value interface{}) error {
+	var data map[string]interface{}
+	if err := mapstructure.Decode(value, &data); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.data[key] = data
+	return nil
+}
+
+func (ms *memStore) StoreWithData(key string, value interface{}, output interface{}) error {
+	return ms.Store(key, value)
+}
+
+func (ms *memStore) Lookup(key string, output interface{}) error {
+	ms.mu.Lock()
+	data, ok := ms.data[key]
+	ms.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return mapstructure.Decode(data, output)
+}
+
+func (ms *memStore) Delete(key string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delete(ms.data, key)
+	return nil
+}
+
+func (ms *memStore) LookupKeys(keyPath string) ([]string, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	prefix := strings.TrimSuffix(keyPath, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	seen := map[string]bool{}
+	var keys []string
+	for key := range ms.data {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		name := strings.TrimPrefix(key, prefix)
+		if i := strings.Index(name, "/"); i >= 0 {
+			name = name[:i+1]
+		}
+		if !seen[name] {
+			seen[name] = true
+			keys = append(keys, name)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func TestServer(t *testing.T) {
+	store := newMemStore()
+	store.Store("hms-creds/x0c0s1b0", map[string]interface{}{"Username": "root", "Password": "pw"})
+	ro := securestorage.ReadOnly(store)
+	mux := http.NewServeMux()
+	mux.Handle("/", NewServer(store, BearerTokenAuth(map[string]string{"s3cret": "admin"})))
+	mux.Handle("/ro/", http.StripPrefix("/ro", NewServer(ro)))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var tests = []struct {
+		method string
+		path   string
+		token  string
+		body   string
+		status int
+		expect string
+	}{
+		{method: "GET", path: "/secrets/hms-creds/x0c0s1b0", token: "s3cret", status: 200, expect: `{"Password":"pw","Username":"root"}`},
+		{method: "GET", path: "/secrets/hms-creds/x0c0s1b0", token: "wrong", status: 401},
+		{method: "GET", path: "/secrets/hms-creds/x0c0s1b0", status: 401},
+		{method: "GET", path: "/secrets/hms-creds/x0c0s9b0", token: "s3cret", status: 404},
+		{method: "HEAD", path: "/secrets/hms-creds/x0c0s1b0", token: "s3cret", status: 405},
+		{method: "HEAD", path: "/keys", token: "s3cret", status: 405},
+		{method: "PUT", path: "/secrets/hms-creds/x0c0s2b0", token: "s3cret", body: `{"Username":"admin"}`, status: 204},
+		{method: "PUT", path: "/secrets/hms-creds/x0c0s2b0", token: "s3cret", body: `[1,2]`, status: 400},
+		{method: "GET", path: "/keys?path=hms-creds", token: "s3cret", status: 200, expect: `{"keys":["x0c0s1b0","x0c0s2b0"]}`},
+		{method: "DELETE", path: "/secrets/hms-creds/x0c0s2b0", token: "s3cret", status: 204},
+		{method: "GET", path: "/keys", token: "s3cret", status: 200, expect: `{"keys":["hms-creds/"]}`},
+		{method: "PUT", path: "/ro/secrets/hms-creds/x0c0s1b0", body: `{"Username":"admin"}`, status: 403},
+		{method: "GET", path: "/ro/secrets/hms-creds/x0c0s1b0", status: 200},
+	}
+
+	for i, test := range tests {
+		req, _ := http.NewRequest(test.method, server.URL+test.path, strings.NewReader(test.body))
+		if test.token != "" {
+			req.Header.Set("Authorization", "Bearer "+test.token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		var body json.RawMessage
+		json.NewDecoder(resp.Body).Decode(&body)
+		resp.Body.Close()
+		if resp.StatusCode != test.status {
+			t.Errorf("Test %v Failed: Expected status %d but got %d (%s)", i, test.status, resp.StatusCode, body)
+		}
+		if test.expect != "" && string(body) != test.expect {
+			t.Errorf("Test %v Failed: Expected body %s but got %s", i, test.expect, body)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package httpserver
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestListeners(t *testing.T) {
+	if listeners, err := Listeners(); err != nil || listeners != nil {
+		t.Errorf("Expected no listeners without socket activation but got %v (%v)", listeners, err)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	defer l.Close()
+	f, err := l.(*net.TCPListener).File()
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	listenFdsStart = int(f.Fd())
+	defer func() { listenFdsStart = 3 }()
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+	t.Setenv("LISTEN_FDS", "1")
+
+	activated, err := Listen("127.0.0.1:1")
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	defer activated.Close()
+	if activated.Addr().String() != l.Addr().String() {
+		t.Errorf("Expected the activated listener on %v but got %v", l.Addr(), activated.Addr())
+	}
+	if os.Getenv("LISTEN_FDS") != "" {
+		t.Errorf("Expected LISTEN_FDS to be cleared")
+	}
+
+	go http.Serve(activated, NewServer(newMemStore()))
+	resp, err := http.Get("http://" + l.Addr().String() + "/keys")
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected the server to answer on the activated listener but got %v (%v)", resp, err)
+	}
+}
+
+func TestNotify(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+	if sent, err := Notify("READY=1"); sent || err != nil {
+		t.Errorf("Expected nothing to be sent without NOTIFY_SOCKET but got %v (%v)", sent, err)
+	}
+
+	socket := filepath.Join(t.TempDir(), "notify")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	defer conn.Close()
+	t.Setenv("NOTIFY_SOCKET", socket)
+	if sent, err := Notify("READY=1"); !sent || err != nil {
+		t.Fatalf("Expected the state to be sent but got %v (%v)", sent, err)
+	}
+	buf := make([]byte, 64)
+	n, _ := conn.Read(buf)
+	if string(buf[:n]) != "READY=1" {
+		t.Errorf("Expected READY=1 but got %q", buf[:n])
+	}
+}
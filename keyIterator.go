@@ -0,0 +1,169 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KeyIterator walks the keys stored below a path one at a time. Only the
+// listing for the directory currently being walked is held in memory, so
+// very large key spaces can be traversed with bounded memory. Keys returned
+// by Key() are full keys that can be passed directly to Lookup().
+//
+//	it := securestorage.IterateKeys(ss, "hms-creds")
+//	defer it.Close()
+//	for it.Next() {
+//		key := it.Key()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type KeyIterator interface {
+	Next() bool
+	Key() string
+	Err() error
+	Close() error
+}
+
+// KeyIterable is implemented by SecureStorage backends that can provide a
+// KeyIterator directly.
+type KeyIterable interface {
+	IterateKeys(keyPath string) KeyIterator
+}
+
+// Get a KeyIterator over every key below keyPath, descending into
+// sub-paths. Backends implementing KeyIterable are used directly, all others
+// are walked using LookupKeys().
+func IterateKeys(ss SecureStorage, keyPath string) KeyIterator {
+	if ki, ok := ss.(KeyIterable); ok {
+		return ki.IterateKeys(keyPath)
+	}
+	return newListKeyIterator(ss, keyPath)
+}
+
+// Join a key path and a key name returned by LookupKeys().
+func joinKey(keyPath string, name string) string {
+	keyPath = strings.TrimSuffix(keyPath, "/")
+	if keyPath == "" {
+		return name
+	}
+	return keyPath + "/" + name
+}
+
+// Remove hidden, a path reserved by an adapter, from the names listed by
+// LookupKeys(keyPath). Listing hidden itself, or a path below it, lists
+// nothing.
+func hideKeyPath(keyPath string, keys []string, hidden string) []string {
+	keyPath = strings.TrimSuffix(keyPath, "/")
+	if keyPath == hidden || (keyPath != "" && underPath(hidden, keyPath)) {
+		return []string{}
+	}
+	name := hidden
+	if keyPath != "" {
+		var ok bool
+		name, ok = strings.CutPrefix(hidden, keyPath+"/")
+		if !ok {
+			return keys
+		}
+	}
+	if strings.Contains(name, "/") {
+		return keys
+	}
+	filtered := keys[:0]
+	for _, key := range keys {
+		if key != name+"/" {
+			filtered = append(filtered, key)
+		}
+	}
+	return filtered
+}
+
+// listKeyIterator implements KeyIterator on top of LookupKeys(). Sub-paths
+// (names ending in "/") are pushed on a stack and listed only once the
+// current listing has been consumed.
+type listKeyIterator struct {
+	ss      SecureStorage
+	pending []string
+	keys    []string
+	key     string
+	err     error
+	closed  bool
+}
+
+func newListKeyIterator(ss SecureStorage, keyPath string) *listKeyIterator {
+	return &listKeyIterator{
+		ss:      ss,
+		pending: []string{strings.TrimSuffix(keyPath, "/")},
+	}
+}
+
+func (it *listKeyIterator) Next() bool {
+	for !it.closed && it.err == nil {
+		if len(it.keys) > 0 {
+			key := it.keys[0]
+			it.keys = it.keys[1:]
+			if strings.HasSuffix(key, "/") {
+				it.pending = append(it.pending, strings.TrimSuffix(key, "/"))
+				continue
+			}
+			it.key = key
+			return true
+		}
+		if len(it.pending) == 0 {
+			break
+		}
+		dir := it.pending[len(it.pending)-1]
+		it.pending = it.pending[:len(it.pending)-1]
+		names, err := it.ss.LookupKeys(dir)
+		if err != nil {
+			it.err = err
+			break
+		}
+		for _, name := range names {
+			it.keys = append(it.keys, joinKey(dir, name))
+		}
+	}
+	it.key = ""
+	return false
+}
+
+func (it *listKeyIterator) Key() string {
+	return it.key
+}
+
+func (it *listKeyIterator) Err() error {
+	return it.err
+}
+
+func (it *listKeyIterator) Close() error {
+	if it.closed {
+		return fmt.Errorf("KeyIterator already closed")
+	}
+	it.closed = true
+	it.pending = nil
+	it.keys = nil
+	return nil
+}
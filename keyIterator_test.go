@@ -0,0 +1,113 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestIterateKeys(t *testing.T) {
+	ms := newMemStore()
+	for _, key := range []string{
+		"hms-creds/x0c0s1b0",
+		"hms-creds/x0c0s2b0",
+		"hms-creds/x1000/x1000c0s0b0",
+		"hms-creds/x1000/c0/x1000c0s1b0",
+		"other/x0c0s3b0",
+	} {
+		ms.Store(key, map[string]interface{}{"Username": "root"})
+	}
+
+	var tests = []struct {
+		keyPath string
+		resp    []string
+	}{
+		{
+			keyPath: "hms-creds",
+			resp: []string{
+				"hms-creds/x0c0s1b0",
+				"hms-creds/x0c0s2b0",
+				"hms-creds/x1000/c0/x1000c0s1b0",
+				"hms-creds/x1000/x1000c0s0b0",
+			},
+		}, {
+			keyPath: "hms-creds/x1000/",
+			resp: []string{
+				"hms-creds/x1000/c0/x1000c0s1b0",
+				"hms-creds/x1000/x1000c0s0b0",
+			},
+		}, {
+			keyPath: "missing",
+			resp:    []string{},
+		},
+	}
+
+	for i, test := range tests {
+		it := IterateKeys(ms, test.keyPath)
+		r := []string{}
+		for it.Next() {
+			r = append(r, it.Key())
+		}
+		if err := it.Err(); err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		if err := it.Close(); err != nil {
+			t.Errorf("Test %v Failed: Unexpected error on Close - %v", i, err)
+		}
+		sort.Strings(r)
+		if !reflect.DeepEqual(r, test.resp) {
+			t.Errorf("Test %v Failed: Expected keys %v but got %v", i, test.resp, r)
+		}
+		if it.Next() {
+			t.Errorf("Test %v Failed: Expected Next() to fail after Close()", i)
+		}
+	}
+}
+
+func TestHideKeyPath(t *testing.T) {
+	var tests = []struct {
+		keyPath  string
+		keys     []string
+		hidden   string
+		expected []string
+	}{
+		{keyPath: "", keys: []string{".keys/", "a", "b/"}, hidden: ".keys", expected: []string{"a", "b/"}},
+		{keyPath: "b", keys: []string{".keys/", "a"}, hidden: ".keys", expected: []string{".keys/", "a"}},
+		{keyPath: ".keys", keys: []string{"a", "b"}, hidden: ".keys", expected: []string{}},
+		{keyPath: ".keys/sub/", keys: []string{"a"}, hidden: ".keys", expected: []string{}},
+		{keyPath: "", keys: []string{"tenant/", "a"}, hidden: "tenant/.keys", expected: []string{"tenant/", "a"}},
+		{keyPath: "tenant", keys: []string{".keys/", "a"}, hidden: "tenant/.keys", expected: []string{"a"}},
+		{keyPath: "tenant/", keys: []string{".keys/", "a"}, hidden: "tenant/.keys", expected: []string{"a"}},
+		{keyPath: "tenant/.keys", keys: []string{"a"}, hidden: "tenant/.keys", expected: []string{}},
+		{keyPath: "tenant2", keys: []string{".keys/"}, hidden: "tenant/.keys", expected: []string{".keys/"}},
+	}
+
+	for i, test := range tests {
+		keys := hideKeyPath(test.keyPath, append([]string{}, test.keys...), test.hidden)
+		if !reflect.DeepEqual(keys, test.expected) {
+			t.Errorf("Test %v Failed: Expected %v but got %v", i, test.expected, keys)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// MasterKeySize is the size in bytes of an AES-256 master key.
+const MasterKeySize = 32
+
+// KeyProvider supplies the master key used by EncryptedAdapter. It is
+// called for every operation so providers may reload or rotate the key.
+// The caller may use the returned key until its operation ends, so a
+// provider that keeps its key must return a copy.
+type KeyProvider interface {
+	MasterKey() ([]byte, error)
+}
+
+// Generate a new random master key.
+func GenerateMasterKey() ([]byte, error) {
+	key := make([]byte, MasterKeySize)
+	err := readEntropy(key)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func checkMasterKey(key []byte) error {
+	if len(key) != MasterKeySize {
+		return fmt.Errorf("Master key must be %d bytes, got %d", MasterKeySize, len(key))
+	}
+	return nil
+}
+
+// StaticKeyProvider always returns the same master key. The key is kept
+// in locked memory if memory locking is on; MasterKey() returns a copy in
+// ordinary memory, so Close() cannot pull it from under an operation.
+type StaticKeyProvider struct {
+	mu  sync.RWMutex
+	key *LockedBuffer
+}
+
+// Create a new StaticKeyProvider for a MasterKeySize byte key.
+func NewStaticKeyProvider(key []byte) (*StaticKeyProvider, error) {
+	err := checkMasterKey(key)
+	if err != nil {
+		return nil, err
+	}
+	buf, err := newSecretBuffer(len(key))
+	if err != nil {
+		return nil, err
+	}
+	copy(buf.Bytes(), key)
+	return &StaticKeyProvider{key: buf}, nil
+}
+
+func (kp *StaticKeyProvider) MasterKey() ([]byte, error) {
+	kp.mu.RLock()
+	defer kp.mu.RUnlock()
+	if kp.key == nil {
+		return nil, fmt.Errorf("Master key has been wiped")
+	}
+	return append([]byte(nil), kp.key.Bytes()...), nil
+}
+
+// Wipe the key from memory. MasterKey() fails afterwards.
+func (kp *StaticKeyProvider) Close() error {
+	kp.mu.Lock()
+	defer kp.mu.Unlock()
+	if kp.key != nil {
+		kp.key.Destroy()
+		kp.key = nil
+	}
+	return nil
+}
+
+// FileKeyProvider reads a hex encoded master key from a file, such as a
+// mounted k8s secret. The file is re-read on every call so a rotated key
+// is picked up without a restart. The file contents are wiped once
+// decoded, and like StaticKeyProvider, MasterKey() returns a copy of the
+// key in ordinary memory.
+type FileKeyProvider struct {
+	Path string
+}
+
+// Create a new FileKeyProvider for the key file at path.
+func NewFileKeyProvider(path string) *FileKeyProvider {
+	return &FileKeyProvider{Path: path}
+}
+
+func (kp *FileKeyProvider) MasterKey() ([]byte, error) {
+	contents, err := os.ReadFile(kp.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(contents)
+	encoded := bytes.TrimSpace(contents)
+	if hex.DecodedLen(len(encoded)) != MasterKeySize {
+		return nil, fmt.Errorf("Master key must be %d bytes, got %d", MasterKeySize, hex.DecodedLen(len(encoded)))
+	}
+	key := make([]byte, MasterKeySize)
+	_, err = hex.Decode(key, encoded)
+	if err != nil {
+		wipe(key)
+		// The decoding error would include part of the key.
+		return nil, fmt.Errorf("Cannot decode master key in %s: not hex encoded", kp.Path)
+	}
+	return key, nil
+}
+
+// Zero buf.
+func wipe(buf []byte) {
+	for i := range buf {
+		buf[i] = 0
+	}
+}
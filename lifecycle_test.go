@@ -0,0 +1,82 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+var (
+	_ SecureStorageV2 = (*VaultAdapter)(nil)
+	_ SecureStorageV2 = (*MockAdapter)(nil)
+	_ SecureStorageV2 = (*AuditedAdapter)(nil)
+	_ SecureStorageV2 = (*CacheAdapter)(nil)
+	_ SecureStorageV2 = (*EncryptedAdapter)(nil)
+	_ SecureStorageV2 = (*FallbackAdapter)(nil)
+	_ SecureStorageV2 = (*InstrumentedAdapter)(nil)
+	_ SecureStorageV2 = (*MetadataAdapter)(nil)
+	_ SecureStorageV2 = (*MirrorAdapter)(nil)
+	_ SecureStorageV2 = (*PrefixAdapter)(nil)
+	_ SecureStorageV2 = (*ReadOnlyAdapter)(nil)
+	_ SecureStorageV2 = (*RetryAdapter)(nil)
+	_ SecureStorageV2 = (*TTLAdapter)(nil)
+	_ SecureStorageV2 = (*ValidatingAdapter)(nil)
+	_ SecureStorageV2 = (*WriteBehindAdapter)(nil)
+)
+
+func TestCloseChain(t *testing.T) {
+	ms := newMemStore()
+	_, mock := NewMockAdapter()
+	mock.CloseData = []MockClose{{Output: OutputClose{Err: fmt.Errorf("close failed")}}}
+	key, _ := GenerateMasterKey()
+	kp, _ := NewStaticKeyProvider(key)
+
+	wa := NewWriteBehindAdapter(ms, 8, 0, time.Millisecond)
+	ss := NewCacheAdapter(Encrypted(Fallback(wa, mock), kp), time.Minute, 0)
+	if err := ss.Store("x0c0s1b0", creds{Username: "root"}); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+
+	if err := Close(ss); err == nil || err.Error() != "close failed" {
+		t.Errorf("Expected the mock's close error but got %v", err)
+	}
+	if mock.CloseNum != 1 {
+		t.Errorf("Expected the mock to be closed once but got %v", mock.CloseNum)
+	}
+	if wa.Pending() != 0 {
+		t.Errorf("Expected queued writes to be flushed")
+	}
+	var raw map[string]interface{}
+	ms.Lookup("x0c0s1b0", &raw)
+	if raw == nil {
+		t.Errorf("Expected the queued write to reach the backend")
+	}
+	if _, err := kp.MasterKey(); err == nil {
+		t.Errorf("Expected the master key to be wiped")
+	}
+	if err := Close(ms); err != nil {
+		t.Errorf("Expected Close of a store without Close() to succeed but got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+)
+
+// LockedBuffer is memory for keys, locked into RAM so it is never written
+// to swap and, on Linux, left out of core dumps. Decrypted values are also
+// opened into a LockedBuffer, but this gives them no protection: they are
+// decoded into ordinary Go strings at once. If the memory cannot be
+// locked, for example because RLIMIT_MEMLOCK is too low, the buffer still
+// works but is not locked and a warning is logged once.
+type LockedBuffer struct {
+	buf    []byte
+	mapped bool
+	locked bool
+}
+
+var (
+	memoryLocking     atomic.Bool
+	memoryLockWarning sync.Once
+)
+
+// Turn memory locking of keys on or off for keys and buffers created
+// afterwards. It is off by default because the memory lock limit of many
+// systems is small.
+func SetMemoryLocking(on bool) {
+	memoryLocking.Store(on)
+}
+
+// Create a new zeroed LockedBuffer of size bytes.
+func NewLockedBuffer(size int) (*LockedBuffer, error) {
+	buf, mapped, err := allocLocked(size)
+	if err != nil {
+		return nil, err
+	}
+	lb := &LockedBuffer{buf: buf, mapped: mapped}
+	err = lockMemory(buf)
+	if err != nil {
+		memoryLockWarning.Do(func() {
+			log.Printf("WARNING: Unable to lock secrets into memory, they may be written to swap: %v", err)
+		})
+	} else {
+		lb.locked = true
+	}
+	return lb, nil
+}
+
+// Get a buffer for a key or decrypted plaintext, locked if memory locking
+// is on.
+func newSecretBuffer(size int) (*LockedBuffer, error) {
+	if !memoryLocking.Load() {
+		return &LockedBuffer{buf: make([]byte, size)}, nil
+	}
+	return NewLockedBuffer(size)
+}
+
+func (lb *LockedBuffer) Bytes() []byte {
+	return lb.buf
+}
+
+// Whether the memory is locked.
+func (lb *LockedBuffer) Locked() bool {
+	return lb.locked
+}
+
+// Wipe and release the memory. Bytes() returns nil afterwards.
+func (lb *LockedBuffer) Destroy() {
+	if lb.buf == nil {
+		return
+	}
+	wipe(lb.buf)
+	if lb.locked {
+		unlockMemory(lb.buf)
+	}
+	if lb.mapped {
+		freeLocked(lb.buf)
+	}
+	lb.buf = nil
+	lb.locked = false
+}
@@ -0,0 +1,79 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+//go:build linux
+
+package securestorage
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+)
+
+const madvDontDump = 0x10
+
+// Get RLIMIT_MEMLOCK, which the syscall package does not define. MIPS
+// numbers the resource limits differently.
+func rlimitMemlock() int {
+	switch runtime.GOARCH {
+	case "mips", "mipsle", "mips64", "mips64le":
+		return 9
+	}
+	return 8
+}
+
+// Allocate memory outside the Go heap so the garbage collector never
+// copies it to an unlocked location.
+func allocLocked(size int) ([]byte, bool, error) {
+	if size == 0 {
+		return []byte{}, false, nil
+	}
+	buf, err := syscall.Mmap(-1, 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_ANON|syscall.MAP_PRIVATE)
+	if err != nil {
+		return nil, false, err
+	}
+	syscall.Madvise(buf, madvDontDump)
+	return buf, true, nil
+}
+
+func freeLocked(buf []byte) {
+	syscall.Munmap(buf)
+}
+
+func lockMemory(buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	err := syscall.Mlock(buf)
+	if err != nil {
+		var limit syscall.Rlimit
+		if syscall.Getrlimit(rlimitMemlock(), &limit) == nil {
+			return fmt.Errorf("%v (RLIMIT_MEMLOCK is %d bytes)", err, limit.Cur)
+		}
+	}
+	return err
+}
+
+func unlockMemory(buf []byte) {
+	syscall.Munlock(buf)
+}
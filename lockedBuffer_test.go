@@ -0,0 +1,149 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLockedBuffer(t *testing.T) {
+	for i, size := range []int{0, 32, 4096, 10000} {
+		lb, err := NewLockedBuffer(size)
+		if err != nil {
+			t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		buf := lb.Bytes()
+		if len(buf) != size {
+			t.Errorf("Test %v Failed: Expected %d bytes but got %d", i, size, len(buf))
+		}
+		for j := range buf {
+			buf[j] = 0xff
+		}
+		lb.Destroy()
+		if lb.Bytes() != nil || lb.Locked() {
+			t.Errorf("Test %v Failed: Expected destroyed buffer to be released", i)
+		}
+		lb.Destroy()
+	}
+}
+
+func TestMemoryLocking(t *testing.T) {
+	SetMemoryLocking(true)
+	defer SetMemoryLocking(false)
+
+	key, _ := GenerateMasterKey()
+	kp, err := NewStaticKeyProvider(key)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	if runtime.GOOS == "linux" && !kp.key.mapped {
+		t.Errorf("Test Failed: Expected master key outside the Go heap")
+	}
+
+	ea := Encrypted(newMemStore(), kp)
+	ea.Store("key", creds{Username: "root", Password: "pw"})
+	var got creds
+	err = ea.Lookup("key", &got)
+	if err != nil || got.Password != "pw" {
+		t.Errorf("Test Failed: Expected pw but got %v (%v)", got, err)
+	}
+	ea.Close()
+	if _, err := kp.MasterKey(); err == nil {
+		t.Errorf("Test Failed: Expected wiped master key")
+	}
+}
+
+func TestFileKeyProvider(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "master.key")
+	kp := NewFileKeyProvider(keyFile)
+
+	var tests = []struct {
+		contents string
+		respErr  bool
+	}{
+		{contents: "", respErr: true},
+		{contents: "00ff", respErr: true},
+		{contents: strings.Repeat("zz", MasterKeySize), respErr: true},
+		{contents: strings.Repeat("01", MasterKeySize) + "\n"},
+		{contents: strings.Repeat("02", MasterKeySize)},
+	}
+
+	for i, test := range tests {
+		os.WriteFile(keyFile, []byte(test.contents), 0600)
+		key, err := kp.MasterKey()
+		if (err != nil) != test.respErr {
+			t.Errorf("Test %v Failed: Unexpected error result - %v", i, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if want, _ := hex.DecodeString(strings.TrimSpace(test.contents)); !bytes.Equal(key, want) {
+			t.Errorf("Test %v Failed: Expected key %x but got %x", i, want, key)
+		}
+	}
+}
+
+func TestStaticKeyProviderClose(t *testing.T) {
+	SetMemoryLocking(true)
+	defer SetMemoryLocking(false)
+
+	key, _ := GenerateMasterKey()
+	kp, err := NewStaticKeyProvider(key)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+
+	// Run under -race: Close() must not race MasterKey(), and keys already
+	// handed out stay usable after it.
+	keys := make([][]byte, 4)
+	var wg sync.WaitGroup
+	for i := range keys {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			keys[i], _ = kp.MasterKey()
+		}(i)
+	}
+	inUse, _ := kp.MasterKey()
+	kp.Close()
+	wg.Wait()
+	if !bytes.Equal(inUse, key) {
+		t.Errorf("Test Failed: Expected the key handed out to survive Close but got %x", inUse)
+	}
+	for i, k := range keys {
+		if k != nil && !bytes.Equal(k, key) {
+			t.Errorf("Test %v Failed: Expected the key handed out to survive Close but got %x", i, k)
+		}
+	}
+	if _, err := kp.MasterKey(); err == nil {
+		t.Errorf("Test Failed: Expected wiped master key")
+	}
+}
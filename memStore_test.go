@@ -0,0 +1,129 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// memStore is a simple in-memory SecureStorage used to exercise the
+// generic helpers and wrappers in unit tests. It mimics Vault's behavior of
+// returning no error and leaving output untouched for missing keys.
+type memStore struct {
+	mu   sync.Mutex
+	data map[string]map[string]interface{}
+}
+
+func newMemStore() *memStore {
+	return &memStore{data: map[string]map[string]interface{}{}}
+}
+
+func (ms *memStore) Store(key string, value interface{}) error {
+	var data map[string]interface{}
+
+	err := mapstructure.Decode(value, &data)
+	if err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.data[key] = data
+	return nil
+}
+
+func (ms *memStore) StoreWithData(key string, value interface{}, output interface{}) error {
+	return ms.Store(key, value)
+}
+
+func (ms *memStore) Lookup(key string, output interface{}) error {
+	ms.mu.Lock()
+	data, ok := ms.data[key]
+	ms.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return mapstructure.Decode(data, output)
+}
+
+func (ms *memStore) Delete(key string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delete(ms.data, key)
+	return nil
+}
+
+func (ms *memStore) LookupKeys(keyPath string) ([]string, error) {
+	prefix := keyPath
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	seen := map[string]bool{}
+	klist := []string{}
+	for key := range ms.data {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		rest := key[len(prefix):]
+		if idx := strings.Index(rest, "/"); idx >= 0 {
+			rest = rest[:idx+1]
+		}
+		if !seen[rest] {
+			seen[rest] = true
+			klist = append(klist, rest)
+		}
+	}
+	sort.Strings(klist)
+	return klist, nil
+}
+
+// failStore is a SecureStorage whose every operation fails with err.
+type failStore struct {
+	err error
+}
+
+func (fs *failStore) Store(key string, value interface{}) error {
+	return fs.err
+}
+
+func (fs *failStore) StoreWithData(key string, value interface{}, output interface{}) error {
+	return fs.err
+}
+
+func (fs *failStore) Lookup(key string, output interface{}) error {
+	return fs.err
+}
+
+func (fs *failStore) Delete(key string) error {
+	return fs.err
+}
+
+func (fs *failStore) LookupKeys(keyPath string) ([]string, error) {
+	return nil, fs.err
+}
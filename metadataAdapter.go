@@ -0,0 +1,199 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"time"
+)
+
+// Metadata describes a stored key without exposing its value.
+type Metadata struct {
+	CreatedTime time.Time
+	UpdatedTime time.Time
+	Version     int
+	Labels      map[string]string
+}
+
+// MetadataStore is implemented by SecureStorage backends that track
+// metadata for each key, so tooling can inspect keys uniformly regardless
+// of backend.
+type MetadataStore interface {
+	LookupMetadata(key string) (*Metadata, error)
+	SetLabels(key string, labels map[string]string) error
+}
+
+// MetadataAdapter adds MetadataStore support to any SecureStorage by keeping
+// the metadata alongside the value. Each Store() reads the existing entry
+// so the creation time, version and labels carry forward. A value written
+// before the adapter was added is read as version 1, created at the time
+// it is read.
+type MetadataAdapter struct {
+	Inner SecureStorage
+	now   func() time.Time
+}
+
+type metadataEntry struct {
+	Value       map[string]interface{} `mapstructure:"value"`
+	CreatedTime string                 `mapstructure:"created_time"`
+	UpdatedTime string                 `mapstructure:"updated_time"`
+	Version     int                    `mapstructure:"version"`
+	Labels      map[string]string      `mapstructure:"labels"`
+}
+
+// Create a new MetadataAdapter wrapping ss.
+func NewMetadataAdapter(ss SecureStorage) *MetadataAdapter {
+	return &MetadataAdapter{
+		Inner: ss,
+		now:   time.Now,
+	}
+}
+
+func (ma *MetadataAdapter) lookupEntry(key string) (*metadataEntry, error) {
+	var (
+		data  map[string]interface{}
+		entry metadataEntry
+	)
+
+	err := ma.Inner.Lookup(key, &data)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	if !isEnvelope(data, "created_time", "updated_time", "version", "labels") {
+		now := ma.now().UTC().Format(time.RFC3339Nano)
+		return &metadataEntry{Value: data, CreatedTime: now, UpdatedTime: now, Version: 1}, nil
+	}
+	err = decodeValue(data, &entry)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Build the entry that will replace the current one for key.
+func (ma *MetadataAdapter) nextEntry(key string, value interface{}) (*metadataEntry, error) {
+	data, err := toMap(value)
+	if err != nil {
+		return nil, err
+	}
+	prev, err := ma.lookupEntry(key)
+	if err != nil {
+		return nil, err
+	}
+	now := ma.now().UTC().Format(time.RFC3339Nano)
+	entry := &metadataEntry{
+		Value:       data,
+		CreatedTime: now,
+		UpdatedTime: now,
+		Version:     1,
+	}
+	if prev != nil {
+		entry.CreatedTime = prev.CreatedTime
+		entry.Version = prev.Version + 1
+		entry.Labels = prev.Labels
+	}
+	return entry, nil
+}
+
+func (ma *MetadataAdapter) Store(key string, value interface{}) error {
+	entry, err := ma.nextEntry(key, value)
+	if err != nil {
+		return err
+	}
+	return ma.Inner.Store(key, entry)
+}
+
+func (ma *MetadataAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	entry, err := ma.nextEntry(key, value)
+	if err != nil {
+		return err
+	}
+	return ma.Inner.StoreWithData(key, entry, output)
+}
+
+func (ma *MetadataAdapter) Lookup(key string, output interface{}) error {
+	if output == nil {
+		return fmt.Errorf("output interface was nil")
+	}
+	entry, err := ma.lookupEntry(key)
+	if err != nil || entry == nil {
+		return err
+	}
+	return decodeValue(entry.Value, output)
+}
+
+func (ma *MetadataAdapter) Delete(key string) error {
+	return ma.Inner.Delete(key)
+}
+
+func (ma *MetadataAdapter) LookupKeys(keyPath string) ([]string, error) {
+	return ma.Inner.LookupKeys(keyPath)
+}
+
+func (ma *MetadataAdapter) LookupMetadata(key string) (*Metadata, error) {
+	entry, err := ma.lookupEntry(key)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	md := &Metadata{
+		Version: entry.Version,
+		Labels:  map[string]string{},
+	}
+	for k, v := range entry.Labels {
+		md.Labels[k] = v
+	}
+	md.CreatedTime, err = time.Parse(time.RFC3339Nano, entry.CreatedTime)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid created time for %s: %v", key, err)
+	}
+	md.UpdatedTime, err = time.Parse(time.RFC3339Nano, entry.UpdatedTime)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid updated time for %s: %v", key, err)
+	}
+	return md, nil
+}
+
+// Replace the labels on an existing key. This does not change the version
+// or updated time of the value.
+func (ma *MetadataAdapter) SetLabels(key string, labels map[string]string) error {
+	entry, err := ma.lookupEntry(key)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	entry.Labels = labels
+	return ma.Inner.Store(key, entry)
+}
+
+func (ma *MetadataAdapter) Close() error {
+	return Close(ma.Inner)
+}
+
+func (ma *MetadataAdapter) Health() error {
+	return Health(ma.Inner)
+}
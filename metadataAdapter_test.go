@@ -0,0 +1,124 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMetadataAdapter(t *testing.T) {
+	value := creds{
+		Xname:    "x0c0s1b0",
+		URL:      "10.4.0.21/redfish/v1/UpdateService",
+		Username: "test1",
+		Password: "123",
+	}
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := created
+	ma := NewMetadataAdapter(newMemStore())
+	ma.now = func() time.Time { return now }
+
+	var _ MetadataStore = ma
+
+	if _, err := ma.LookupMetadata("x0c0s1b0"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound but got %v", err)
+	}
+	if err := ma.SetLabels("x0c0s1b0", map[string]string{"a": "b"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound but got %v", err)
+	}
+
+	labels := map[string]string{"cabinet": "x0"}
+	for i := 1; i <= 3; i++ {
+		now = now.Add(time.Minute)
+		if err := ma.Store("x0c0s1b0", value); err != nil {
+			t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		if i == 1 {
+			if err := ma.SetLabels("x0c0s1b0", labels); err != nil {
+				t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+			}
+		}
+		md, err := ma.LookupMetadata("x0c0s1b0")
+		if err != nil {
+			t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		expected := &Metadata{
+			CreatedTime: created.Add(time.Minute),
+			UpdatedTime: now,
+			Version:     i,
+			Labels:      labels,
+		}
+		if !reflect.DeepEqual(md, expected) {
+			t.Errorf("Test %v Failed: Expected metadata %v but got %v", i, expected, md)
+		}
+		var r creds
+		if err := ma.Lookup("x0c0s1b0", &r); err != nil || !reflect.DeepEqual(r, value) {
+			t.Errorf("Test %v Failed: Expected credentials %v but got %v (%v)", i, value, r, err)
+		}
+	}
+}
+
+func TestMetadataAdapterExisting(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	ms := newMemStore()
+	ms.Store("x0c0s1b0", creds{Username: "root", Password: "pw"})
+	ma := NewMetadataAdapter(ms)
+	ma.now = func() time.Time { return now }
+
+	// Until it is next written, the existing value counts as created when
+	// it is read.
+	var tests = []struct {
+		store   bool
+		version int
+		created time.Time
+	}{
+		{version: 1, created: start},
+		{store: true, version: 2, created: start.Add(time.Minute)},
+		{version: 2, created: start.Add(time.Minute)},
+	}
+
+	for i, test := range tests {
+		if test.store {
+			if err := ma.Store("x0c0s1b0", creds{Username: "root", Password: "pw2"}); err != nil {
+				t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+			}
+		}
+		var r creds
+		if err := ma.Lookup("x0c0s1b0", &r); err != nil || r.Username != "root" {
+			t.Errorf("Test %v Failed: Expected the existing value but got %v (%v)", i, r, err)
+		}
+		md, err := ma.LookupMetadata("x0c0s1b0")
+		if err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+			continue
+		}
+		if md.Version != test.version || !md.CreatedTime.Equal(test.created) {
+			t.Errorf("Test %v Failed: Expected version %v created %v but got %+v", i, test.version, test.created, md)
+		}
+		now = now.Add(time.Minute)
+	}
+}
@@ -0,0 +1,238 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Default latency histogram buckets, in seconds
+var DefaultLatencyBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics collects operation counts, error counts and latency histograms
+// per backend and operation. One Metrics can be shared by several
+// InstrumentedAdapters with different backend labels.
+type Metrics struct {
+	mu      sync.Mutex
+	buckets []float64
+	ops     map[metricsKey]*OpMetrics
+}
+
+type metricsKey struct {
+	backend   string
+	operation string
+}
+
+// OpMetrics holds the metrics for one backend and operation. Buckets[i] is
+// the number of operations that took at most Metrics bucket i seconds.
+type OpMetrics struct {
+	Backend    string
+	Operation  string
+	Count      uint64
+	Errors     uint64
+	LatencySum float64
+	Buckets    []uint64
+}
+
+// Create a new Metrics using the given latency buckets in seconds. A nil
+// buckets uses DefaultLatencyBuckets.
+func NewMetrics(buckets []float64) *Metrics {
+	if buckets == nil {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &Metrics{
+		buckets: buckets,
+		ops:     map[metricsKey]*OpMetrics{},
+	}
+}
+
+// Record one operation.
+func (m *Metrics) Observe(backend string, operation string, latency time.Duration, err error) {
+	seconds := latency.Seconds()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := metricsKey{backend: backend, operation: operation}
+	om, ok := m.ops[key]
+	if !ok {
+		om = &OpMetrics{
+			Backend:   backend,
+			Operation: operation,
+			Buckets:   make([]uint64, len(m.buckets)),
+		}
+		m.ops[key] = om
+	}
+	om.Count++
+	if err != nil {
+		om.Errors++
+	}
+	om.LatencySum += seconds
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			om.Buckets[i]++
+		}
+	}
+}
+
+// Get the latency bucket bounds in seconds.
+func (m *Metrics) Buckets() []float64 {
+	return append([]float64(nil), m.buckets...)
+}
+
+// Get a copy of the current metrics sorted by backend and operation.
+func (m *Metrics) Snapshot() []OpMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snap := make([]OpMetrics, 0, len(m.ops))
+	for _, om := range m.ops {
+		c := *om
+		c.Buckets = append([]uint64(nil), om.Buckets...)
+		snap = append(snap, c)
+	}
+	sort.Slice(snap, func(i, j int) bool {
+		if snap[i].Backend != snap[j].Backend {
+			return snap[i].Backend < snap[j].Backend
+		}
+		return snap[i].Operation < snap[j].Operation
+	})
+	return snap
+}
+
+// Write the metrics in the Prometheus text exposition format.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	snap := m.Snapshot()
+	labels := func(om OpMetrics) string {
+		return fmt.Sprintf("backend=%q,operation=%q", om.Backend, om.Operation)
+	}
+
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+	printf("# HELP securestorage_operations_total Number of secure storage operations.\n")
+	printf("# TYPE securestorage_operations_total counter\n")
+	for _, om := range snap {
+		printf("securestorage_operations_total{%s} %d\n", labels(om), om.Count)
+	}
+	printf("# HELP securestorage_operation_errors_total Number of failed secure storage operations.\n")
+	printf("# TYPE securestorage_operation_errors_total counter\n")
+	for _, om := range snap {
+		printf("securestorage_operation_errors_total{%s} %d\n", labels(om), om.Errors)
+	}
+	printf("# HELP securestorage_operation_duration_seconds Latency of secure storage operations.\n")
+	printf("# TYPE securestorage_operation_duration_seconds histogram\n")
+	for _, om := range snap {
+		for i, bound := range m.buckets {
+			le := strconv.FormatFloat(bound, 'g', -1, 64)
+			printf("securestorage_operation_duration_seconds_bucket{%s,le=%q} %d\n", labels(om), le, om.Buckets[i])
+		}
+		printf("securestorage_operation_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels(om), om.Count)
+		printf("securestorage_operation_duration_seconds_sum{%s} %g\n", labels(om), om.LatencySum)
+		printf("securestorage_operation_duration_seconds_count{%s} %d\n", labels(om), om.Count)
+	}
+	return err
+}
+
+// InstrumentedAdapter records metrics for every operation on the wrapped
+// SecureStorage, labelled with Backend.
+type InstrumentedAdapter struct {
+	Inner   SecureStorage
+	Metrics *Metrics
+	Backend string
+	now     func() time.Time
+
+	mu        sync.Mutex
+	lastWrite time.Time
+}
+
+// Create a new InstrumentedAdapter recording into metrics.
+func Instrumented(store SecureStorage, metrics *Metrics, backend string) *InstrumentedAdapter {
+	return &InstrumentedAdapter{
+		Inner:   store,
+		Metrics: metrics,
+		Backend: backend,
+		now:     time.Now,
+	}
+}
+
+func (ia *InstrumentedAdapter) observe(op string, start time.Time, err error) {
+	end := ia.now()
+	ia.Metrics.Observe(ia.Backend, op, end.Sub(start), err)
+	if err == nil && op != OpLookup && op != OpLookupKeys {
+		ia.mu.Lock()
+		ia.lastWrite = end
+		ia.mu.Unlock()
+	}
+}
+
+func (ia *InstrumentedAdapter) Store(key string, value interface{}) error {
+	start := ia.now()
+	err := ia.Inner.Store(key, value)
+	ia.observe(OpStore, start, err)
+	return err
+}
+
+func (ia *InstrumentedAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	start := ia.now()
+	err := ia.Inner.StoreWithData(key, value, output)
+	ia.observe(OpStoreWithData, start, err)
+	return err
+}
+
+func (ia *InstrumentedAdapter) Lookup(key string, output interface{}) error {
+	start := ia.now()
+	err := ia.Inner.Lookup(key, output)
+	ia.observe(OpLookup, start, err)
+	return err
+}
+
+func (ia *InstrumentedAdapter) Delete(key string) error {
+	start := ia.now()
+	err := ia.Inner.Delete(key)
+	ia.observe(OpDelete, start, err)
+	return err
+}
+
+func (ia *InstrumentedAdapter) LookupKeys(keyPath string) ([]string, error) {
+	start := ia.now()
+	klist, err := ia.Inner.LookupKeys(keyPath)
+	ia.observe(OpLookupKeys, start, err)
+	return klist, err
+}
+
+func (ia *InstrumentedAdapter) Close() error {
+	return Close(ia.Inner)
+}
+
+func (ia *InstrumentedAdapter) Health() error {
+	return Health(ia.Inner)
+}
@@ -0,0 +1,83 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInstrumentedAdapter(t *testing.T) {
+	m := NewMetrics([]float64{0.1, 1})
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	latency := 50 * time.Millisecond
+
+	good := Instrumented(newMemStore(), m, "memory")
+	bad := Instrumented(&failStore{err: fmt.Errorf("Code: 503")}, m, "vault")
+	for _, ia := range []*InstrumentedAdapter{good, bad} {
+		ia.now = func() time.Time {
+			now = now.Add(latency)
+			return now
+		}
+	}
+
+	var r creds
+	good.Store("x0c0s1b0", creds{Username: "root"})
+	good.Lookup("x0c0s1b0", &r)
+	latency = 500 * time.Millisecond
+	good.Lookup("x0c0s1b0", &r)
+	bad.Lookup("x0c0s1b0", &r)
+
+	expected := []OpMetrics{
+		{Backend: "memory", Operation: OpLookup, Count: 2, Errors: 0, LatencySum: 0.55, Buckets: []uint64{1, 2}},
+		{Backend: "memory", Operation: OpStore, Count: 1, Errors: 0, LatencySum: 0.05, Buckets: []uint64{1, 1}},
+		{Backend: "vault", Operation: OpLookup, Count: 1, Errors: 1, LatencySum: 0.5, Buckets: []uint64{0, 1}},
+	}
+	snap := m.Snapshot()
+	for i := range snap {
+		snap[i].LatencySum = float64(int(snap[i].LatencySum*1000+0.5)) / 1000
+	}
+	if !reflect.DeepEqual(snap, expected) {
+		t.Errorf("Expected metrics %+v but got %+v", expected, snap)
+	}
+
+	var buf bytes.Buffer
+	if err := m.WritePrometheus(&buf); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	for _, line := range []string{
+		`securestorage_operations_total{backend="memory",operation="lookup"} 2`,
+		`securestorage_operation_errors_total{backend="vault",operation="lookup"} 1`,
+		`securestorage_operation_duration_seconds_bucket{backend="memory",operation="lookup",le="0.1"} 1`,
+		`securestorage_operation_duration_seconds_bucket{backend="memory",operation="lookup",le="+Inf"} 2`,
+		`securestorage_operation_duration_seconds_count{backend="vault",operation="lookup"} 1`,
+	} {
+		if !strings.Contains(buf.String(), line+"\n") {
+			t.Errorf("Expected output to contain %s", line)
+		}
+	}
+}
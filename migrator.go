@@ -0,0 +1,277 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// KeyTransform maps a source key and value to the key and value written to
+// the destination store. Returning an empty key skips the entry.
+type KeyTransform func(key string, value map[string]interface{}) (string, map[string]interface{}, error)
+
+// Migrator copies every key below a prefix from one SecureStorage to
+// another. The stores may be different backends.
+//
+// When CheckpointFile is set, each key copied successfully is appended to it
+// and keys already listed there are skipped, so an interrupted migration can
+// be resumed by running it again. When Verify is set, every selected key is
+// read back from both stores once the copy is done and compared.
+type Migrator struct {
+	From        SecureStorage
+	To          SecureStorage
+	Prefix      string
+	Concurrency int
+	// Only keys for which Filter returns true are migrated.
+	Filter func(key string) bool
+	// Optional; by default keys and values are copied unchanged.
+	Transform      KeyTransform
+	CheckpointFile string
+	Verify         bool
+
+	mu       sync.Mutex
+	done     map[string]bool
+	progress *os.File
+}
+
+// MigrationResult summarizes a Migrator run.
+type MigrationResult struct {
+	Copied  int
+	Skipped int
+	// Keys that could not be copied, with the reason.
+	Failed map[string]error
+	// Keys whose destination value did not match the source after the copy.
+	Mismatched []string
+}
+
+// Create a new Migrator copying every key below prefix from one store to
+// another, one key at a time.
+func NewMigrator(from SecureStorage, to SecureStorage, prefix string) *Migrator {
+	return &Migrator{
+		From:        from,
+		To:          to,
+		Prefix:      prefix,
+		Concurrency: 1,
+	}
+}
+
+// Run the migration. An error is returned if the source could not be
+// listed, the checkpoint file could not be used, or any key failed to copy
+// or verify; the result is returned in every case but the first two.
+func (m *Migrator) Run() (*MigrationResult, error) {
+	err := m.loadCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+	if m.progress != nil {
+		defer func() {
+			m.progress.Close()
+			m.progress = nil
+		}()
+	}
+
+	var keys []string
+	it := IterateKeys(m.From, m.Prefix)
+	for it.Next() {
+		if m.Filter == nil || m.Filter(it.Key()) {
+			keys = append(keys, it.Key())
+		}
+	}
+	err = it.Err()
+	it.Close()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(keys)
+
+	result := &MigrationResult{Failed: map[string]error{}}
+	m.forEach(keys, func(key string) {
+		if m.isDone(key) {
+			m.record(result, func() { result.Skipped++ })
+			return
+		}
+		copied, err := m.migrateKey(key)
+		m.record(result, func() {
+			switch {
+			case err != nil:
+				result.Failed[key] = err
+			case copied:
+				result.Copied++
+			default:
+				result.Skipped++
+			}
+		})
+	})
+
+	if m.Verify {
+		m.forEach(keys, func(key string) {
+			if _, failed := result.Failed[key]; failed {
+				return
+			}
+			if ok, err := m.verifyKey(key); err != nil || !ok {
+				m.record(result, func() { result.Mismatched = append(result.Mismatched, key) })
+			}
+		})
+		sort.Strings(result.Mismatched)
+	}
+
+	if len(result.Failed) > 0 || len(result.Mismatched) > 0 {
+		return result, fmt.Errorf("Migration of %s incomplete: %d keys failed, %d keys did not verify",
+			m.Prefix, len(result.Failed), len(result.Mismatched))
+	}
+	return result, nil
+}
+
+// Run fn for every key using up to Concurrency goroutines.
+func (m *Migrator) forEach(keys []string, fn func(key string)) {
+	workers := m.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	work := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range work {
+				fn(key)
+			}
+		}()
+	}
+	for _, key := range keys {
+		work <- key
+	}
+	close(work)
+	wg.Wait()
+}
+
+func (m *Migrator) record(result *MigrationResult, update func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	update()
+}
+
+// Read key from the source and apply the transform. An empty destination
+// key means the entry is skipped.
+func (m *Migrator) source(key string) (string, map[string]interface{}, error) {
+	var data map[string]interface{}
+
+	err := m.From.Lookup(key, &data)
+	if err != nil {
+		return "", nil, err
+	}
+	if data == nil {
+		return "", nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if m.Transform == nil {
+		return key, data, nil
+	}
+	return m.Transform(key, data)
+}
+
+func (m *Migrator) migrateKey(key string) (bool, error) {
+	dst, data, err := m.source(key)
+	if err != nil || dst == "" {
+		return false, err
+	}
+	err = m.To.Store(dst, data)
+	if err != nil {
+		return false, err
+	}
+	return true, m.markDone(key)
+}
+
+func (m *Migrator) verifyKey(key string) (bool, error) {
+	dst, expected, err := m.source(key)
+	if err != nil || dst == "" {
+		return dst == "" && err == nil, err
+	}
+	var actual map[string]interface{}
+	err = m.To.Lookup(dst, &actual)
+	if err != nil || actual == nil {
+		return false, err
+	}
+	// Compare the encoded forms so that backends returning numbers as
+	// different types still compare equal.
+	want, err := json.Marshal(expected)
+	if err != nil {
+		return false, err
+	}
+	got, err := json.Marshal(actual)
+	if err != nil {
+		return false, err
+	}
+	return string(want) == string(got), nil
+}
+
+// Read the keys already copied by a previous run and open the checkpoint
+// file for appending.
+func (m *Migrator) loadCheckpoint() error {
+	m.done = map[string]bool{}
+	if m.CheckpointFile == "" {
+		return nil
+	}
+	f, err := os.OpenFile(m.CheckpointFile, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("Unable to open checkpoint file %s: %v", m.CheckpointFile, err)
+	}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if key := strings.TrimSpace(scanner.Text()); key != "" {
+			m.done[key] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		f.Close()
+		return fmt.Errorf("Unable to read checkpoint file %s: %v", m.CheckpointFile, err)
+	}
+	m.progress = f
+	return nil
+}
+
+func (m *Migrator) isDone(key string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.done[key]
+}
+
+func (m *Migrator) markDone(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.done[key] = true
+	if m.progress == nil {
+		return nil
+	}
+	_, err := m.progress.WriteString(key + "\n")
+	if err != nil {
+		return fmt.Errorf("Unable to update checkpoint file %s: %v", m.CheckpointFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrator(t *testing.T) {
+	from := newMemStore()
+	for _, xname := range []string{"x0c0s1b0", "x0c0s2b0", "x0c0s3b0", "x1000c0s1b0"} {
+		from.Store("hms-creds/"+xname, creds{Xname: xname, Username: "root", Password: "pw"})
+	}
+	from.Store("other/x0c0s1b0", creds{Xname: "x0c0s1b0"})
+
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint")
+	to := &flakyStore{memStore: newMemStore(), failures: 1}
+	m := NewMigrator(from, to, "hms-creds")
+	m.Concurrency = 3
+	m.CheckpointFile = checkpoint
+	m.Verify = true
+	m.Filter = func(key string) bool { return !strings.Contains(key, "x1000") }
+	m.Transform = func(key string, value map[string]interface{}) (string, map[string]interface{}, error) {
+		value["URL"] = "https://" + value["Xname"].(string)
+		return strings.Replace(key, "hms-creds/", "creds/", 1), value, nil
+	}
+
+	// The first write fails, so one key is left for the second run.
+	result, err := m.Run()
+	if err == nil || len(result.Failed) != 1 || result.Copied != 2 {
+		t.Fatalf("Expected one failed key on the first run but got %+v (%v)", result, err)
+	}
+	result, err = m.Run()
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	if result.Copied != 1 || result.Skipped != 2 || len(result.Mismatched) != 0 {
+		t.Errorf("Expected 1 copied and 2 skipped on the second run but got %+v", result)
+	}
+
+	keys, _ := to.LookupKeys("creds")
+	if len(keys) != 3 {
+		t.Errorf("Expected 3 migrated keys but got %v", keys)
+	}
+	var r creds
+	to.Lookup("creds/x0c0s2b0", &r)
+	if r.URL != "https://x0c0s2b0" || r.Password != "pw" {
+		t.Errorf("Expected transformed value but got %+v", r)
+	}
+	contents, _ := os.ReadFile(checkpoint)
+	if n := strings.Count(string(contents), "\n"); n != 3 {
+		t.Errorf("Expected 3 keys in the checkpoint file but got %d", n)
+	}
+
+	m.To = &failStore{err: os.ErrPermission}
+	m.CheckpointFile = ""
+	if _, err := m.Run(); err == nil {
+		t.Errorf("Expected an error when the destination fails")
+	}
+}
@@ -0,0 +1,211 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MirrorAdapter writes every Store and Delete to a primary SecureStorage and
+// to a set of mirrors, so a warm standby copy of the data is kept in other
+// stores. Reads are only served by the primary.
+//
+// In synchronous mode (NewMirrorAdapter) a write returns once every mirror
+// has been updated. In asynchronous mode (NewAsyncMirrorAdapter) a write
+// returns once the primary has been updated; each mirror has its own queue
+// that is applied in order, retrying failed operations. Close() drains the
+// queues.
+type MirrorAdapter struct {
+	Primary SecureStorage
+	Mirrors []SecureStorage
+	// Called when an operation could not be applied to a mirror. In
+	// asynchronous mode this is only called once the retries are used up.
+	OnError func(mirror int, key string, err error)
+
+	async      bool
+	maxRetries int
+	retryDelay time.Duration
+	queues     []chan mirrorOp
+	wg         sync.WaitGroup
+	mu         sync.RWMutex
+	closed     bool
+}
+
+type mirrorOp struct {
+	delete bool
+	key    string
+	value  map[string]interface{}
+}
+
+// Create a new MirrorAdapter that updates the mirrors synchronously.
+func NewMirrorAdapter(primary SecureStorage, mirrors ...SecureStorage) *MirrorAdapter {
+	return &MirrorAdapter{
+		Primary: primary,
+		Mirrors: mirrors,
+	}
+}
+
+// Create a new MirrorAdapter that updates the mirrors asynchronously. Each
+// mirror queues up to queueSize operations; writes block once a queue is
+// full. A failed operation is retried up to maxRetries times, retryDelay
+// apart, before it is dropped and reported to OnError.
+func NewAsyncMirrorAdapter(primary SecureStorage, queueSize int, maxRetries int, retryDelay time.Duration, mirrors ...SecureStorage) *MirrorAdapter {
+	ma := &MirrorAdapter{
+		Primary:    primary,
+		Mirrors:    mirrors,
+		async:      true,
+		maxRetries: maxRetries,
+		retryDelay: retryDelay,
+	}
+	for i := range mirrors {
+		queue := make(chan mirrorOp, queueSize)
+		ma.queues = append(ma.queues, queue)
+		ma.wg.Add(1)
+		go ma.drain(i, queue)
+	}
+	return ma
+}
+
+func (ma *MirrorAdapter) reportError(mirror int, key string, err error) {
+	if ma.OnError != nil {
+		ma.OnError(mirror, key, err)
+	}
+}
+
+func (ma *MirrorAdapter) apply(mirror int, op mirrorOp) error {
+	if op.delete {
+		return ma.Mirrors[mirror].Delete(op.key)
+	}
+	return ma.Mirrors[mirror].Store(op.key, op.value)
+}
+
+// Apply queued operations to one mirror until its queue is closed.
+func (ma *MirrorAdapter) drain(mirror int, queue chan mirrorOp) {
+	defer ma.wg.Done()
+	for op := range queue {
+		err := ma.apply(mirror, op)
+		for i := 0; err != nil && i < ma.maxRetries; i++ {
+			time.Sleep(ma.retryDelay)
+			err = ma.apply(mirror, op)
+		}
+		if err != nil {
+			ma.reportError(mirror, op.key, err)
+		}
+	}
+}
+
+// Send an operation to every mirror.
+func (ma *MirrorAdapter) fanOut(op mirrorOp) error {
+	if ma.async {
+		ma.mu.RLock()
+		defer ma.mu.RUnlock()
+		if ma.closed {
+			return fmt.Errorf("MirrorAdapter is closed")
+		}
+		for _, queue := range ma.queues {
+			queue <- op
+		}
+		return nil
+	}
+
+	var errs []string
+	for i := range ma.Mirrors {
+		err := ma.apply(i, op)
+		if err != nil {
+			ma.reportError(i, op.key, err)
+			errs = append(errs, fmt.Sprintf("mirror %d: %v", i, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("Failed to update mirrors for %s: %s", op.key, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (ma *MirrorAdapter) Store(key string, value interface{}) error {
+	data, err := toMap(value)
+	if err != nil {
+		return err
+	}
+	err = ma.Primary.Store(key, data)
+	if err != nil {
+		return err
+	}
+	return ma.fanOut(mirrorOp{key: key, value: data})
+}
+
+func (ma *MirrorAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	data, err := toMap(value)
+	if err != nil {
+		return err
+	}
+	err = ma.Primary.StoreWithData(key, data, output)
+	if err != nil {
+		return err
+	}
+	return ma.fanOut(mirrorOp{key: key, value: data})
+}
+
+func (ma *MirrorAdapter) Lookup(key string, output interface{}) error {
+	return ma.Primary.Lookup(key, output)
+}
+
+func (ma *MirrorAdapter) Delete(key string) error {
+	err := ma.Primary.Delete(key)
+	if err != nil {
+		return err
+	}
+	return ma.fanOut(mirrorOp{delete: true, key: key})
+}
+
+func (ma *MirrorAdapter) LookupKeys(keyPath string) ([]string, error) {
+	return ma.Primary.LookupKeys(keyPath)
+}
+
+// Stop accepting writes, wait for the mirror queues to drain, then close
+// the primary and every mirror.
+func (ma *MirrorAdapter) Close() error {
+	if ma.async {
+		ma.mu.Lock()
+		if ma.closed {
+			ma.mu.Unlock()
+			return nil
+		}
+		ma.closed = true
+		for _, queue := range ma.queues {
+			close(queue)
+		}
+		ma.mu.Unlock()
+		ma.wg.Wait()
+	}
+	return closeAll(append([]SecureStorage{ma.Primary}, ma.Mirrors...)...)
+}
+
+// Only the primary is checked; mirror failures are reported through
+// OnError.
+func (ma *MirrorAdapter) Health() error {
+	return Health(ma.Primary)
+}
@@ -0,0 +1,130 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyStore fails the first failures operations and then behaves like a
+// memStore.
+type flakyStore struct {
+	*memStore
+	mu       sync.Mutex
+	failures int
+}
+
+func (fs *flakyStore) fail() error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if fs.failures > 0 {
+		fs.failures--
+		return fmt.Errorf("Code: 503")
+	}
+	return nil
+}
+
+func (fs *flakyStore) Store(key string, value interface{}) error {
+	if err := fs.fail(); err != nil {
+		return err
+	}
+	return fs.memStore.Store(key, value)
+}
+
+func (fs *flakyStore) Delete(key string) error {
+	if err := fs.fail(); err != nil {
+		return err
+	}
+	return fs.memStore.Delete(key)
+}
+
+func TestMirrorAdapter(t *testing.T) {
+	value := creds{
+		Xname:    "x0c0s1b0",
+		URL:      "10.4.0.21/redfish/v1/UpdateService",
+		Username: "test1",
+		Password: "123",
+	}
+
+	var tests = []struct {
+		async    bool
+		failures int
+		retries  int
+		respErr  bool
+		mirrored bool
+		errors   int
+	}{
+		{async: false, failures: 0, respErr: false, mirrored: true},
+		{async: false, failures: 1, respErr: true, mirrored: false, errors: 1},
+		{async: true, failures: 0, retries: 0, mirrored: true},
+		{async: true, failures: 2, retries: 2, mirrored: true},
+		{async: true, failures: 2, retries: 1, mirrored: false, errors: 1},
+	}
+
+	for i, test := range tests {
+		primary := newMemStore()
+		good := newMemStore()
+		flaky := &flakyStore{memStore: newMemStore(), failures: test.failures}
+		var ma *MirrorAdapter
+		if test.async {
+			ma = NewAsyncMirrorAdapter(primary, 4, test.retries, time.Millisecond, good, flaky)
+		} else {
+			ma = NewMirrorAdapter(primary, good, flaky)
+		}
+		errors := 0
+		ma.OnError = func(mirror int, key string, err error) {
+			errors++
+		}
+
+		err := ma.Store("x0c0s1b0", value)
+		if (err != nil) != test.respErr {
+			t.Errorf("Test %v Failed: Unexpected error result - %v", i, err)
+		}
+		ma.Close()
+
+		for j, ms := range []*memStore{primary, good} {
+			var r creds
+			ms.Lookup("x0c0s1b0", &r)
+			if !reflect.DeepEqual(r, value) {
+				t.Errorf("Test %v Failed: Expected store %v to have %v but got %v", i, j, value, r)
+			}
+		}
+		var r creds
+		flaky.Lookup("x0c0s1b0", &r)
+		if reflect.DeepEqual(r, value) != test.mirrored {
+			t.Errorf("Test %v Failed: Expected mirrored=%v but got %v", i, test.mirrored, r)
+		}
+		if errors != test.errors {
+			t.Errorf("Test %v Failed: Expected %v errors but got %v", i, test.errors, errors)
+		}
+		if test.async {
+			if err := ma.Store("x0c0s2b0", value); err == nil {
+				t.Errorf("Test %v Failed: Expected an error writing after Close()", i)
+			}
+		}
+	}
+}
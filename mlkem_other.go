@@ -0,0 +1,43 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+//go:build !go1.24
+
+package securestorage
+
+import "fmt"
+
+const mlkemSeedSize = 64
+
+var errNoMLKEM = fmt.Errorf("%s requires Go 1.24 or later", AlgX25519MLKEM768)
+
+func mlkemPublic(seed []byte) ([]byte, error) {
+	return nil, errNoMLKEM
+}
+
+func mlkemEncapsulate(encapsulationKey []byte) ([]byte, []byte, error) {
+	return nil, nil, errNoMLKEM
+}
+
+func mlkemDecapsulate(seed []byte, ciphertext []byte) ([]byte, error) {
+	return nil, errNoMLKEM
+}
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright [2019-2022] Hewlett Packard Enterprise Development LP
+// (C) Copyright [2019-2022,2026] Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -24,7 +24,6 @@ package securestorage
 
 import (
 	"fmt"
-	"github.com/mitchellh/mapstructure"
 )
 
 type InputStore struct {
@@ -87,6 +86,22 @@ type MockLookupKeys struct {
 	Output OutputLookupKeys
 }
 
+type OutputClose struct {
+	Err error
+}
+
+type MockClose struct {
+	Output OutputClose
+}
+
+type OutputHealth struct {
+	Err error
+}
+
+type MockHealth struct {
+	Output OutputHealth
+}
+
 type MockAdapter struct {
 	StoreNum       int
 	StoreData      []MockStore
@@ -98,6 +113,10 @@ type MockAdapter struct {
 	DeleteData     []MockDelete
 	LookupKeysNum  int
 	LookupKeysData []MockLookupKeys
+	CloseNum       int
+	CloseData      []MockClose
+	HealthNum      int
+	HealthData     []MockHealth
 }
 
 func NewMockAdapter() (SecureStorage, *MockAdapter) {
@@ -124,7 +143,7 @@ func (ss *MockAdapter) StoreWithData(key string, value interface{}, output inter
 	ss.StoreWDataNum++
 	ss.StoreWData[i].Input.Key = key
 	ss.StoreWData[i].Input.Value = value
-	err := mapstructure.Decode(ss.StoreWData[i].Output.Output, output)
+	err := decodeValue(ss.StoreWData[i].Output.Output, output)
 	if err != nil {
 		return err
 	}
@@ -156,7 +175,7 @@ func (ss *MockAdapter) Lookup(key string, output interface{}) error {
 		}
 	}
 
-	err := mapstructure.Decode(ss.LookupData[i].Output.Output, output)
+	err := decodeValue(ss.LookupData[i].Output.Output, output)
 	if err != nil {
 		return err
 	}
@@ -200,3 +219,25 @@ func (ss *MockAdapter) LookupKeys(keyPath string) ([]string, error) {
 
 	return ss.LookupKeysData[i].Output.Klist, ss.LookupKeysData[i].Output.Err
 }
+
+func (ss *MockAdapter) Close() error {
+	i := ss.CloseNum
+	if len(ss.CloseData) <= i {
+		return fmt.Errorf("Unexpected call to MockClose")
+	}
+	ss.CloseNum++
+	return ss.CloseData[i].Output.Err
+}
+
+func (ss *MockAdapter) Health() error {
+	i := ss.HealthNum
+	if len(ss.HealthData) <= i {
+		return fmt.Errorf("Unexpected call to MockHealth")
+	}
+	ss.HealthNum++
+	return ss.HealthData[i].Output.Err
+}
+
+func (ss *MockAdapter) IterateKeys(keyPath string) KeyIterator {
+	return newListKeyIterator(ss, keyPath)
+}
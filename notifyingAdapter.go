@@ -0,0 +1,130 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"io"
+	"time"
+)
+
+// ChangeEvent describes a successful write to a store. Values are never
+// included. Version is only set for stores implementing VersionedStore.
+type ChangeEvent struct {
+	Key       string    `json:"key"`
+	Operation string    `json:"operation"`
+	Version   int       `json:"version,omitempty"`
+	Time      time.Time `json:"timestamp"`
+}
+
+// ChangeNotifier is told about every ChangeEvent. Notify must not block for
+// long since it is called on the write path.
+type ChangeNotifier interface {
+	Notify(event ChangeEvent)
+}
+
+// NotifyingAdapter sends a ChangeEvent to every notifier after each
+// successful Store, StoreWithData and Delete on the wrapped store.
+type NotifyingAdapter struct {
+	Inner     SecureStorage
+	Notifiers []ChangeNotifier
+	now       func() time.Time
+}
+
+// Create a new NotifyingAdapter wrapping inner.
+func NewNotifyingAdapter(inner SecureStorage, notifiers ...ChangeNotifier) *NotifyingAdapter {
+	return &NotifyingAdapter{
+		Inner:     inner,
+		Notifiers: notifiers,
+		now:       time.Now,
+	}
+}
+
+func (na *NotifyingAdapter) notify(op string, key string) {
+	event := ChangeEvent{
+		Key:       key,
+		Operation: op,
+		Time:      na.now().UTC(),
+	}
+	if vs, ok := na.Inner.(VersionedStore); ok && op != OpDelete {
+		if versions, err := vs.ListVersions(key); err == nil && len(versions) > 0 {
+			event.Version = versions[len(versions)-1].Version
+		}
+	}
+	for _, n := range na.Notifiers {
+		n.Notify(event)
+	}
+}
+
+func (na *NotifyingAdapter) Store(key string, value interface{}) error {
+	err := na.Inner.Store(key, value)
+	if err == nil {
+		na.notify(OpStore, key)
+	}
+	return err
+}
+
+func (na *NotifyingAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	err := na.Inner.StoreWithData(key, value, output)
+	if err == nil {
+		na.notify(OpStoreWithData, key)
+	}
+	return err
+}
+
+func (na *NotifyingAdapter) Lookup(key string, output interface{}) error {
+	return na.Inner.Lookup(key, output)
+}
+
+func (na *NotifyingAdapter) Delete(key string) error {
+	err := na.Inner.Delete(key)
+	if err == nil {
+		na.notify(OpDelete, key)
+	}
+	return err
+}
+
+func (na *NotifyingAdapter) LookupKeys(keyPath string) ([]string, error) {
+	return na.Inner.LookupKeys(keyPath)
+}
+
+func (na *NotifyingAdapter) Health() error {
+	return Health(na.Inner)
+}
+
+// Close every notifier that supports it, so queued events are delivered,
+// then close the wrapped store.
+func (na *NotifyingAdapter) Close() error {
+	var err error
+
+	for _, n := range na.Notifiers {
+		if c, ok := n.(io.Closer); ok {
+			if cerr := c.Close(); err == nil {
+				err = cerr
+			}
+		}
+	}
+	if cerr := Close(na.Inner); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,126 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Populate fills the fields of the struct pointed to by target from ss,
+// using "secure" struct tags of the form "path/to/key#field". Without a
+// "#field" the whole value stored at the key is decoded into the struct
+// field. Adding ",optional" leaves the field alone when the key or field
+// does not exist; otherwise a missing key or field is an error. Untagged
+// struct fields are walked recursively. Each key is only looked up once.
+//
+//	type Config struct {
+//		BMCUser     string `secure:"hms-creds/global#Username"`
+//		BMCPassword string `secure:"hms-creds/global#Password"`
+//		Switch      creds  `secure:"hms-creds/switch"`
+//		Token       string `secure:"tokens/api#value,optional"`
+//	}
+func Populate(ss SecureStorage, target interface{}) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Populate target must be a pointer to a struct")
+	}
+	return populateStruct(ss, v.Elem(), map[string]map[string]interface{}{})
+}
+
+func populateStruct(ss SecureStorage, v reflect.Value, cache map[string]map[string]interface{}) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := v.Field(i)
+		if !fv.CanSet() {
+			continue
+		}
+		tag, ok := sf.Tag.Lookup("secure")
+		if !ok {
+			if fv.Kind() == reflect.Struct {
+				err := populateStruct(ss, fv, cache)
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		err := populateField(ss, sf.Name, fv, tag, cache)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func populateField(ss SecureStorage, name string, fv reflect.Value, tag string, cache map[string]map[string]interface{}) error {
+	opts := strings.Split(tag, ",")
+	optional := false
+	for _, opt := range opts[1:] {
+		if opt == "optional" {
+			optional = true
+		}
+	}
+	key, field, _ := strings.Cut(opts[0], "#")
+	if key == "" {
+		return fmt.Errorf("Invalid secure tag %q on field %s", tag, name)
+	}
+
+	data, ok := cache[key]
+	if !ok {
+		err := ss.Lookup(key, &data)
+		if err != nil {
+			return fmt.Errorf("Cannot look up %s for field %s: %v", key, name, err)
+		}
+		cache[key] = data
+	}
+	if data == nil {
+		if optional {
+			return nil
+		}
+		return fmt.Errorf("%w: %s for field %s", ErrNotFound, key, name)
+	}
+
+	var value interface{} = data
+	if field != "" {
+		value, ok = data[field]
+		if !ok {
+			if optional {
+				return nil
+			}
+			return fmt.Errorf("%w: %s#%s for field %s", ErrNotFound, key, field, name)
+		}
+	}
+
+	if s, ok := value.(string); ok && fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.Uint8 {
+		fv.SetBytes([]byte(s))
+		return nil
+	}
+	err := decodeValue(value, fv.Addr().Interface())
+	if err != nil {
+		return fmt.Errorf("Cannot decode %s into field %s: %v", opts[0], name, err)
+	}
+	return nil
+}
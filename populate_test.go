@@ -0,0 +1,80 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPopulate(t *testing.T) {
+	sw := creds{Xname: "x3000c0w14", Username: "admin", Password: "swpw"}
+	cs := &countingStore{memStore: newMemStore()}
+	cs.memStore.Store("hms-creds/global", creds{Username: "root", Password: "pw"})
+	cs.memStore.Store("hms-creds/switch", sw)
+	cs.memStore.Store("tokens/api", map[string]interface{}{"value": "tok", "retries": 3})
+
+	type nested struct {
+		Retries int `secure:"tokens/api#retries"`
+	}
+	type config struct {
+		BMCUser     string `secure:"hms-creds/global#Username"`
+		BMCPassword []byte `secure:"hms-creds/global#Password"`
+		Switch      creds  `secure:"hms-creds/switch"`
+		Token       string `secure:"tokens/api#value,optional"`
+		Missing     string `secure:"tokens/other#value,optional"`
+		Nested      nested
+		Plain       string
+	}
+
+	var cfg config
+	cfg.Missing = "default"
+	if err := Populate(cs, &cfg); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	expected := config{
+		BMCUser:     "root",
+		BMCPassword: []byte("pw"),
+		Switch:      sw,
+		Token:       "tok",
+		Missing:     "default",
+		Nested:      nested{Retries: 3},
+	}
+	if !reflect.DeepEqual(cfg, expected) {
+		t.Errorf("Expected %+v but got %+v", expected, cfg)
+	}
+	if cs.lookups != 4 {
+		t.Errorf("Expected 4 lookups but got %v", cs.lookups)
+	}
+
+	var required struct {
+		Value string `secure:"tokens/other#value"`
+	}
+	if err := Populate(cs, &required); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound but got %v", err)
+	}
+	if err := Populate(cs, cfg); err == nil {
+		t.Errorf("Expected an error for a non-pointer target")
+	}
+}
@@ -0,0 +1,101 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import "strings"
+
+// PrefixAdapter partitions a shared SecureStorage by prepending a prefix to
+// every key, so each service only sees its own part of the key space. Keys
+// that fail CheckKey(), such as those with ".." elements that would escape
+// the prefix, are rejected.
+type PrefixAdapter struct {
+	Inner  SecureStorage
+	Prefix string
+}
+
+// Create a new PrefixAdapter that stores every key below prefix in store.
+func WithPrefix(store SecureStorage, prefix string) *PrefixAdapter {
+	return &PrefixAdapter{
+		Inner:  store,
+		Prefix: strings.Trim(prefix, "/"),
+	}
+}
+
+// Map a caller's key to the key used in the wrapped store.
+func (pa *PrefixAdapter) fullKey(key string) (string, error) {
+	err := CheckKey(key)
+	if err != nil {
+		return "", err
+	}
+	return joinKey(pa.Prefix, key), nil
+}
+
+func (pa *PrefixAdapter) Store(key string, value interface{}) error {
+	fullKey, err := pa.fullKey(key)
+	if err != nil {
+		return err
+	}
+	return pa.Inner.Store(fullKey, value)
+}
+
+func (pa *PrefixAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	fullKey, err := pa.fullKey(key)
+	if err != nil {
+		return err
+	}
+	return pa.Inner.StoreWithData(fullKey, value, output)
+}
+
+func (pa *PrefixAdapter) Lookup(key string, output interface{}) error {
+	fullKey, err := pa.fullKey(key)
+	if err != nil {
+		return err
+	}
+	return pa.Inner.Lookup(fullKey, output)
+}
+
+func (pa *PrefixAdapter) Delete(key string) error {
+	fullKey, err := pa.fullKey(key)
+	if err != nil {
+		return err
+	}
+	return pa.Inner.Delete(fullKey)
+}
+
+// List the keys below keyPath within the prefix. The names returned are
+// relative to keyPath, as with any other SecureStorage.
+func (pa *PrefixAdapter) LookupKeys(keyPath string) ([]string, error) {
+	fullKey, err := pa.fullKey(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	return pa.Inner.LookupKeys(fullKey)
+}
+
+func (pa *PrefixAdapter) Close() error {
+	return Close(pa.Inner)
+}
+
+func (pa *PrefixAdapter) Health() error {
+	return Health(pa.Inner)
+}
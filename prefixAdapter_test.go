@@ -0,0 +1,89 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestPrefixAdapter(t *testing.T) {
+	ms := newMemStore()
+	ms.Store("other/x0c0s9b0", map[string]interface{}{"Username": "other"})
+	pa := WithPrefix(ms, "/hms-creds/")
+
+	for _, key := range []string{"x0c0s1b0", "x1000/x1000c0s0b0"} {
+		if err := pa.Store(key, map[string]interface{}{"Username": key}); err != nil {
+			t.Fatalf("Unexpected error - %v", err)
+		}
+	}
+
+	var raw map[string]interface{}
+	ms.Lookup("hms-creds/x0c0s1b0", &raw)
+	if raw["Username"] != "x0c0s1b0" {
+		t.Errorf("Expected key to be stored below the prefix but got %v", raw)
+	}
+
+	var tests = []struct {
+		keyPath string
+		resp    []string
+		respErr bool
+	}{
+		{keyPath: "", resp: []string{"x0c0s1b0", "x1000/"}},
+		{keyPath: "x1000", resp: []string{"x1000c0s0b0"}},
+		{keyPath: "x1000/", resp: []string{"x1000c0s0b0"}},
+		{keyPath: "../other", respErr: true},
+		{keyPath: "./x1000", respErr: true},
+		{keyPath: "x1000//", respErr: true},
+		{keyPath: "/x1000", respErr: true},
+	}
+	for i, test := range tests {
+		r, err := pa.LookupKeys(test.keyPath)
+		if (err != nil) != test.respErr {
+			t.Errorf("Test %v Failed: Unexpected error result - %v", i, err)
+			continue
+		}
+		if !test.respErr && !reflect.DeepEqual(r, test.resp) {
+			t.Errorf("Test %v Failed: Expected keys %v but got %v", i, test.resp, r)
+		}
+	}
+
+	var r creds
+	for _, key := range []string{"../other/x0c0s9b0", "./x0c0s1b0", "x1000//x1000c0s0b0", "x1000/./x1000c0s0b0"} {
+		if err := pa.Lookup(key, &r); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Expected ErrInvalidKey looking up %q but got %v", key, err)
+		}
+	}
+
+	keys := []string{}
+	it := IterateKeys(pa, "")
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	sort.Strings(keys)
+	if !reflect.DeepEqual(keys, []string{"x0c0s1b0", "x1000/x1000c0s0b0"}) {
+		t.Errorf("Expected iterated keys relative to the prefix but got %v", keys)
+	}
+}
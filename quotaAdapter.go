@@ -0,0 +1,309 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrQuotaExceeded matches every *QuotaError with errors.Is.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// Quota limits the keys stored below a key path. A zero limit is unlimited.
+type Quota struct {
+	MaxKeys  int
+	MaxBytes int64
+}
+
+// Limits reported in a QuotaError
+const (
+	QuotaKeys  = "keys"
+	QuotaBytes = "bytes"
+)
+
+// QuotaError is returned when a write would take a key path over its quota.
+type QuotaError struct {
+	Path  string
+	Key   string
+	Limit string
+	Max   int64
+	// Usage the write would have resulted in.
+	Requested int64
+}
+
+func (qe *QuotaError) Error() string {
+	return fmt.Sprintf("%v: storing %s would use %d %s of %d allowed below %q",
+		ErrQuotaExceeded, qe.Key, qe.Requested, qe.Limit, qe.Max, qe.Path)
+}
+
+func (qe *QuotaError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// quotaUsage is the usage below one key path. mu is held while the usage
+// is loaded and across writes below the path, so writes in other paths
+// are not held up.
+type quotaUsage struct {
+	mu    sync.Mutex
+	quota Quota
+	sizes map[string]int64
+	bytes int64
+}
+
+// QuotaAdapter enforces per key path quotas on the number of keys and the
+// total size of their values, so one user of a shared store cannot exhaust
+// it. Sizes are the length of the JSON encoding of each value. Usage below
+// each path is read from the wrapped store the first time it is needed and
+// tracked from then on, so writes that bypass the adapter are not counted.
+// Keys that fail CheckKey() are rejected, so they cannot reach another
+// path on the backend without being counted there.
+type QuotaAdapter struct {
+	Inner SecureStorage
+
+	mu     sync.Mutex
+	quotas map[string]*quotaUsage
+}
+
+// Create a new QuotaAdapter wrapping inner. No quotas apply until they are
+// set with SetQuota().
+func NewQuotaAdapter(inner SecureStorage) *QuotaAdapter {
+	return &QuotaAdapter{
+		Inner:  inner,
+		quotas: map[string]*quotaUsage{},
+	}
+}
+
+// Set the quota for every key below keyPath. An empty keyPath applies to the
+// whole store.
+func (qa *QuotaAdapter) SetQuota(keyPath string, quota Quota) {
+	qa.mu.Lock()
+	keyPath = strings.TrimSuffix(keyPath, "/")
+	usage, ok := qa.quotas[keyPath]
+	if !ok {
+		qa.quotas[keyPath] = &quotaUsage{quota: quota}
+	}
+	qa.mu.Unlock()
+	if ok {
+		usage.mu.Lock()
+		usage.quota = quota
+		usage.mu.Unlock()
+	}
+}
+
+// Get the number of keys and bytes stored below a key path with a quota.
+func (qa *QuotaAdapter) Usage(keyPath string) (int, int64, error) {
+	qa.mu.Lock()
+	keyPath = strings.TrimSuffix(keyPath, "/")
+	usage, ok := qa.quotas[keyPath]
+	qa.mu.Unlock()
+	if !ok {
+		return 0, 0, fmt.Errorf("No quota set for %q", keyPath)
+	}
+	usage.mu.Lock()
+	defer usage.mu.Unlock()
+	err := qa.load(keyPath, usage)
+	if err != nil {
+		return 0, 0, err
+	}
+	return len(usage.sizes), usage.bytes, nil
+}
+
+func underPath(keyPath string, key string) bool {
+	return keyPath == "" || strings.HasPrefix(key, keyPath+"/")
+}
+
+func valueSize(value interface{}) (int64, error) {
+	data, err := toMap(value)
+	if err != nil {
+		return 0, err
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(encoded)), nil
+}
+
+// Read the current usage below keyPath from the wrapped store. Must be
+// called with usage.mu held.
+func (qa *QuotaAdapter) load(keyPath string, usage *quotaUsage) error {
+	if usage.sizes != nil {
+		return nil
+	}
+	sizes := map[string]int64{}
+	var total int64
+	it := IterateKeys(qa.Inner, keyPath)
+	defer it.Close()
+	for it.Next() {
+		var data map[string]interface{}
+		err := qa.Inner.Lookup(it.Key(), &data)
+		if err != nil {
+			return err
+		}
+		size, err := valueSize(data)
+		if err != nil {
+			return err
+		}
+		sizes[it.Key()] = size
+		total += size
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	usage.sizes = sizes
+	usage.bytes = total
+	return nil
+}
+
+// Lock the usage of every key path with a quota that applies to key, in
+// a stable order, and load it. The returned function unlocks them.
+func (qa *QuotaAdapter) lockApplicable(key string) ([]string, []*quotaUsage, func(), error) {
+	qa.mu.Lock()
+	var paths []string
+	for keyPath := range qa.quotas {
+		if underPath(keyPath, key) {
+			paths = append(paths, keyPath)
+		}
+	}
+	sort.Strings(paths)
+	usages := make([]*quotaUsage, len(paths))
+	for i, keyPath := range paths {
+		usages[i] = qa.quotas[keyPath]
+	}
+	qa.mu.Unlock()
+
+	unlock := func() {
+		for _, usage := range usages {
+			usage.mu.Unlock()
+		}
+	}
+	for _, usage := range usages {
+		usage.mu.Lock()
+	}
+	for i, keyPath := range paths {
+		err := qa.load(keyPath, usages[i])
+		if err != nil {
+			unlock()
+			return nil, nil, nil, err
+		}
+	}
+	return paths, usages, unlock, nil
+}
+
+// Check every quota that applies to key and, if the write is allowed, run
+// it and record the new size.
+func (qa *QuotaAdapter) store(key string, value interface{}, write func() error) error {
+	err := CheckKey(key)
+	if err != nil {
+		return err
+	}
+	size, err := valueSize(value)
+	if err != nil {
+		return err
+	}
+	paths, usages, unlock, err := qa.lockApplicable(key)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	for i, keyPath := range paths {
+		usage := usages[i]
+		old, exists := usage.sizes[key]
+		keys := int64(len(usage.sizes))
+		if !exists {
+			keys++
+		}
+		if usage.quota.MaxKeys > 0 && keys > int64(usage.quota.MaxKeys) {
+			return &QuotaError{Path: keyPath, Key: key, Limit: QuotaKeys,
+				Max: int64(usage.quota.MaxKeys), Requested: keys}
+		}
+		bytes := usage.bytes - old + size
+		if usage.quota.MaxBytes > 0 && bytes > usage.quota.MaxBytes {
+			return &QuotaError{Path: keyPath, Key: key, Limit: QuotaBytes,
+				Max: usage.quota.MaxBytes, Requested: bytes}
+		}
+	}
+	err = write()
+	if err != nil {
+		return err
+	}
+	for _, usage := range usages {
+		usage.bytes += size - usage.sizes[key]
+		usage.sizes[key] = size
+	}
+	return nil
+}
+
+func (qa *QuotaAdapter) Store(key string, value interface{}) error {
+	return qa.store(key, value, func() error {
+		return qa.Inner.Store(key, value)
+	})
+}
+
+func (qa *QuotaAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	return qa.store(key, value, func() error {
+		return qa.Inner.StoreWithData(key, value, output)
+	})
+}
+
+func (qa *QuotaAdapter) Lookup(key string, output interface{}) error {
+	return qa.Inner.Lookup(key, output)
+}
+
+func (qa *QuotaAdapter) Delete(key string) error {
+	err := CheckKey(key)
+	if err != nil {
+		return err
+	}
+	_, usages, unlock, err := qa.lockApplicable(key)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	err = qa.Inner.Delete(key)
+	if err != nil {
+		return err
+	}
+	for _, usage := range usages {
+		usage.bytes -= usage.sizes[key]
+		delete(usage.sizes, key)
+	}
+	return nil
+}
+
+func (qa *QuotaAdapter) LookupKeys(keyPath string) ([]string, error) {
+	return qa.Inner.LookupKeys(keyPath)
+}
+
+func (qa *QuotaAdapter) Health() error {
+	return Health(qa.Inner)
+}
+
+func (qa *QuotaAdapter) Close() error {
+	return Close(qa.Inner)
+}
@@ -0,0 +1,135 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuotaAdapter(t *testing.T) {
+	store := newMemStore()
+	store.Store("tenant-a/x0c0s1b0", creds{Password: "pw"})
+	qa := NewQuotaAdapter(store)
+	qa.SetQuota("tenant-a", Quota{MaxKeys: 2})
+	qa.SetQuota("tenant-b/", Quota{MaxBytes: 60})
+
+	var tests = []struct {
+		key     string
+		value   interface{}
+		limit   string
+		deleted bool
+		invalid bool
+	}{
+		{key: "tenant-a/x0c0s2b0", value: creds{Password: "pw"}},
+		{key: "tenant-a/x0c0s3b0", value: creds{Password: "pw"}, limit: QuotaKeys},
+		{key: "tenant-a/x0c0s2b0", value: creds{Password: "updated"}},
+		{key: "tenant-a/x0c0s2b0", deleted: true},
+		{key: "tenant-a/x0c0s3b0", value: creds{Password: "pw"}},
+		{key: "tenant-b/x0c0s1b0", value: map[string]string{"Password": "short"}},
+		{key: "tenant-b/x0c0s2b0", value: map[string]string{"Password": "long enough to go over the quota"}, limit: QuotaBytes},
+		{key: "tenant-c/x0c0s1b0", value: creds{Password: "pw"}},
+		{key: "./tenant-a/x0c0s4b0", value: creds{Password: "pw"}, invalid: true},
+		{key: "tenant-a//x0c0s4b0", value: creds{Password: "pw"}, invalid: true},
+		{key: "x/../tenant-a/x0c0s4b0", value: creds{Password: "pw"}, invalid: true},
+		{key: "x/../tenant-a/x0c0s1b0", deleted: true, invalid: true},
+	}
+
+	for i, test := range tests {
+		var err error
+		if test.deleted {
+			err = qa.Delete(test.key)
+		} else {
+			err = qa.Store(test.key, test.value)
+		}
+		var qe *QuotaError
+		if test.invalid {
+			if !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("Test %v Failed: Expected ErrInvalidKey but got %v", i, err)
+			}
+		} else if test.limit == "" {
+			if err != nil {
+				t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+			}
+		} else if !errors.As(err, &qe) || !errors.Is(err, ErrQuotaExceeded) || qe.Limit != test.limit {
+			t.Errorf("Test %v Failed: Expected a %s QuotaError but got %v", i, test.limit, err)
+		}
+	}
+
+	keys, bytes, err := qa.Usage("tenant-b")
+	if err != nil || keys != 1 || bytes != 20 {
+		t.Errorf("Expected 1 key and 20 bytes used but got %d, %d (%v)", keys, bytes, err)
+	}
+	if _, _, err := qa.Usage("tenant-c"); err == nil {
+		t.Errorf("Expected an error for a path without a quota")
+	}
+}
+
+// blockingStore holds writes below a prefix until release is closed.
+type blockingStore struct {
+	*memStore
+	prefix  string
+	release chan struct{}
+}
+
+func (bs *blockingStore) Store(key string, value interface{}) error {
+	if strings.HasPrefix(key, bs.prefix) {
+		<-bs.release
+	}
+	return bs.memStore.Store(key, value)
+}
+
+func TestQuotaAdapterSlowWrite(t *testing.T) {
+	bs := &blockingStore{memStore: newMemStore(), prefix: "tenant-a/", release: make(chan struct{})}
+	qa := NewQuotaAdapter(bs)
+	qa.SetQuota("tenant-a", Quota{MaxKeys: 2})
+	qa.SetQuota("tenant-b", Quota{MaxKeys: 2})
+	qa.Usage("tenant-a")
+
+	// A write held up in one tenant does not block another.
+	slow := make(chan error)
+	go func() {
+		slow <- qa.Store("tenant-a/x0c0s1b0", creds{Password: "pw"})
+	}()
+	fast := make(chan error)
+	go func() {
+		fast <- qa.Store("tenant-b/x0c0s1b0", creds{Password: "pw"})
+	}()
+	select {
+	case err := <-fast:
+		if err != nil {
+			t.Errorf("Test Failed: Unexpected error - %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("Test Failed: Write to tenant-b waited for tenant-a")
+	}
+	close(bs.release)
+	if err := <-slow; err != nil {
+		t.Errorf("Test Failed: Unexpected error - %v", err)
+	}
+	if keys, _, err := qa.Usage("tenant-a"); err != nil || keys != 1 {
+		t.Errorf("Test Failed: Expected 1 key used but got %d (%v)", keys, err)
+	}
+}
@@ -0,0 +1,75 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrReadOnly is returned (wrapped with the key) by ReadOnlyAdapter for
+// every operation that would modify the store.
+var ErrReadOnly = errors.New("secure storage is read-only")
+
+// ReadOnlyAdapter allows lookups but rejects every write, so a store can be
+// handed to reporting and export tools without any risk of them changing
+// credentials. The wrapped store is unexported so it cannot be reached
+// through the adapter.
+type ReadOnlyAdapter struct {
+	inner SecureStorage
+}
+
+// Create a new ReadOnlyAdapter wrapping store.
+func ReadOnly(store SecureStorage) *ReadOnlyAdapter {
+	return &ReadOnlyAdapter{inner: store}
+}
+
+func (ra *ReadOnlyAdapter) Store(key string, value interface{}) error {
+	return fmt.Errorf("%w: cannot store %s", ErrReadOnly, key)
+}
+
+func (ra *ReadOnlyAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	return fmt.Errorf("%w: cannot store %s", ErrReadOnly, key)
+}
+
+func (ra *ReadOnlyAdapter) Lookup(key string, output interface{}) error {
+	return ra.inner.Lookup(key, output)
+}
+
+func (ra *ReadOnlyAdapter) Delete(key string) error {
+	return fmt.Errorf("%w: cannot delete %s", ErrReadOnly, key)
+}
+
+func (ra *ReadOnlyAdapter) LookupKeys(keyPath string) ([]string, error) {
+	return ra.inner.LookupKeys(keyPath)
+}
+
+// Close does nothing. The wrapped store belongs to whoever handed out the
+// read-only view and stays open.
+func (ra *ReadOnlyAdapter) Close() error {
+	return nil
+}
+
+func (ra *ReadOnlyAdapter) Health() error {
+	return Health(ra.inner)
+}
@@ -0,0 +1,52 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReadOnlyAdapter(t *testing.T) {
+	ms := newMemStore()
+	ms.Store("x0c0s1b0", map[string]interface{}{"Username": "root"})
+	ra := ReadOnly(ms)
+
+	if err := ra.Store("x0c0s1b0", map[string]interface{}{"Username": "admin"}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Store but got %v", err)
+	}
+	if err := ra.StoreWithData("x0c0s1b0", map[string]interface{}{}, nil); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from StoreWithData but got %v", err)
+	}
+	if err := ra.Delete("x0c0s1b0"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly from Delete but got %v", err)
+	}
+
+	var r creds
+	if err := ra.Lookup("x0c0s1b0", &r); err != nil || r.Username != "root" {
+		t.Errorf("Expected unchanged credentials but got %v (%v)", r, err)
+	}
+	if keys, err := ra.LookupKeys(""); err != nil || len(keys) != 1 {
+		t.Errorf("Expected one key but got %v (%v)", keys, err)
+	}
+}
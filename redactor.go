@@ -0,0 +1,216 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Strings shorter than this are never redacted, so that values such as
+// "on" or "0" do not mangle messages.
+const minRedactLength = 4
+
+// DefaultRedactionPatterns match credentials that must never be logged
+// whatever store they come from: Vault tokens, JWTs, bearer tokens and PEM
+// private keys.
+var DefaultRedactionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\bhv[sbr]\.[A-Za-z0-9_-]{20,}`),
+	regexp.MustCompile(`\b[sbr]\.[A-Za-z0-9]{24}\b`),
+	regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/=-]+`),
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?(-----END [A-Z ]*PRIVATE KEY-----|$)`),
+}
+
+// Redactor removes secrets from error messages and log lines. It removes
+// anything matching its patterns, every secret registered with Add() and
+// the string values of whatever is passed to Redact().
+type Redactor struct {
+	Patterns []*regexp.Regexp
+
+	mu      sync.RWMutex
+	secrets map[string]bool
+}
+
+// DefaultRedactor is used by AuditedAdapter, the httpserver package and
+// this package's own error paths. Secrets such as master keys added to it
+// are redacted everywhere.
+var DefaultRedactor = NewRedactor()
+
+// Create a new Redactor using DefaultRedactionPatterns.
+func NewRedactor() *Redactor {
+	return &Redactor{
+		Patterns: DefaultRedactionPatterns,
+		secrets:  map[string]bool{},
+	}
+}
+
+// Register secrets that must always be redacted.
+func (r *Redactor) Add(secrets ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, secret := range secrets {
+		if len(secret) >= minRedactLength {
+			r.secrets[secret] = true
+		}
+	}
+}
+
+// Collect the strings in a value: strings, maps, slices and structs.
+func collectStrings(value interface{}, out map[string]bool) {
+	switch v := value.(type) {
+	case nil:
+		return
+	case string:
+		if len(v) >= minRedactLength {
+			out[v] = true
+		}
+		return
+	case SecretString:
+		collectStrings(v.Reveal(), out)
+		return
+	case SecretBytes:
+		collectStrings(string(v.Reveal()), out)
+		return
+	case []byte:
+		collectStrings(string(v), out)
+		return
+	case map[string]interface{}:
+		for _, elem := range v {
+			collectStrings(elem, out)
+		}
+		return
+	case []interface{}:
+		for _, elem := range v {
+			collectStrings(elem, out)
+		}
+		return
+	}
+
+	collectValue(reflect.ValueOf(value), out, map[uintptr]bool{})
+}
+
+// Collect the strings in the map keys and values, exported struct fields
+// and elements of rv. seen holds the pointers already followed, so cyclic
+// values end.
+func collectValue(rv reflect.Value, out map[string]bool, seen map[uintptr]bool) {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return
+		}
+		if rv.Kind() == reflect.Pointer {
+			if seen[rv.Pointer()] {
+				return
+			}
+			seen[rv.Pointer()] = true
+		}
+		rv = rv.Elem()
+	}
+	if rv.CanInterface() {
+		switch v := rv.Interface().(type) {
+		case SecretString, SecretBytes, []byte:
+			collectStrings(v, out)
+			return
+		}
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		collectStrings(rv.String(), out)
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			if rv.Type().Field(i).IsExported() {
+				collectValue(rv.Field(i), out, seen)
+			}
+		}
+	case reflect.Map:
+		iter := rv.MapRange()
+		for iter.Next() {
+			collectValue(iter.Key(), out, seen)
+			collectValue(iter.Value(), out, seen)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			collectValue(rv.Index(i), out, seen)
+		}
+	}
+}
+
+// Redact s, also removing the string values found in values.
+func (r *Redactor) Redact(s string, values ...interface{}) string {
+	secrets := map[string]bool{}
+	for _, value := range values {
+		collectStrings(value, secrets)
+	}
+	r.mu.RLock()
+	for secret := range r.secrets {
+		secrets[secret] = true
+	}
+	r.mu.RUnlock()
+
+	// Longest first so a secret containing another is removed whole.
+	sorted := make([]string, 0, len(secrets))
+	for secret := range secrets {
+		sorted = append(sorted, secret)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	for _, secret := range sorted {
+		s = strings.ReplaceAll(s, secret, Redacted)
+	}
+	for _, pattern := range r.Patterns {
+		s = pattern.ReplaceAllString(s, Redacted)
+	}
+	return s
+}
+
+// redactedError replaces the message of an error. It does not unwrap, as
+// the wrapped errors carry the original message, but errors.Is() still
+// matches the sentinels in its chain.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (re *redactedError) Error() string {
+	return re.msg
+}
+
+func (re *redactedError) Is(target error) bool {
+	return errors.Is(re.err, target)
+}
+
+// Redact the message of err, also removing the string values found in
+// values. err is returned unchanged if there was nothing to redact.
+func (r *Redactor) RedactError(err error, values ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	msg := r.Redact(err.Error(), values...)
+	if msg == err.Error() {
+		return err
+	}
+	return &redactedError{msg: msg, err: err}
+}
@@ -0,0 +1,211 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RemoteAdapter is a SecureStorage backed by the JSON API served by the
+// httpserver package, so Go programs can use a store exposed by another
+// process exactly like a local one. As with Vault,
+// looking up a missing key returns no error and leaves output untouched.
+type RemoteAdapter struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	// Sent as a bearer token if set.
+	Token string
+}
+
+// Create a new RemoteAdapter for the server at baseURL, e.g.
+// "https://127.0.0.1:8201".
+func NewRemoteAdapter(baseURL string) *RemoteAdapter {
+	return &RemoteAdapter{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Create a new RemoteAdapter for a server listening on the Unix socket at path.
+func NewUnixRemoteAdapter(path string) *RemoteAdapter {
+	return &RemoteAdapter{
+		BaseURL: "http://unix",
+		HTTPClient: &http.Client{
+			Timeout: 30 * time.Second,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, network string, addr string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", path)
+				},
+			},
+		},
+	}
+}
+
+// Send a request with a JSON body, if there is one.
+func (ra *RemoteAdapter) send(method string, path string, body interface{}) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequest(method, ra.BaseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if ra.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+ra.Token)
+	}
+	return ra.HTTPClient.Do(req)
+}
+
+// Make a request, decoding a JSON response into out. A 404 is returned as
+// an error wrapping ErrNotFound; only Lookup() treats it as a missing key,
+// since for any other request it means a wrong BaseURL or route.
+func (ra *RemoteAdapter) do(method string, path string, body interface{}, out interface{}) error {
+	resp, err := ra.send(method, path, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusAccepted:
+		// A write recorded for approval by an ApprovalAdapter
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		if out == nil || resp.StatusCode == http.StatusNoContent {
+			return nil
+		}
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	var errBody struct {
+		Error string `json:"error"`
+	}
+	json.NewDecoder(resp.Body).Decode(&errBody)
+	if errBody.Error == "" {
+		errBody.Error = resp.Status
+	}
+	switch resp.StatusCode {
+	case http.StatusAccepted:
+		return fmt.Errorf("%w: %s", ErrApprovalRequired, errBody.Error)
+	case http.StatusForbidden:
+		return fmt.Errorf("%w: %s", ErrAccessDenied, errBody.Error)
+	case http.StatusBadRequest:
+		return fmt.Errorf("%w: %s", ErrValidation, errBody.Error)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s %s: %s", ErrNotFound, method, path, errBody.Error)
+	}
+	return fmt.Errorf("Code: %d. %s", resp.StatusCode, errBody.Error)
+}
+
+// Get the request path for key, escaping each element so characters such
+// as "?", "#" and "%" stay part of the key.
+func remotePath(key string) string {
+	elems := strings.Split(strings.TrimPrefix(key, "/"), "/")
+	for i, elem := range elems {
+		elems[i] = url.PathEscape(elem)
+	}
+	return "/secrets/" + strings.Join(elems, "/")
+}
+
+func (ra *RemoteAdapter) Store(key string, value interface{}) error {
+	data, err := toMap(value)
+	if err != nil {
+		return err
+	}
+	return ra.do(http.MethodPut, remotePath(key), data, nil)
+}
+
+// The server does not return data for a write, so output is left
+// untouched.
+func (ra *RemoteAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	return ra.Store(key, value)
+}
+
+func (ra *RemoteAdapter) Lookup(key string, output interface{}) error {
+	var data map[string]interface{}
+
+	if output == nil {
+		return fmt.Errorf("output interface was nil")
+	}
+	err := ra.do(http.MethodGet, remotePath(key), nil, &data)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return decodeValue(data, output)
+}
+
+func (ra *RemoteAdapter) Delete(key string) error {
+	return ra.do(http.MethodDelete, remotePath(key), nil, nil)
+}
+
+func (ra *RemoteAdapter) LookupKeys(keyPath string) ([]string, error) {
+	var body struct {
+		Keys []string `json:"keys"`
+	}
+
+	err := ra.do(http.MethodGet, "/keys?path="+url.QueryEscape(keyPath), nil, &body)
+	return body.Keys, err
+}
+
+// Check that the server answers the API. A listing of the root is used as
+// the probe; a caller not allowed to list the root still gets a healthy
+// result, since the server answered.
+func (ra *RemoteAdapter) Health() error {
+	resp, err := ra.send(http.MethodGet, "/keys", nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299,
+		resp.StatusCode == http.StatusUnauthorized,
+		resp.StatusCode == http.StatusForbidden:
+		return nil
+	}
+	return fmt.Errorf("Code: %d. %s", resp.StatusCode, resp.Status)
+}
+
+func (ra *RemoteAdapter) Close() error {
+	ra.HTTPClient.CloseIdleConnections()
+	return nil
+}
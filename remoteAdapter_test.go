@@ -0,0 +1,125 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRemoteAdapter(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /secrets/found", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Xname":"x0c0s0b0","Username":"root"}`))
+	})
+	mux.HandleFunc("GET /secrets/denied", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":"no"}`))
+	})
+	mux.HandleFunc("PUT /secrets/bad", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"bad value"}`))
+	})
+	mux.HandleFunc("PUT /secrets/found", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("DELETE /secrets/root", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"error":"pending approval"}`))
+	})
+	mux.HandleFunc("GET /secrets/odd/{name}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Username":"` + r.PathValue("name") + `"}`))
+	})
+	mux.HandleFunc("GET /keys", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"keys":["` + r.URL.Query().Get("path") + `"]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ra := NewRemoteAdapter(server.URL + "/")
+	ra.Token = "token"
+	defer ra.Close()
+
+	var got creds
+	err := ra.Lookup("found", &got)
+	if err != nil || got.Username != "root" {
+		t.Errorf("Test 0 Failed: Expected root but got %v, %v", got, err)
+	}
+	var missing creds
+	err = ra.Lookup("missing", &missing)
+	if err != nil || missing != (creds{}) {
+		t.Errorf("Test 1 Failed: Expected missing key to be left empty but got %v, %v", missing, err)
+	}
+	err = ra.Lookup("denied", &got)
+	if !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("Test 2 Failed: Expected ErrAccessDenied but got %v", err)
+	}
+	err = ra.Store("bad", creds{})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("Test 3 Failed: Expected ErrValidation but got %v", err)
+	}
+	err = ra.Store("found", creds{Username: "root"})
+	if err != nil {
+		t.Errorf("Test 4 Failed: Unexpected error %v", err)
+	}
+	err = ra.Delete("root")
+	if !errors.Is(err, ErrApprovalRequired) {
+		t.Errorf("Test 6 Failed: Expected ErrApprovalRequired but got %v", err)
+	}
+	keys, err := ra.LookupKeys("a b")
+	if err != nil || len(keys) != 1 || keys[0] != "a b" {
+		t.Errorf("Test 5 Failed: Unexpected keys %v, %v", keys, err)
+	}
+
+	// Only lookups treat 404 as a missing key.
+	err = ra.Store("missing", creds{})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Test 7 Failed: Expected ErrNotFound but got %v", err)
+	}
+	err = ra.Delete("missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Test 8 Failed: Expected ErrNotFound but got %v", err)
+	}
+	got = creds{}
+	err = ra.Lookup("odd/a?b#c%d", &got)
+	if err != nil || got.Username != "a?b#c%d" {
+		t.Errorf("Test 9 Failed: Expected the key to be escaped but got %v, %v", got, err)
+	}
+
+	if err := ra.Health(); err != nil {
+		t.Errorf("Test 10 Failed: Unexpected error %v", err)
+	}
+	wrong := NewRemoteAdapter(server.URL + "/wrong")
+	if err := wrong.Health(); err == nil {
+		t.Errorf("Test 11 Failed: Expected an error for a wrong BaseURL")
+	}
+	if err := wrong.Store("found", creds{}); err == nil {
+		t.Errorf("Test 12 Failed: Expected an error storing to a wrong BaseURL")
+	}
+}
@@ -0,0 +1,214 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReportEntry describes one key in a ComplianceReport. It never contains
+// the value. Times are zero and Versions is 1 when no adapter in the chain
+// tracks them. Encryption is empty when values are only protected by the
+// backend.
+type ReportEntry struct {
+	Key         string            `json:"key"`
+	Backend     string            `json:"backend"`
+	CreatedTime time.Time         `json:"created_time,omitempty"`
+	LastRotated time.Time         `json:"last_rotated,omitempty"`
+	Versions    int               `json:"versions"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Encryption  string            `json:"encryption,omitempty"`
+	DataKeyID   string            `json:"data_key_id,omitempty"`
+}
+
+// ComplianceReport is an inventory of the keys in a store for security
+// compliance evidence.
+type ComplianceReport struct {
+	Generated time.Time     `json:"generated"`
+	Entries   []ReportEntry `json:"entries"`
+}
+
+// Get the store wrapped by ss, found in its exported Inner field, or nil.
+func innerStore(ss SecureStorage) SecureStorage {
+	if ra, ok := ss.(*ReadOnlyAdapter); ok {
+		return ra.inner
+	}
+	v := reflect.ValueOf(ss)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	f := v.FieldByName("Inner")
+	if !f.IsValid() || !f.CanInterface() {
+		return nil
+	}
+	inner, _ := f.Interface().(SecureStorage)
+	return inner
+}
+
+// Find the adapters in the chain starting at ss that describe a key. The
+// search stops at a PrefixAdapter since keys below it have other names.
+// sealed is the store below the first encrypting adapter, which holds its
+// encrypted entries.
+func reportSources(ss SecureStorage) (md MetadataStore, vs VersionedStore, sealed SecureStorage, backend string) {
+	for s := ss; s != nil; s = innerStore(s) {
+		if m, ok := s.(MetadataStore); ok && md == nil {
+			md = m
+		}
+		if v, ok := s.(VersionedStore); ok && vs == nil {
+			vs = v
+		}
+		if sealed == nil {
+			switch e := s.(type) {
+			case *EncryptedAdapter:
+				sealed = e.Inner
+			case *ShreddingAdapter:
+				sealed = e.Inner
+			}
+		}
+		if ia, ok := s.(*InstrumentedAdapter); ok && backend == "" {
+			backend = ia.Backend
+		}
+		if _, ok := s.(*PrefixAdapter); ok {
+			break
+		}
+		if innerStore(s) == nil && backend == "" {
+			t := reflect.TypeOf(s)
+			if t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			backend = t.Name()
+		}
+	}
+	return md, vs, sealed, backend
+}
+
+// Build a ComplianceReport of every key below keyPath. Key metadata,
+// version counts and encryption parameters are collected from any
+// MetadataStore, VersionedStore, EncryptedAdapter and ShreddingAdapter
+// wrapped by ss.
+func Report(ss SecureStorage, keyPath string) (*ComplianceReport, error) {
+	md, vs, sealed, backend := reportSources(ss)
+	report := &ComplianceReport{
+		Generated: time.Now().UTC(),
+		Entries:   []ReportEntry{},
+	}
+	it := IterateKeys(ss, keyPath)
+	defer it.Close()
+	for it.Next() {
+		key := it.Key()
+		entry := ReportEntry{Key: key, Backend: backend, Versions: 1}
+		if md != nil {
+			m, err := md.LookupMetadata(key)
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				return nil, err
+			}
+			if m != nil {
+				entry.CreatedTime = m.CreatedTime
+				entry.LastRotated = m.UpdatedTime
+				entry.Versions = m.Version
+				if len(m.Labels) > 0 {
+					entry.Labels = m.Labels
+				}
+			}
+		}
+		if vs != nil {
+			versions, err := vs.ListVersions(key)
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				return nil, err
+			}
+			if err == nil {
+				entry.Versions = len(versions)
+			}
+		}
+		if sealed != nil {
+			var enc *encryptedEntry
+			err := sealed.Lookup(key, &enc)
+			if err != nil {
+				return nil, err
+			}
+			if enc != nil {
+				entry.Encryption = enc.Alg
+				entry.DataKeyID = enc.KeyID
+			}
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(report.Entries, func(i, j int) bool {
+		return report.Entries[i].Key < report.Entries[j].Key
+	})
+	return report, nil
+}
+
+// Write the report as indented JSON.
+func (cr *ComplianceReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(cr)
+}
+
+// Write the report as CSV with a header row. Labels are written as
+// "name=value" pairs separated by ";" and zero times as empty fields.
+func (cr *ComplianceReport) WriteCSV(w io.Writer) error {
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"key", "backend", "created_time", "last_rotated", "versions", "labels", "encryption", "data_key_id"})
+	for _, e := range cr.Entries {
+		labels := make([]string, 0, len(e.Labels))
+		for k, v := range e.Labels {
+			labels = append(labels, fmt.Sprintf("%s=%s", k, v))
+		}
+		sort.Strings(labels)
+		cw.Write([]string{
+			e.Key,
+			e.Backend,
+			formatTime(e.CreatedTime),
+			formatTime(e.LastRotated),
+			strconv.Itoa(e.Versions),
+			strings.Join(labels, ";"),
+			e.Encryption,
+			e.DataKeyID,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,102 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	key, _ := GenerateMasterKey()
+	kp, err := NewStaticKeyProvider(key)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	ma := NewMetadataAdapter(NewVersionedAdapter(newMemStore(), 0))
+	ss := Encrypted(Instrumented(ma, NewMetrics(nil), "vault"), kp)
+	ss.Store("bmc/x0c0s1b0", creds{Username: "root", Password: "pw0"})
+	ss.Store("bmc/x0c0s1b0", creds{Username: "root", Password: "pw1"})
+	ss.Store("bmc/x0c0s2b0", creds{Username: "root", Password: "pw0"})
+	ma.SetLabels("bmc/x0c0s2b0", map[string]string{"owner": "hms", "class": "bmc"})
+	plain := newMemStore()
+	plain.Store("bmc/x0c0s1b0", creds{Username: "root", Password: "pw0"})
+
+	var tests = []struct {
+		ss       SecureStorage
+		backend  string
+		versions []int
+		enc      string
+		created  bool
+	}{
+		{ss: ss, backend: "vault", versions: []int{2, 2}, enc: AlgAES256GCM, created: true},
+		{ss: ma, backend: "memStore", versions: []int{2, 2}, created: true},
+		{ss: ReadOnly(plain), backend: "memStore", versions: []int{1}},
+	}
+
+	for i, test := range tests {
+		report, err := Report(test.ss, "bmc")
+		if err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+			continue
+		}
+		if len(report.Entries) != len(test.versions) {
+			t.Errorf("Test %v Failed: Expected %v entries but got %+v", i, len(test.versions), report.Entries)
+			continue
+		}
+		for j, e := range report.Entries {
+			if e.Backend != test.backend || e.Versions != test.versions[j] || e.Encryption != test.enc ||
+				e.CreatedTime.IsZero() == test.created {
+				t.Errorf("Test %v Failed: Unexpected entry %+v", i, e)
+			}
+		}
+	}
+
+	report, err := Report(ss, "bmc")
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	if strings.Contains(buf.String(), "pw0") || strings.Contains(buf.String(), "ciphertext") {
+		t.Errorf("Report must not contain values: %s", buf.String())
+	}
+	var decoded ComplianceReport
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || len(decoded.Entries) != 2 ||
+		decoded.Entries[1].Labels["owner"] != "hms" {
+		t.Errorf("Unexpected JSON report %s (%v)", buf.String(), err)
+	}
+
+	buf.Reset()
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "key,backend,") ||
+		!strings.HasPrefix(lines[2], "bmc/x0c0s2b0,vault,") || !strings.Contains(lines[2], ",2,class=bmc;owner=hms,AES-256-GCM,") {
+		t.Errorf("Unexpected CSV report %q", lines)
+	}
+}
@@ -0,0 +1,160 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryAdapter retries failed operations on the wrapped SecureStorage, for
+// backends that have no retries of their own. IsRetryable decides whether
+// an error is worth retrying and Backoff gives the delay before each retry
+// (attempt starts at 1 for the first retry).
+type RetryAdapter struct {
+	Inner       SecureStorage
+	MaxAttempts int
+	IsRetryable func(err error) bool
+	Backoff     func(attempt int) time.Duration
+	sleep       func(time.Duration)
+}
+
+// Create a new RetryAdapter making at most maxAttempts attempts per
+// operation, using DefaultIsRetryable and an exponential backoff from
+// 100ms up to 5s.
+func NewRetryAdapter(inner SecureStorage, maxAttempts int) *RetryAdapter {
+	return &RetryAdapter{
+		Inner:       inner,
+		MaxAttempts: maxAttempts,
+		IsRetryable: DefaultIsRetryable,
+		Backoff:     ExponentialBackoff(100*time.Millisecond, 5*time.Second),
+		sleep:       time.Sleep,
+	}
+}
+
+// Get a backoff policy that doubles the delay for every retry, starting at
+// base and never exceeding max.
+func ExponentialBackoff(base time.Duration, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		delay := base
+		for i := 1; i < attempt && delay < max; i++ {
+			delay *= 2
+		}
+		if delay > max {
+			delay = max
+		}
+		return delay
+	}
+}
+
+// Get a backoff policy that always waits delay.
+func ConstantBackoff(delay time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		return delay
+	}
+}
+
+// DefaultIsRetryable treats network timeouts, refused or reset
+// connections, interrupted reads, and HTTP 429/5xx responses as transient.
+// Errors from this package such as ErrNotFound and ErrReadOnly are not
+// retried.
+func DefaultIsRetryable(err error) bool {
+	if err == nil || errors.Is(err, ErrNotFound) || errors.Is(err, ErrReadOnly) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EAGAIN) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	lowerErrorString := strings.ToLower(err.Error())
+	for _, s := range []string{
+		"code: 429", "code: 500", "code: 502", "code: 503", "code: 504",
+		"connection refused", "connection reset", "i/o timeout",
+	} {
+		if strings.Contains(lowerErrorString, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ra *RetryAdapter) do(op func() error) error {
+	err := op()
+	for attempt := 1; err != nil && attempt < ra.MaxAttempts && ra.IsRetryable(err); attempt++ {
+		ra.sleep(ra.Backoff(attempt))
+		err = op()
+	}
+	return err
+}
+
+func (ra *RetryAdapter) Store(key string, value interface{}) error {
+	return ra.do(func() error {
+		return ra.Inner.Store(key, value)
+	})
+}
+
+func (ra *RetryAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	return ra.do(func() error {
+		return ra.Inner.StoreWithData(key, value, output)
+	})
+}
+
+func (ra *RetryAdapter) Lookup(key string, output interface{}) error {
+	return ra.do(func() error {
+		return ra.Inner.Lookup(key, output)
+	})
+}
+
+func (ra *RetryAdapter) Delete(key string) error {
+	return ra.do(func() error {
+		return ra.Inner.Delete(key)
+	})
+}
+
+func (ra *RetryAdapter) LookupKeys(keyPath string) ([]string, error) {
+	var klist []string
+
+	err := ra.do(func() error {
+		var err error
+		klist, err = ra.Inner.LookupKeys(keyPath)
+		return err
+	})
+	return klist, err
+}
+
+func (ra *RetryAdapter) Close() error {
+	return Close(ra.Inner)
+}
+
+func (ra *RetryAdapter) Health() error {
+	return Health(ra.Inner)
+}
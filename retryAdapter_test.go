@@ -0,0 +1,76 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRetryAdapter(t *testing.T) {
+	var tests = []struct {
+		err      error
+		failures int
+		attempts int
+		delays   []time.Duration
+		respErr  bool
+	}{
+		{err: fmt.Errorf("Code: 503"), failures: 0, attempts: 3, delays: []time.Duration{}},
+		{err: fmt.Errorf("Code: 503"), failures: 2, attempts: 3, delays: []time.Duration{10 * time.Millisecond, 20 * time.Millisecond}},
+		{err: fmt.Errorf("Code: 503"), failures: 3, attempts: 3, delays: []time.Duration{10 * time.Millisecond, 20 * time.Millisecond}, respErr: true},
+		{err: fmt.Errorf("Code: 400"), failures: 1, attempts: 3, delays: []time.Duration{}, respErr: true},
+		{err: fmt.Errorf("%w: x", ErrNotFound), failures: 1, attempts: 3, delays: []time.Duration{}, respErr: true},
+	}
+
+	for i, test := range tests {
+		fs := &flakyStore{memStore: newMemStore(), failures: test.failures}
+		ra := NewRetryAdapter(fs, test.attempts)
+		ra.Backoff = ExponentialBackoff(10*time.Millisecond, 15*time.Second)
+		delays := []time.Duration{}
+		ra.sleep = func(d time.Duration) {
+			delays = append(delays, d)
+		}
+		ra.IsRetryable = func(err error) bool {
+			return DefaultIsRetryable(test.err)
+		}
+		err := ra.Store("x0c0s1b0", creds{Username: "root"})
+		if (err != nil) != test.respErr {
+			t.Errorf("Test %v Failed: Unexpected error result - %v", i, err)
+		}
+		if !reflect.DeepEqual(delays, test.delays) {
+			t.Errorf("Test %v Failed: Expected delays %v but got %v", i, test.delays, delays)
+		}
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second)
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for i, delay := range expected {
+		if d := backoff(i + 1); d != delay {
+			t.Errorf("Test %v Failed: Expected delay %v but got %v", i, delay, d)
+		}
+	}
+}
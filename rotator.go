@@ -0,0 +1,387 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotationGenerator produces the new value for key. current is the value
+// being replaced, or nil if the key does not exist yet.
+type RotationGenerator func(key string, current map[string]interface{}) (interface{}, error)
+
+// DefaultPasswordCharset is used by RandomPasswordGenerator() when no
+// charset is given.
+const DefaultPasswordCharset = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_.+!#%"
+
+// RandomPasswordGenerator returns a RotationGenerator that replaces field
+// with a random string of length characters drawn from charset, keeping
+// the other fields of the current value. The characters are read from the
+// entropy source.
+func RandomPasswordGenerator(field string, length int, charset string) RotationGenerator {
+	if charset == "" {
+		charset = DefaultPasswordCharset
+	}
+	return func(key string, current map[string]interface{}) (interface{}, error) {
+		if length <= 0 {
+			return nil, fmt.Errorf("Password length for %s must be positive", key)
+		}
+		max := big.NewInt(int64(len(charset)))
+		pw := make([]byte, length)
+		for i := range pw {
+			n, err := rand.Int(entropyReader{}, max)
+			if err != nil {
+				return nil, err
+			}
+			pw[i] = charset[n.Int64()]
+		}
+		value := make(map[string]interface{}, len(current)+1)
+		for k, v := range current {
+			value[k] = v
+		}
+		value[field] = string(pw)
+		return value, nil
+	}
+}
+
+// ErrPartialRotation is returned (wrapped) by RotateKey() when a hook
+// fails after earlier hooks have applied the new value. The new value is
+// kept, as it is already in use.
+var ErrPartialRotation = errors.New("rotation partially applied")
+
+// RotationHook is called after a new value has been written for key, for
+// example to push a new password to a BMC. If the first hook fails the
+// previous value is restored, or a key that did not exist is deleted. If
+// a later hook fails the new value is kept and ErrPartialRotation is
+// returned.
+type RotationHook func(key string, value interface{}) error
+
+// RotationPolicy rotates a single key, or every key below a prefix when Key
+// ends in "/", every Interval.
+type RotationPolicy struct {
+	Key      string
+	Interval time.Duration
+	Generate RotationGenerator
+	Hooks    []RotationHook
+}
+
+// RotationStatus tracks the rotation of one key.
+type RotationStatus struct {
+	Key          string
+	LastRotated  time.Time
+	NextRotation time.Time
+	Rotations    int
+	LastError    string
+}
+
+// Rotator rotates secrets according to registered policies. RunOnce()
+// rotates every key that is due; Start() does so periodically. A key's
+// schedule starts from its last update time when the store implements
+// MetadataStore, and from when the Rotator first sees it otherwise.
+type Rotator struct {
+	Store SecureStorage
+
+	mu       sync.Mutex
+	policies []*RotationPolicy
+	status   map[string]*RotationStatus
+	now      func() time.Time
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// Create a new Rotator for store.
+func NewRotator(store SecureStorage) *Rotator {
+	return &Rotator{
+		Store:  store,
+		status: map[string]*RotationStatus{},
+		now:    time.Now,
+	}
+}
+
+// Add a rotation policy.
+func (r *Rotator) Register(policy RotationPolicy) error {
+	if policy.Key == "" {
+		return fmt.Errorf("Rotation policy key must not be empty")
+	}
+	if policy.Interval <= 0 {
+		return fmt.Errorf("Rotation interval for %s must be positive", policy.Key)
+	}
+	if policy.Generate == nil {
+		return fmt.Errorf("Rotation policy for %s has no generator", policy.Key)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policies = append(r.policies, &policy)
+	return nil
+}
+
+// Find the policy for key. An exact key match wins over the longest
+// matching prefix.
+func (r *Rotator) policyFor(key string) *RotationPolicy {
+	var best *RotationPolicy
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range r.policies {
+		if p.Key == key {
+			return p
+		}
+		if strings.HasSuffix(p.Key, "/") && strings.HasPrefix(key, p.Key) &&
+			(best == nil || len(p.Key) > len(best.Key)) {
+			best = p
+		}
+	}
+	return best
+}
+
+// Get the keys covered by the registered policies.
+func (r *Rotator) keys() ([]string, error) {
+	r.mu.Lock()
+	policies := append([]*RotationPolicy(nil), r.policies...)
+	r.mu.Unlock()
+
+	seen := map[string]bool{}
+	for _, p := range policies {
+		if !strings.HasSuffix(p.Key, "/") {
+			seen[p.Key] = true
+			continue
+		}
+		it := IterateKeys(r.Store, p.Key)
+		for it.Next() {
+			seen[it.Key()] = true
+		}
+		err := it.Err()
+		it.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Get the status for key, creating it if needed. Must be called without
+// r.mu held, as creating it reads the key's metadata from the store.
+func (r *Rotator) statusFor(key string, interval time.Duration) *RotationStatus {
+	r.mu.Lock()
+	st, ok := r.status[key]
+	r.mu.Unlock()
+	if ok {
+		return st
+	}
+
+	last := r.now()
+	if ms, ok := r.Store.(MetadataStore); ok {
+		if md, err := ms.LookupMetadata(key); err == nil {
+			last = md.UpdatedTime
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if st, ok := r.status[key]; ok {
+		return st
+	}
+	st = &RotationStatus{
+		Key:          key,
+		LastRotated:  last,
+		NextRotation: last.Add(interval),
+	}
+	r.status[key] = st
+	return st
+}
+
+// Write a new value for key and run the policy's hooks.
+func (r *Rotator) rotate(key string, p *RotationPolicy, current map[string]interface{}) error {
+	value, err := p.Generate(key, current)
+	if err != nil {
+		return err
+	}
+	err = r.Store.Store(key, value)
+	if err != nil {
+		return err
+	}
+	for i, hook := range p.Hooks {
+		err = hook(key, value)
+		if err == nil {
+			continue
+		}
+		if i > 0 {
+			return fmt.Errorf("%w: hook %d of %d failed and the new value is kept: %v",
+				ErrPartialRotation, i+1, len(p.Hooks), err)
+		}
+		var rerr error
+		if current != nil {
+			rerr = r.Store.Store(key, current)
+		} else {
+			rerr = r.Store.Delete(key)
+		}
+		if rerr != nil {
+			return fmt.Errorf("%w; restoring previous value failed: %v", err, rerr)
+		}
+		return err
+	}
+	return nil
+}
+
+// Rotate key now using its policy, regardless of schedule.
+func (r *Rotator) RotateKey(key string) error {
+	var current map[string]interface{}
+
+	p := r.policyFor(key)
+	if p == nil {
+		return fmt.Errorf("No rotation policy for %s", key)
+	}
+
+	err := r.Store.Lookup(key, &current)
+	if err == nil {
+		err = r.rotate(key, p, current)
+	}
+
+	st := r.statusFor(key, p.Interval)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		st.LastError = err.Error()
+		return fmt.Errorf("Rotation of %s failed: %w", key, err)
+	}
+	st.LastError = ""
+	st.Rotations++
+	st.LastRotated = r.now()
+	st.NextRotation = st.LastRotated.Add(p.Interval)
+	return nil
+}
+
+// Rotate every key whose rotation is due. Every due key is attempted even
+// if some fail; the first error is returned.
+func (r *Rotator) RunOnce() error {
+	var firstErr error
+
+	keys, err := r.keys()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		p := r.policyFor(key)
+		if p == nil {
+			continue
+		}
+		st := r.statusFor(key, p.Interval)
+		r.mu.Lock()
+		due := !r.now().Before(st.NextRotation)
+		r.mu.Unlock()
+		if !due {
+			continue
+		}
+		err = r.RotateKey(key)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Get the status of every key covered by a policy whose rotation is due,
+// without rotating anything. Keys whose last rotation failed stay overdue
+// until a rotation succeeds.
+func (r *Rotator) Overdue() ([]RotationStatus, error) {
+	keys, err := r.keys()
+	if err != nil {
+		return nil, err
+	}
+	overdue := []RotationStatus{}
+	for _, key := range keys {
+		p := r.policyFor(key)
+		if p == nil {
+			continue
+		}
+		st := r.statusFor(key, p.Interval)
+		r.mu.Lock()
+		if !r.now().Before(st.NextRotation) {
+			overdue = append(overdue, *st)
+		}
+		r.mu.Unlock()
+	}
+	return overdue, nil
+}
+
+// Get the rotation status of every key seen so far, sorted by key.
+func (r *Rotator) Status() []RotationStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	status := make([]RotationStatus, 0, len(r.status))
+	for _, st := range r.status {
+		status = append(status, *st)
+	}
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Key < status[j].Key
+	})
+	return status
+}
+
+// Call RunOnce() every checkInterval until Stop() is called. Errors are
+// recorded in the status of the affected keys.
+func (r *Rotator) Start(checkInterval time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stop != nil {
+		return
+	}
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+	go func(stop chan struct{}, done chan struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+		for {
+			r.RunOnce()
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}(r.stop, r.done)
+}
+
+// Stop a Rotator started with Start() and wait for it to finish.
+func (r *Rotator) Stop() {
+	r.mu.Lock()
+	stop, done := r.stop, r.done
+	r.stop, r.done = nil, nil
+	r.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
@@ -0,0 +1,245 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotator(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	ms := newMemStore()
+	ms.Store("bmc/x0c0s1b0", creds{Username: "root", Password: "pw0"})
+	ms.Store("bmc/x0c0s2b0", creds{Username: "root", Password: "pw0"})
+	ms.Store("switch", creds{Username: "admin", Password: "pw0"})
+
+	gen := 0
+	generate := func(key string, current map[string]interface{}) (interface{}, error) {
+		gen++
+		return creds{Username: current["Username"].(string), Password: fmt.Sprintf("pw%d", gen)}, nil
+	}
+	hookFail := false
+	hooked := []string{}
+	r := NewRotator(ms)
+	r.now = func() time.Time { return now }
+	if err := r.Register(RotationPolicy{
+		Key:      "bmc/",
+		Interval: time.Hour,
+		Generate: generate,
+		Hooks: []RotationHook{func(key string, value interface{}) error {
+			if hookFail {
+				return fmt.Errorf("BMC unreachable")
+			}
+			hooked = append(hooked, key)
+			return nil
+		}},
+	}); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	if err := r.Register(RotationPolicy{Key: "switch", Interval: 24 * time.Hour, Generate: generate}); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	if err := r.Register(RotationPolicy{Key: "bad", Interval: 0, Generate: generate}); err == nil {
+		t.Errorf("Expected an error for a zero interval")
+	}
+
+	var tests = []struct {
+		advance  time.Duration
+		hookFail bool
+		respErr  bool
+		switchPw string
+		bmcPw    string
+		hooked   int
+	}{
+		{advance: 0, switchPw: "pw0", bmcPw: "pw0", hooked: 0},
+		{advance: time.Hour, switchPw: "pw0", bmcPw: "pw1", hooked: 2},
+		{advance: 30 * time.Minute, switchPw: "pw0", bmcPw: "pw1", hooked: 2},
+		{advance: 30 * time.Minute, hookFail: true, respErr: true, switchPw: "pw0", bmcPw: "pw1", hooked: 2},
+		{advance: 23 * time.Hour, switchPw: "pw7", bmcPw: "pw5", hooked: 4},
+	}
+
+	for i, test := range tests {
+		now = now.Add(test.advance)
+		hookFail = test.hookFail
+		err := r.RunOnce()
+		if (err != nil) != test.respErr {
+			t.Errorf("Test %v Failed: Unexpected error result - %v", i, err)
+		}
+		var sw, bmc creds
+		ms.Lookup("switch", &sw)
+		ms.Lookup("bmc/x0c0s1b0", &bmc)
+		if sw.Password != test.switchPw || bmc.Password != test.bmcPw {
+			t.Errorf("Test %v Failed: Expected passwords %v/%v but got %v/%v", i, test.switchPw, test.bmcPw, sw.Password, bmc.Password)
+		}
+		if len(hooked) != test.hooked {
+			t.Errorf("Test %v Failed: Expected %v hook calls but got %v", i, test.hooked, len(hooked))
+		}
+	}
+
+	status := r.Status()
+	if len(status) != 3 || status[0].Key != "bmc/x0c0s1b0" || status[0].Rotations != 2 || status[0].LastError != "" {
+		t.Errorf("Unexpected status %+v", status)
+	}
+}
+
+func TestRotatorHookFailure(t *testing.T) {
+	var tests = []struct {
+		existing bool
+		failHook int
+		partial  bool
+		expPw    string
+	}{
+		{existing: true, failHook: 0, expPw: "pw0"},
+		{existing: true, failHook: 1, partial: true, expPw: "new"},
+		{existing: false, failHook: 0, expPw: ""},
+		{existing: false, failHook: 1, partial: true, expPw: "new"},
+	}
+
+	for i, test := range tests {
+		ms := newMemStore()
+		if test.existing {
+			ms.Store("bmc", creds{Username: "root", Password: "pw0"})
+		}
+		hook := func(n int) RotationHook {
+			return func(key string, value interface{}) error {
+				if n == test.failHook {
+					return fmt.Errorf("hook %d failed", n)
+				}
+				return nil
+			}
+		}
+		r := NewRotator(ms)
+		r.Register(RotationPolicy{
+			Key:      "bmc",
+			Interval: time.Hour,
+			Generate: func(key string, current map[string]interface{}) (interface{}, error) {
+				return creds{Username: "root", Password: "new"}, nil
+			},
+			Hooks: []RotationHook{hook(0), hook(1)},
+		})
+		err := r.RotateKey("bmc")
+		if err == nil || errors.Is(err, ErrPartialRotation) != test.partial {
+			t.Errorf("Test %v Failed: Unexpected error result - %v", i, err)
+		}
+		var c creds
+		ms.Lookup("bmc", &c)
+		if c.Password != test.expPw {
+			t.Errorf("Test %v Failed: Expected password %q but got %q", i, test.expPw, c.Password)
+		}
+		if _, ok := ms.data["bmc"]; ok != (test.expPw != "") {
+			t.Errorf("Test %v Failed: Unexpected presence of key - %v", i, ok)
+		}
+	}
+}
+
+func TestRotatorOverdue(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	ms := newMemStore()
+	ms.Store("bmc/x0c0s1b0", creds{Username: "root", Password: "pw0"})
+	ms.Store("bmc/x0c0s2b0", creds{Username: "root", Password: "pw0"})
+	ms.Store("switch", creds{Username: "admin", Password: "pw0"})
+
+	r := NewRotator(ms)
+	r.now = func() time.Time { return now }
+	r.Register(RotationPolicy{Key: "bmc/", Interval: time.Hour, Generate: RandomPasswordGenerator("Password", 16, "")})
+	r.Register(RotationPolicy{Key: "switch", Interval: 24 * time.Hour, Generate: RandomPasswordGenerator("Password", 16, "")})
+
+	var tests = []struct {
+		advance time.Duration
+		rotate  bool
+		overdue []string
+	}{
+		{advance: 0, overdue: []string{}},
+		{advance: time.Hour, overdue: []string{"bmc/x0c0s1b0", "bmc/x0c0s2b0"}},
+		{advance: 23 * time.Hour, overdue: []string{"bmc/x0c0s1b0", "bmc/x0c0s2b0", "switch"}},
+		{advance: 0, rotate: true, overdue: []string{}},
+	}
+
+	for i, test := range tests {
+		now = now.Add(test.advance)
+		if test.rotate {
+			if err := r.RunOnce(); err != nil {
+				t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+			}
+		}
+		overdue, err := r.Overdue()
+		if err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+			continue
+		}
+		keys := []string{}
+		for _, st := range overdue {
+			keys = append(keys, st.Key)
+		}
+		if fmt.Sprint(keys) != fmt.Sprint(test.overdue) {
+			t.Errorf("Test %v Failed: Expected overdue keys %v but got %v", i, test.overdue, keys)
+		}
+	}
+
+	var c creds
+	ms.Lookup("switch", &c)
+	if c.Username != "admin" || len(c.Password) != 16 || c.Password == "pw0" {
+		t.Errorf("Unexpected rotated value %+v", c)
+	}
+}
+
+func TestRandomPasswordGenerator(t *testing.T) {
+	var tests = []struct {
+		length  int
+		charset string
+		respErr bool
+	}{
+		{length: 24},
+		{length: 8, charset: "ab"},
+		{length: 0, respErr: true},
+	}
+
+	for i, test := range tests {
+		value, err := RandomPasswordGenerator("Password", test.length, test.charset)("k", map[string]interface{}{"Username": "root"})
+		if (err != nil) != test.respErr {
+			t.Errorf("Test %v Failed: Unexpected error result - %v", i, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		m := value.(map[string]interface{})
+		pw, _ := m["Password"].(string)
+		charset := test.charset
+		if charset == "" {
+			charset = DefaultPasswordCharset
+		}
+		if m["Username"] != "root" || len(pw) != test.length {
+			t.Errorf("Test %v Failed: Unexpected value %v", i, m)
+		}
+		for _, c := range pw {
+			if !strings.ContainsRune(charset, c) {
+				t.Errorf("Test %v Failed: Unexpected character %q", i, c)
+			}
+		}
+	}
+}
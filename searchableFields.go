@@ -0,0 +1,137 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+const (
+	searchKeyPurpose = "securestorage searchable "
+	searchIVPurpose  = "securestorage searchable iv "
+)
+
+// Seal value deterministically for field: the nonce is a MAC of the value,
+// as in SIV mode, so equal values always give the same token.
+func searchToken(provider CryptoProvider, master []byte, field string, value interface{}) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	key, err := provider.DeriveKey(master, searchKeyPurpose+field)
+	if err != nil {
+		return "", err
+	}
+	ivKey, err := provider.DeriveKey(master, searchIVPurpose+field)
+	if err != nil {
+		return "", err
+	}
+	aead, err := provider.NewAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	iv, err := provider.MAC(ivKey, encoded)
+	if err != nil {
+		return "", err
+	}
+	if len(iv) < aead.NonceSize() {
+		return "", fmt.Errorf("MAC is too short for a %d byte nonce", aead.NonceSize())
+	}
+	nonce := iv[:aead.NonceSize()]
+	sealed := aead.Seal(nonce, nonce, encoded, []byte(field))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Get the search tokens for the SearchableFields present in data, or nil
+// if there are none.
+func (ea *EncryptedAdapter) searchTokens(provider CryptoProvider, master []byte, data map[string]interface{}) (map[string]string, error) {
+	var tokens map[string]string
+
+	for _, field := range ea.SearchableFields {
+		value, ok := data[field]
+		if !ok {
+			continue
+		}
+		token, err := searchToken(provider, master, field, value)
+		if err != nil {
+			return nil, err
+		}
+		if tokens == nil {
+			tokens = map[string]string{}
+		}
+		tokens[field] = token
+	}
+	return tokens, nil
+}
+
+// Find the keys below keyPath whose field equals value, sorted, without
+// decrypting any values. field must be one of SearchableFields, and only values
+// written since it was added can be found.
+//
+// Searchable fields are sealed deterministically, so anyone who can read
+// the backend can tell which keys share the same value for that field, and
+// how many do, though not what the value is. Only make low value fields
+// such as usernames searchable, never passwords. Rotating the master key
+// requires rewriting every value before searches work again.
+func (ea *EncryptedAdapter) FindByField(keyPath string, field string, value interface{}) ([]string, error) {
+	searchable := false
+	for _, f := range ea.SearchableFields {
+		searchable = searchable || f == field
+	}
+	if !searchable {
+		return nil, fmt.Errorf("Field %s is not searchable", field)
+	}
+	provider, err := currentCrypto()
+	if err != nil {
+		return nil, err
+	}
+	masterKey, err := ea.Keys.MasterKey()
+	if err != nil {
+		return nil, err
+	}
+	token, err := searchToken(provider, masterKey, field, value)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	it := IterateKeys(ea.Inner, keyPath)
+	defer it.Close()
+	for it.Next() {
+		var entry *encryptedEntry
+		err := ea.Inner.Lookup(it.Key(), &entry)
+		if err != nil {
+			return nil, err
+		}
+		if entry != nil && entry.Fields[field] == token {
+			keys = append(keys, it.Key())
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
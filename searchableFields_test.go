@@ -0,0 +1,83 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFindByField(t *testing.T) {
+	key, _ := GenerateMasterKey()
+	kp, err := NewStaticKeyProvider(key)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	ms := newMemStore()
+	ea := Encrypted(ms, kp)
+	ea.SearchableFields = []string{"Username", "Port"}
+	ea.Store("bmc/x0c0s1b0", creds{Username: "root", Password: "pw1"})
+	ea.Store("bmc/x0c0s2b0", creds{Username: "admin", Password: "pw2"})
+	ea.Store("bmc/x0c0s3b0", creds{Username: "root", Password: "pw3"})
+	ea.Store("switch/x3000c0w14", map[string]interface{}{"Username": "root", "Port": 22})
+
+	var tests = []struct {
+		keyPath string
+		field   string
+		value   interface{}
+		respErr bool
+		keys    []string
+	}{
+		{keyPath: "bmc", field: "Username", value: "root", keys: []string{"bmc/x0c0s1b0", "bmc/x0c0s3b0"}},
+		{keyPath: "", field: "Username", value: "root", keys: []string{"bmc/x0c0s1b0", "bmc/x0c0s3b0", "switch/x3000c0w14"}},
+		{keyPath: "", field: "Username", value: "admin", keys: []string{"bmc/x0c0s2b0"}},
+		{keyPath: "", field: "Username", value: "nobody", keys: []string{}},
+		{keyPath: "", field: "Port", value: 22, keys: []string{"switch/x3000c0w14"}},
+		{keyPath: "", field: "Password", value: "pw1", respErr: true},
+	}
+
+	for i, test := range tests {
+		keys, err := ea.FindByField(test.keyPath, test.field, test.value)
+		if (err != nil) != test.respErr {
+			t.Errorf("Test %v Failed: Unexpected error result - %v", i, err)
+			continue
+		}
+		if !test.respErr && fmt.Sprint(keys) != fmt.Sprint(test.keys) {
+			t.Errorf("Test %v Failed: Expected keys %v but got %v", i, test.keys, keys)
+		}
+	}
+
+	// Tokens are deterministic per value but reveal nothing in the clear
+	// and the values still decrypt normally.
+	var e1, e3 encryptedEntry
+	ms.Lookup("bmc/x0c0s1b0", &e1)
+	ms.Lookup("bmc/x0c0s3b0", &e3)
+	if e1.Fields["Username"] == "" || e1.Fields["Username"] != e3.Fields["Username"] ||
+		strings.Contains(fmt.Sprint(e1), "root") || e1.Ciphertext == e3.Ciphertext {
+		t.Errorf("Unexpected entries %+v and %+v", e1, e3)
+	}
+	var r creds
+	if err := ea.Lookup("bmc/x0c0s3b0", &r); err != nil || r.Password != "pw3" {
+		t.Errorf("Expected to decrypt the value but got %v (%v)", r, err)
+	}
+}
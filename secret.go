@@ -0,0 +1,152 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"time"
+)
+
+// Lease describes how long a secret remains valid. A zero Duration means
+// the secret does not expire.
+type Lease struct {
+	ID        string
+	Duration  time.Duration
+	Renewable bool
+	Expires   time.Time
+}
+
+// Leaser is implemented by backends that hand out leased secrets, such as
+// Vault dynamic secrets engines.
+type Leaser interface {
+	AcquireLease(key string, output interface{}) (*Lease, error)
+	RenewLease(lease *Lease, increment time.Duration) (*Lease, error)
+	ReleaseLease(lease *Lease) error
+}
+
+// Secret is a handle for a value read with Acquire() together with its
+// lease, whether that lease comes from Vault or from a TTLStore.
+type Secret[T any] struct {
+	Key   string
+	Value T
+	Lease Lease
+
+	store    SecureStorage
+	released bool
+}
+
+// Read key into a new Secret. Leases come from the backend if it
+// implements Leaser, from the key's TTL if it implements TTLStore, and are
+// otherwise empty.
+func Acquire[T any](ss SecureStorage, key string) (*Secret[T], error) {
+	var data map[string]interface{}
+
+	s := &Secret[T]{
+		Key:   key,
+		store: ss,
+	}
+
+	if leaser, ok := ss.(Leaser); ok {
+		lease, err := leaser.AcquireLease(key, &s.Value)
+		if err != nil {
+			return nil, err
+		}
+		s.Lease = *lease
+		return s, nil
+	}
+
+	err := ss.Lookup(key, &data)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	err = decodeValue(data, &s.Value)
+	if err != nil {
+		return nil, err
+	}
+	if ts, ok := ss.(TTLStore); ok {
+		ttl, err := ts.GetTTL(key)
+		if err != nil {
+			return nil, err
+		}
+		if ttl > 0 {
+			s.Lease = Lease{
+				Duration:  ttl,
+				Renewable: true,
+				Expires:   time.Now().Add(ttl),
+			}
+		}
+	}
+	return s, nil
+}
+
+// Check whether the lease has run out. Secrets without a lease never
+// expire.
+func (s *Secret[T]) Expired() bool {
+	return s.released || (s.Lease.Duration > 0 && !time.Now().Before(s.Lease.Expires))
+}
+
+// Extend the lease by increment. For TTLStore backends this resets the
+// key's TTL.
+func (s *Secret[T]) Renew(increment time.Duration) error {
+	if s.released {
+		return fmt.Errorf("Secret %s has been released", s.Key)
+	}
+	if !s.Lease.Renewable {
+		return fmt.Errorf("Secret %s is not renewable", s.Key)
+	}
+
+	if leaser, ok := s.store.(Leaser); ok {
+		lease, err := leaser.RenewLease(&s.Lease, increment)
+		if err != nil {
+			return err
+		}
+		s.Lease = *lease
+		return nil
+	}
+	if ts, ok := s.store.(TTLStore); ok {
+		err := ts.Touch(s.Key, increment)
+		if err != nil {
+			return err
+		}
+		s.Lease.Duration = increment
+		s.Lease.Expires = time.Now().Add(increment)
+		return nil
+	}
+	return fmt.Errorf("Secret %s is not renewable", s.Key)
+}
+
+// Give up the secret. Leases from a Leaser backend are revoked; other
+// secrets are only marked as released.
+func (s *Secret[T]) Release() error {
+	if s.released {
+		return nil
+	}
+	s.released = true
+	if leaser, ok := s.store.(Leaser); ok && s.Lease.ID != "" {
+		return leaser.ReleaseLease(&s.Lease)
+	}
+	return nil
+}
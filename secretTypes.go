@@ -0,0 +1,217 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// Redacted is what SecretString and SecretBytes print as.
+const Redacted = "***"
+
+// SecretString holds a secret that prints, logs and marshals to JSON as
+// Redacted. The real value is only available through Reveal(). Values of
+// this type are written to backends unredacted and can be used as
+// Lookup() output fields.
+type SecretString string
+
+func (s SecretString) Reveal() string {
+	return string(s)
+}
+
+func (s SecretString) String() string {
+	return Redacted
+}
+
+func (s SecretString) GoString() string {
+	return Redacted
+}
+
+func (s SecretString) LogValue() slog.Value {
+	return slog.StringValue(Redacted)
+}
+
+func (s SecretString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Redacted)
+}
+
+// SecretBytes is the []byte counterpart of SecretString. When used as a
+// Lookup() output field it is decoded from the stored string.
+type SecretBytes []byte
+
+func (s SecretBytes) Reveal() []byte {
+	return []byte(s)
+}
+
+// Overwrite the secret with zeros once it is no longer needed.
+func (s SecretBytes) Wipe() {
+	for i := range s {
+		s[i] = 0
+	}
+}
+
+func (s SecretBytes) String() string {
+	return Redacted
+}
+
+func (s SecretBytes) GoString() string {
+	return Redacted
+}
+
+func (s SecretBytes) LogValue() slog.Value {
+	return slog.StringValue(Redacted)
+}
+
+func (s SecretBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Redacted)
+}
+
+var (
+	secretStringType = reflect.TypeOf(SecretString(""))
+	secretBytesType  = reflect.TypeOf(SecretBytes(nil))
+)
+
+// Replace SecretString and SecretBytes values in a decoded value with their
+// plain forms so they are not redacted when the backend marshals them.
+// Secrets are found at any depth, through slices, arrays, pointers, maps
+// and structs. Parts of the value holding secrets are copied, with slices
+// and arrays becoming []interface{}, maps map[string]interface{} and
+// structs maps as decoded by mapstructure, so they decode back into the
+// same types on Lookup(). Everything else is returned as is.
+func revealSecrets(value interface{}) interface{} {
+	return revealValue(reflect.ValueOf(value))
+}
+
+func revealValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	switch v.Type() {
+	case secretStringType:
+		return v.String()
+	case secretBytesType:
+		return string(v.Bytes())
+	}
+	if !holdsSecret(v) {
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		return revealValue(v.Elem())
+	case reflect.Slice, reflect.Array:
+		out := make([]interface{}, v.Len())
+		for i := range out {
+			out[i] = revealValue(v.Index(i))
+		}
+		return out
+	case reflect.Map:
+		out := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = revealValue(iter.Value())
+		}
+		return out
+	case reflect.Struct:
+		var data map[string]interface{}
+		err := mapstructure.Decode(v.Interface(), &data)
+		if err != nil {
+			return v.Interface()
+		}
+		for k, e := range data {
+			data[k] = revealSecrets(e)
+		}
+		return data
+	}
+	return v.Interface()
+}
+
+// Whether v holds a SecretString or SecretBytes at any depth.
+func holdsSecret(v reflect.Value) bool {
+	if !v.IsValid() {
+		return false
+	}
+	t := v.Type()
+	if t == secretStringType || t == secretBytesType {
+		return true
+	}
+	if !mayHoldSecrets(t, map[reflect.Type]bool{}) {
+		return false
+	}
+
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		return !v.IsNil() && holdsSecret(v.Elem())
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if holdsSecret(v.Index(i)) {
+				return true
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if holdsSecret(iter.Value()) {
+				return true
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() && holdsSecret(v.Field(i)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Whether values of type t can hold a SecretString or SecretBytes, so
+// values that cannot, such as a large []byte, are not walked.
+func mayHoldSecrets(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if t == secretStringType || t == secretBytesType {
+		return true
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return mayHoldSecrets(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() && mayHoldSecrets(t.Field(i).Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
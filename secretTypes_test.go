@@ -0,0 +1,160 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+)
+
+type secretCreds struct {
+	Username string
+	Password SecretString
+	Key      SecretBytes
+}
+
+func TestSecretTypesRedaction(t *testing.T) {
+	c := secretCreds{Username: "root", Password: "hunter2", Key: SecretBytes("k3y")}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("creds", "password", c.Password, "key", c.Key)
+	js, _ := json.Marshal(c)
+	for i, s := range []string{
+		fmt.Sprintf("%v", c),
+		fmt.Sprintf("%+v", c),
+		fmt.Sprintf("%#v", c),
+		fmt.Sprintf("%s %q %x", c.Password, c.Password, c.Password),
+		fmt.Sprint(c.Key),
+		string(js),
+		buf.String(),
+	} {
+		if strings.Contains(s, "hunter2") || strings.Contains(s, "k3y") || !strings.Contains(s, Redacted) {
+			t.Errorf("Test %v Failed: Secret leaked or not redacted: %s", i, s)
+		}
+	}
+	if c.Password.Reveal() != "hunter2" || string(c.Key.Reveal()) != "k3y" {
+		t.Errorf("Reveal() returned the wrong values")
+	}
+	c.Key.Wipe()
+	if !bytes.Equal(c.Key, []byte{0, 0, 0}) {
+		t.Errorf("Expected Wipe() to zero the secret but got %v", []byte(c.Key))
+	}
+}
+
+func TestSecretTypesVault(t *testing.T) {
+	ss := &VaultAdapter{
+		BasePath:   "secret/hms-cred",
+		VaultRetry: 1,
+	}
+	var vmock *MockVaultApi
+	ss.Client, vmock = NewMockVaultApi()
+	vmock.WriteData = []MockVWrite{{Output: OutputVWrite{S: &api.Secret{}}}}
+	vmock.ReadData = []MockVRead{
+		{
+			Output: OutputVRead{
+				S: &api.Secret{Data: map[string]interface{}{"Username": "root", "Password": "hunter2", "Key": "k3y"}},
+			},
+		},
+	}
+
+	err := ss.Store("x0c0s1b0", secretCreds{Username: "root", Password: "hunter2", Key: SecretBytes("k3y")})
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	js, _ := json.Marshal(vmock.WriteData[0].Input.Data)
+	if !strings.Contains(string(js), "hunter2") || !strings.Contains(string(js), "k3y") {
+		t.Errorf("Expected the backend to receive unredacted values but got %s", js)
+	}
+
+	var r secretCreds
+	if err := ss.Lookup("x0c0s1b0", &r); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	if r.Password.Reveal() != "hunter2" || string(r.Key.Reveal()) != "k3y" {
+		t.Errorf("Expected secrets to be decoded but got %q %q", r.Password.Reveal(), r.Key.Reveal())
+	}
+}
+
+type nestedSecrets struct {
+	List   []SecretString
+	Array  [2]SecretString
+	Ptr    *secretCreds
+	Nested secretCreds
+	Map    map[string]SecretString
+	Any    []interface{}
+	Plain  []string
+}
+
+func TestSecretTypesNested(t *testing.T) {
+	key, _ := GenerateMasterKey()
+	kp, _ := NewStaticKeyProvider(key)
+	ms := newMemStore()
+	ea := Encrypted(ms, kp)
+
+	var tests = []nestedSecrets{
+		{List: []SecretString{"a", "b"}},
+		{Array: [2]SecretString{"c", "d"}},
+		{Ptr: &secretCreds{Username: "root", Password: "e", Key: SecretBytes("f")}},
+		{Nested: secretCreds{Username: "root", Password: "g", Key: SecretBytes("h")}},
+		{Map: map[string]SecretString{"bmc": "i"}},
+		{Any: []interface{}{"plain", SecretString("j")}},
+		{Plain: []string{"k"}, List: []SecretString{"l"}},
+	}
+
+	for i, test := range tests {
+		data, err := toMap(test)
+		if err != nil {
+			t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		js, _ := json.Marshal(data)
+		if strings.Contains(string(js), Redacted) {
+			t.Errorf("Test %v Failed: Expected secrets to be revealed but got %s", i, js)
+		}
+
+		err = ea.Store("x0c0s1b0", test)
+		if err != nil {
+			t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		var r nestedSecrets
+		err = ea.Lookup("x0c0s1b0", &r)
+		if err != nil {
+			t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		if fmt.Sprintf("%q", revealSecrets(r)) != fmt.Sprintf("%q", revealSecrets(test)) {
+			t.Errorf("Test %v Failed: Expected %q but got %q", i, revealSecrets(test), revealSecrets(r))
+		}
+	}
+
+	// The caller's value is not modified.
+	value := map[string]interface{}{"Password": SecretString("m")}
+	toMap(value)
+	if _, ok := value["Password"].(SecretString); !ok {
+		t.Errorf("Expected the caller's map to keep its SecretString")
+	}
+}
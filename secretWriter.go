@@ -0,0 +1,234 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// SecretFile selects a value to be written to a file by SecretWriter.
+type SecretFile struct {
+	Key string
+	// If set, the file holds only this field of the value, otherwise the
+	// whole value as JSON.
+	Field string
+	// File name template, given .Key, .Base (the last element of the key)
+	// and .Field. Defaults to the last element of the key.
+	Name string
+	// Defaults to 0400.
+	Mode os.FileMode
+}
+
+// SecretWriter writes selected values from a store into files in a
+// directory, for applications that can only read secrets from disk. Files
+// are replaced atomically, and only when their contents change. Start()
+// keeps the files up to date by polling the store.
+//
+// With RequireTmpfs set, nothing is written unless Dir is on a tmpfs, so
+// secrets never reach a persistent disk.
+type SecretWriter struct {
+	Store        SecureStorage
+	Dir          string
+	Files        []SecretFile
+	RequireTmpfs bool
+	// Called for errors in the background refresh.
+	OnError func(err error)
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Create a new SecretWriter writing files into dir.
+func NewSecretWriter(store SecureStorage, dir string, files ...SecretFile) *SecretWriter {
+	return &SecretWriter{
+		Store: store,
+		Dir:   dir,
+		Files: files,
+	}
+}
+
+// Get the name of the file for a SecretFile.
+func (sw *SecretWriter) fileName(sf SecretFile) (string, error) {
+	if sf.Name == "" {
+		return path.Base(sf.Key), nil
+	}
+	tmpl, err := template.New(sf.Key).Option("missingkey=error").Parse(sf.Name)
+	if err != nil {
+		return "", fmt.Errorf("Invalid file name template for %s: %v", sf.Key, err)
+	}
+	var name bytes.Buffer
+	err = tmpl.Execute(&name, map[string]string{
+		"Key":   sf.Key,
+		"Base":  path.Base(sf.Key),
+		"Field": sf.Field,
+	})
+	if err != nil {
+		return "", fmt.Errorf("Invalid file name template for %s: %v", sf.Key, err)
+	}
+	if name.Len() == 0 || strings.ContainsAny(name.String(), "/\\") || name.String() == ".." {
+		return "", fmt.Errorf("Invalid file name %q for %s", name.String(), sf.Key)
+	}
+	return name.String(), nil
+}
+
+func (sw *SecretWriter) contents(sf SecretFile) ([]byte, error) {
+	var data map[string]interface{}
+
+	err := sw.Store.Lookup(sf.Key, &data)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, sf.Key)
+	}
+	if sf.Field == "" {
+		return json.Marshal(data)
+	}
+	value, ok := data[sf.Field]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s has no field %s", ErrNotFound, sf.Key, sf.Field)
+	}
+	if s, ok := value.(string); ok {
+		return []byte(s), nil
+	}
+	return json.Marshal(value)
+}
+
+// Replace a file atomically by writing a temporary file next to it and
+// renaming it into place.
+func writeFileAtomic(name string, contents []byte, mode os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(contents)
+	if err == nil {
+		err = tmp.Chmod(mode)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// Write every file whose contents have changed. All files are attempted;
+// the first error is returned.
+func (sw *SecretWriter) WriteOnce() error {
+	if sw.RequireTmpfs {
+		tmpfs, err := isTmpfs(sw.Dir)
+		if err != nil {
+			return err
+		}
+		if !tmpfs {
+			return fmt.Errorf("Refusing to write secrets to %s: not a tmpfs", sw.Dir)
+		}
+	}
+	var firstErr error
+	for _, sf := range sw.Files {
+		err := sw.writeFile(sf)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (sw *SecretWriter) writeFile(sf SecretFile) error {
+	name, err := sw.fileName(sf)
+	if err != nil {
+		return err
+	}
+	contents, err := sw.contents(sf)
+	if err != nil {
+		return err
+	}
+	mode := sf.Mode
+	if mode == 0 {
+		mode = 0400
+	}
+	name = filepath.Join(sw.Dir, name)
+	current, err := os.ReadFile(name)
+	if err == nil && bytes.Equal(current, contents) {
+		if info, err := os.Stat(name); err == nil && info.Mode().Perm() == mode.Perm() {
+			return nil
+		}
+	}
+	return writeFileAtomic(name, contents, mode)
+}
+
+// Call WriteOnce() every interval until Stop() is called. Errors are passed
+// to OnError.
+func (sw *SecretWriter) Start(interval time.Duration) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if sw.stop != nil {
+		return
+	}
+	sw.stop = make(chan struct{})
+	sw.done = make(chan struct{})
+	go func(stop chan struct{}, done chan struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			err := sw.WriteOnce()
+			if err != nil && sw.OnError != nil {
+				sw.OnError(err)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}(sw.stop, sw.done)
+}
+
+// Stop a SecretWriter started with Start() and wait for it to finish.
+func (sw *SecretWriter) Stop() {
+	sw.mu.Lock()
+	stop, done := sw.stop, sw.done
+	sw.stop, sw.done = nil, nil
+	sw.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
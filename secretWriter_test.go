@@ -0,0 +1,95 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSecretWriter(t *testing.T) {
+	dir := t.TempDir()
+	store := newMemStore()
+	store.Store("hms-creds/x0c0s1b0", creds{Username: "root", Password: "pw1"})
+	store.Store("tls/server", map[string]string{"cert": "CERT", "key": "KEY"})
+
+	sw := NewSecretWriter(store, dir,
+		SecretFile{Key: "hms-creds/x0c0s1b0"},
+		SecretFile{Key: "tls/server", Field: "key", Name: "{{.Base}}.{{.Field}}", Mode: 0600},
+	)
+	if err := sw.WriteOnce(); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+
+	var tests = []struct {
+		name     string
+		contents string
+		mode     os.FileMode
+	}{
+		{name: "x0c0s1b0", contents: `{"Password":"pw1","URL":"","Username":"root","Xname":""}`, mode: 0400},
+		{name: "server.key", contents: "KEY", mode: 0600},
+	}
+	for i, test := range tests {
+		contents, err := os.ReadFile(filepath.Join(dir, test.name))
+		if err != nil || string(contents) != test.contents {
+			t.Errorf("Test %v Failed: Expected %q but got %q (%v)", i, test.contents, contents, err)
+		}
+		info, _ := os.Stat(filepath.Join(dir, test.name))
+		if info == nil || info.Mode().Perm() != test.mode {
+			t.Errorf("Test %v Failed: Expected mode %v but got %v", i, test.mode, info)
+		}
+	}
+
+	// The background refresh picks up changes.
+	store.Store("tls/server", map[string]string{"cert": "CERT", "key": "NEWKEY"})
+	errs := make(chan error, 10)
+	sw.OnError = func(err error) { errs <- err }
+	sw.Start(time.Millisecond)
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if contents, _ := os.ReadFile(filepath.Join(dir, "server.key")); string(contents) == "NEWKEY" {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	sw.Stop()
+	if contents, _ := os.ReadFile(filepath.Join(dir, "server.key")); string(contents) != "NEWKEY" {
+		t.Errorf("Expected the file to be refreshed but got %q", contents)
+	}
+	select {
+	case err := <-errs:
+		t.Errorf("Unexpected error - %v", err)
+	default:
+	}
+
+	bad := NewSecretWriter(store, dir, SecretFile{Key: "tls/server", Name: "../{{.Base}}"})
+	if err := bad.WriteOnce(); err == nil {
+		t.Errorf("Expected an error for a file name outside the directory")
+	}
+	missing := NewSecretWriter(store, dir, SecretFile{Key: "tls/client"})
+	if err := missing.WriteOnce(); err == nil {
+		t.Errorf("Expected an error for a missing key")
+	}
+}
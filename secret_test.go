@@ -0,0 +1,119 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+)
+
+func TestAcquireVaultLease(t *testing.T) {
+	ss := &VaultAdapter{
+		BasePath:   "database",
+		VaultRetry: 1,
+	}
+	ss.AuthConfig = &AuthConfig{
+		JWTFile:  "token",
+		RoleFile: "namespace",
+		Path:     "auth/kubernetes/login",
+	}
+	var vmock *MockVaultApi
+	ss.Client, vmock = NewMockVaultApi()
+	vmock.ReadData = []MockVRead{
+		{
+			Output: OutputVRead{
+				S: &api.Secret{
+					LeaseID:       "database/creds/hms/abc",
+					LeaseDuration: 3600,
+					Renewable:     true,
+					Data:          map[string]interface{}{"Username": "v-hms", "Password": "xyz"},
+				},
+			},
+		},
+	}
+	vmock.WriteData = []MockVWrite{
+		{Output: OutputVWrite{S: &api.Secret{LeaseID: "database/creds/hms/abc", LeaseDuration: 7200, Renewable: true}}},
+		{Output: OutputVWrite{S: &api.Secret{}}},
+	}
+
+	s, err := Acquire[creds](ss, "creds/hms")
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	if vmock.ReadData[0].Input.Path != "database/creds/hms" {
+		t.Errorf("Expected Read path database/creds/hms but got %v", vmock.ReadData[0].Input.Path)
+	}
+	if s.Value.Username != "v-hms" || s.Lease.ID != "database/creds/hms/abc" || s.Lease.Duration != time.Hour || s.Expired() {
+		t.Errorf("Unexpected secret %+v", s)
+	}
+	if err := s.Renew(2 * time.Hour); err != nil {
+		t.Errorf("Unexpected error renewing - %v", err)
+	}
+	if vmock.WriteData[0].Input.Path != "sys/leases/renew" || vmock.WriteData[0].Input.Data["increment"] != 7200 {
+		t.Errorf("Unexpected renew request %+v", vmock.WriteData[0].Input)
+	}
+	if s.Lease.Duration != 2*time.Hour {
+		t.Errorf("Expected renewed lease of 2h but got %v", s.Lease.Duration)
+	}
+	if err := s.Release(); err != nil {
+		t.Errorf("Unexpected error releasing - %v", err)
+	}
+	if vmock.WriteData[1].Input.Path != "sys/leases/revoke" || !s.Expired() {
+		t.Errorf("Expected lease to be revoked but got %+v", vmock.WriteData[1].Input)
+	}
+}
+
+func TestAcquireTTL(t *testing.T) {
+	ta := NewTTLAdapter(newMemStore())
+	ta.StoreWithTTL("token", creds{Username: "svc", Password: "tok"}, time.Minute)
+	ta.Store("static", creds{Username: "root", Password: "pw"})
+
+	s, err := Acquire[creds](ta, "token")
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	if s.Value.Password != "tok" || s.Lease.Duration <= 0 || !s.Lease.Renewable {
+		t.Errorf("Unexpected secret %+v", s)
+	}
+	if err := s.Renew(time.Hour); err != nil {
+		t.Errorf("Unexpected error renewing - %v", err)
+	}
+	if ttl, _ := ta.GetTTL("token"); ttl <= time.Minute {
+		t.Errorf("Expected TTL to be extended but got %v", ttl)
+	}
+
+	s2, err := Acquire[creds](ta, "static")
+	if err != nil || s2.Lease.Duration != 0 || s2.Expired() {
+		t.Errorf("Expected a secret without a lease but got %+v (%v)", s2, err)
+	}
+	if err := s2.Renew(time.Hour); err == nil {
+		t.Errorf("Expected an error renewing a secret without a lease")
+	}
+
+	if _, err := Acquire[creds](ta, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound but got %v", err)
+	}
+}
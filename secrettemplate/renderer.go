@@ -0,0 +1,247 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+// Package secrettemplate renders Go text templates against a SecureStorage
+// and writes the results to files, re-rendering when secrets change and
+// optionally running a command to make a service reload them. Templates
+// can use:
+//
+//	{{ secret "hms-creds/x0c0s1b0" "Password" }}  one field of a value
+//	{{ secretJSON "hms-creds/x0c0s1b0" }}         a whole value as JSON
+//	{{ range keys "hms-creds" }}...{{ end }}      the keys below a path
+package secrettemplate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"text/template"
+	"time"
+
+	securestorage "github.com/Cray-HPE/hms-securestorage"
+)
+
+// Template describes one file to render.
+type Template struct {
+	// Path of the template file, or the template itself in Contents.
+	Source      string
+	Contents    string
+	Destination string
+	// Defaults to 0600.
+	Mode os.FileMode
+	// Run after the destination has changed, e.g.
+	// []string{"systemctl", "reload", "nginx"}.
+	Command []string
+}
+
+// Renderer renders a set of Templates against a store.
+type Renderer struct {
+	Store     securestorage.SecureStorage
+	Templates []Template
+	// Called for errors in the background refresh.
+	OnError func(err error)
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Create a new Renderer for the given templates.
+func NewRenderer(store securestorage.SecureStorage, templates ...Template) *Renderer {
+	return &Renderer{
+		Store:     store,
+		Templates: templates,
+	}
+}
+
+func (r *Renderer) funcs() template.FuncMap {
+	lookup := func(key string) (map[string]interface{}, error) {
+		var data map[string]interface{}
+
+		err := r.Store.Lookup(key, &data)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			return nil, fmt.Errorf("%w: %s", securestorage.ErrNotFound, key)
+		}
+		return data, nil
+	}
+	return template.FuncMap{
+		"secret": func(key string, field string) (string, error) {
+			data, err := lookup(key)
+			if err != nil {
+				return "", err
+			}
+			value, ok := data[field]
+			if !ok {
+				return "", fmt.Errorf("%w: %s has no field %s", securestorage.ErrNotFound, key, field)
+			}
+			return fmt.Sprint(value), nil
+		},
+		"secretJSON": func(key string) (string, error) {
+			data, err := lookup(key)
+			if err != nil {
+				return "", err
+			}
+			encoded, err := json.Marshal(data)
+			return string(encoded), err
+		},
+		"keys": func(keyPath string) ([]string, error) {
+			return r.Store.LookupKeys(keyPath)
+		},
+	}
+}
+
+// Render a template without writing it anywhere.
+func (r *Renderer) Render(t Template) ([]byte, error) {
+	contents := t.Contents
+	if t.Source != "" {
+		source, err := os.ReadFile(t.Source)
+		if err != nil {
+			return nil, err
+		}
+		contents = string(source)
+	}
+	tmpl, err := template.New(t.Destination).Funcs(r.funcs()).Option("missingkey=error").Parse(contents)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid template for %s: %v", t.Destination, err)
+	}
+	var out bytes.Buffer
+	err = tmpl.Execute(&out, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to render %s: %v", t.Destination, err)
+	}
+	return out.Bytes(), nil
+}
+
+// Render every template, writing the destinations whose contents changed
+// and running their commands. All templates are attempted; the number
+// changed and the first error are returned.
+func (r *Renderer) RenderOnce() (int, error) {
+	var firstErr error
+	changed := 0
+	for _, t := range r.Templates {
+		ok, err := r.renderFile(t)
+		if ok {
+			changed++
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return changed, firstErr
+}
+
+func (r *Renderer) renderFile(t Template) (bool, error) {
+	contents, err := r.Render(t)
+	if err != nil {
+		return false, err
+	}
+	current, err := os.ReadFile(t.Destination)
+	if err == nil && bytes.Equal(current, contents) {
+		return false, nil
+	}
+	mode := t.Mode
+	if mode == 0 {
+		mode = 0600
+	}
+	err = writeFileAtomic(t.Destination, contents, mode)
+	if err != nil {
+		return false, err
+	}
+	if len(t.Command) > 0 {
+		output, err := exec.Command(t.Command[0], t.Command[1:]...).CombinedOutput()
+		if err != nil {
+			return true, fmt.Errorf("Command for %s failed: %v: %s", t.Destination, err, bytes.TrimSpace(output))
+		}
+	}
+	return true, nil
+}
+
+// Replace a file atomically by writing a temporary file next to it and
+// renaming it into place.
+func writeFileAtomic(name string, contents []byte, mode os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(contents)
+	if err == nil {
+		err = tmp.Chmod(mode)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// Call RenderOnce() every interval until Stop() is called, so destinations
+// follow changes to the secrets. Errors are passed to OnError.
+func (r *Renderer) Start(interval time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stop != nil {
+		return
+	}
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+	go func(stop chan struct{}, done chan struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			_, err := r.RenderOnce()
+			if err != nil && r.OnError != nil {
+				r.OnError(err)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}(r.stop, r.done)
+}
+
+// Stop a Renderer started with Start() and wait for it to finish.
+func (r *Renderer) Stop() {
+	r.mu.Lock()
+	stop, done := r.stop, r.done
+	r.stop, r.done = nil, nil
+	r.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
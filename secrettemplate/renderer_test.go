@@ -0,0 +1,155 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package secrettemplate
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// memStore is a minimal in-memory SecureStorage listing keys one level at a
+// time like Vault.
+type memStore struct {
+	mu   sync.Mutex
+	data map[string]map[string]interface{}
+}
+
+func newMemStore() *memStore {
+	return &memStore{data: map[string]map[string]interface{}{}}
+}
+
+func (ms *memStore) Store(key string, value interface{}) error {
+	var data map[string]interface{}
+	if err := mapstructure.Decode(value, &data); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.data[key] = data
+	return nil
+}
+
+func (ms *memStore) StoreWithData(key string, value interface{}, output interface{}) error {
+	return ms.Store(key, value)
+}
+
+func (ms *memStore) Lookup(key string, output interface{}) error {
+	ms.mu.Lock()
+	data, ok := ms.data[key]
+	ms.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return mapstructure.Decode(data, output)
+}
+
+func (ms *memStore) Delete(key string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delete(ms.data, key)
+	return nil
+}
+
+func (ms *memStore) LookupKeys(keyPath string) ([]string, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	prefix := strings.TrimSuffix(keyPath, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	seen := map[string]bool{}
+	var keys []string
+	for key := range ms.data {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		name := strings.TrimPrefix(key, prefix)
+		if i := strings.Index(name, "/"); i >= 0 {
+			name = name[:i+1]
+		}
+		if !seen[name] {
+			seen[name] = true
+			keys = append(keys, name)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func TestRenderer(t *testing.T) {
+	dir := t.TempDir()
+	store := newMemStore()
+	store.Store("hms-creds/x0c0s1b0", map[string]interface{}{"Username": "root", "Password": "pw1"})
+	store.Store("hms-creds/x0c0s2b0", map[string]interface{}{"Username": "admin", "Password": "pw2"})
+
+	source := filepath.Join(dir, "creds.tmpl")
+	os.WriteFile(source, []byte(`{{ range keys "hms-creds" }}{{ . }}={{ secret (printf "hms-creds/%s" .) "Password" }}
+{{ end }}`), 0600)
+	marker := filepath.Join(dir, "reloaded")
+	r := NewRenderer(store,
+		Template{Source: source, Destination: filepath.Join(dir, "creds.env"), Command: []string{"touch", marker}},
+		Template{Contents: `{{ secretJSON "hms-creds/x0c0s1b0" }}`, Destination: filepath.Join(dir, "x0c0s1b0.json")},
+	)
+
+	var tests = []struct {
+		update  bool
+		changed int
+		env     string
+	}{
+		{changed: 2, env: "x0c0s1b0=pw1\nx0c0s2b0=pw2\n"},
+		{changed: 0, env: "x0c0s1b0=pw1\nx0c0s2b0=pw2\n"},
+		{update: true, changed: 1, env: "x0c0s1b0=pw1\nx0c0s2b0=new\n"},
+	}
+	for i, test := range tests {
+		if test.update {
+			store.Store("hms-creds/x0c0s2b0", map[string]interface{}{"Username": "admin", "Password": "new"})
+		}
+		os.Remove(marker)
+		changed, err := r.RenderOnce()
+		if err != nil || changed != test.changed {
+			t.Errorf("Test %v Failed: Expected %d changed but got %d (%v)", i, test.changed, changed, err)
+		}
+		env, _ := os.ReadFile(filepath.Join(dir, "creds.env"))
+		if string(env) != test.env {
+			t.Errorf("Test %v Failed: Expected %q but got %q", i, test.env, env)
+		}
+		_, err = os.Stat(marker)
+		if reloaded := err == nil; reloaded != (test.changed > 0) {
+			t.Errorf("Test %v Failed: Expected reload %v but got %v", i, test.changed > 0, reloaded)
+		}
+	}
+
+	contents, _ := os.ReadFile(filepath.Join(dir, "x0c0s1b0.json"))
+	if !strings.Contains(string(contents), `"Password":"pw1"`) {
+		t.Errorf("Expected the JSON value but got %s", contents)
+	}
+	if _, err := r.Render(Template{Contents: `{{ secret "hms-creds/x0c0s9b0" "Password" }}`}); err == nil {
+		t.Errorf("Expected an error for a missing secret")
+	}
+}
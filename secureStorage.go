@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright [2019-2022] Hewlett Packard Enterprise Development LP
+// (C) Copyright [2019-2022,2026] Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -22,6 +22,45 @@
 
 package securestorage
 
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// ErrNotFound is returned (possibly wrapped) by helpers that require a key
+// to exist. Lookup() itself does not return an error for missing keys.
+var ErrNotFound = errors.New("key not found")
+
+// ErrInvalidKey is returned (wrapped with the key) by CheckKey().
+var ErrInvalidKey = errors.New("invalid key")
+
+// Check that key names the location it appears to. Backends such as Vault
+// resolve empty, "." and ".." elements and a leading "/", so a key like
+// "hms-creds/../x" would pass a check for the "hms-creds" prefix and then
+// reach "x". A trailing "/", as in key paths passed to LookupKeys(), and
+// the empty key path are allowed.
+func CheckKey(key string) error {
+	if key == "" {
+		return nil
+	}
+	if strings.HasPrefix(key, "/") {
+		return fmt.Errorf("%w %q: must not start with \"/\"", ErrInvalidKey, key)
+	}
+	for _, elem := range strings.Split(strings.TrimSuffix(key, "/"), "/") {
+		switch elem {
+		case "", ".", "..":
+			return fmt.Errorf("%w %q: must not contain empty, \".\" or \"..\" elements", ErrInvalidKey, key)
+		}
+	}
+	return nil
+}
+
 type SecureStorage interface {
 	Store(key string, value interface{}) error
 	StoreWithData(key string, value interface{}, output interface{}) error
@@ -29,3 +68,104 @@ type SecureStorage interface {
 	Delete(key string) error
 	LookupKeys(keyPath string) ([]string, error)
 }
+
+// SecureStorageV2 adds lifecycle management to SecureStorage. Close() stops
+// any background work, flushes pending writes, wipes keys held in memory
+// and releases connections. Every implementation in this package satisfies
+// it; use Close() to close a SecureStorage that may not.
+type SecureStorageV2 interface {
+	SecureStorage
+	io.Closer
+}
+
+// Close ss if it implements io.Closer.
+func Close(ss SecureStorage) error {
+	if c, ok := ss.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// HealthChecker is implemented by SecureStorage backends that can report
+// whether they are able to serve requests.
+type HealthChecker interface {
+	Health() error
+}
+
+// Check whether ss can serve requests, for use in readiness probes.
+// Backends that do not implement HealthChecker are probed by listing the
+// root of the key space.
+func Health(ss SecureStorage) error {
+	if hc, ok := ss.(HealthChecker); ok {
+		return hc.Health()
+	}
+	_, err := ss.LookupKeys("")
+	return err
+}
+
+// Check if a and b are the same store. Stores of a type that is not
+// comparable, such as a struct holding a map, are never the same.
+func sameStore(a SecureStorage, b SecureStorage) bool {
+	t := reflect.TypeOf(a)
+	return t == reflect.TypeOf(b) && t != nil && t.Comparable() && a == b
+}
+
+// Close every store, returning the first error.
+func closeAll(stores ...SecureStorage) error {
+	var err error
+
+	for _, ss := range stores {
+		if cerr := Close(ss); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Convert a value passed to Store() into the generic map form that is
+// written to the backend.
+func toMap(value interface{}) (map[string]interface{}, error) {
+	var data map[string]interface{}
+
+	err := mapstructure.Decode(value, &data)
+	if err != nil {
+		return nil, DefaultRedactor.RedactError(err, value)
+	}
+	data, _ = revealSecrets(data).(map[string]interface{})
+	return data, nil
+}
+
+// Check if data, as read from the backend, is an adapter's envelope: a
+// map value under "value" and nothing but fields besides it. Values
+// written before the adapter was added are not.
+func isEnvelope(data map[string]interface{}, fields ...string) bool {
+	if _, ok := data["value"].(map[string]interface{}); !ok {
+		return false
+	}
+	for field := range data {
+		if field != "value" && !slices.Contains(fields, field) {
+			return false
+		}
+	}
+	return true
+}
+
+// Decode a value read from the backend into a caller's output. This is
+// mapstructure.Decode() with support for SecretBytes fields.
+func decodeValue(input interface{}, output interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: decodeSecretBytes,
+		Result:     output,
+	})
+	if err != nil {
+		return err
+	}
+	return DefaultRedactor.RedactError(decoder.Decode(input), input)
+}
+
+func decodeSecretBytes(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() == reflect.String && to == reflect.TypeOf(SecretBytes(nil)) {
+		return SecretBytes(data.(string)), nil
+	}
+	return data, nil
+}
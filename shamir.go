@@ -0,0 +1,171 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+)
+
+// Largest number of shares SplitMasterKey can produce; share x coordinates
+// are the non-zero bytes.
+const MaxShares = 255
+
+// Logarithm and exponent tables for GF(2^8) with the AES polynomial and
+// generator 3.
+var gfLog, gfExp = gfTables()
+
+func gfTables() ([256]byte, [510]byte) {
+	var (
+		logs [256]byte
+		exps [510]byte
+	)
+
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		exps[i] = x
+		exps[i+255] = x
+		logs[x] = byte(i)
+		// Multiply by the generator 3, i.e. x*2 ^ x.
+		x2 := x << 1
+		if x&0x80 != 0 {
+			x2 ^= 0x1b
+		}
+		x ^= x2
+	}
+	return logs, exps
+}
+
+func gfMul(a byte, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+func gfDiv(a byte, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+255-int(gfLog[b])]
+}
+
+// Split a master key into n shares so that any threshold of them recover
+// it with CombineShares() and fewer reveal nothing about it, using Shamir's
+// secret sharing. Each share is one byte longer than the key; the last
+// byte identifies the share, as with Vault's unseal keys.
+func SplitMasterKey(key []byte, n int, threshold int) ([][]byte, error) {
+	if len(key) == 0 {
+		return nil, fmt.Errorf("Cannot split an empty key")
+	}
+	if threshold < 2 || threshold > n || n > MaxShares {
+		return nil, fmt.Errorf("Invalid key split: %d shares with threshold %d (need 2 <= threshold <= shares <= %d)",
+			n, threshold, MaxShares)
+	}
+
+	shares := make([][]byte, n)
+	for i := range shares {
+		shares[i] = make([]byte, len(key)+1)
+		shares[i][len(key)] = byte(i + 1)
+	}
+	coeffs := make([]byte, threshold)
+	defer func() {
+		for i := range coeffs {
+			coeffs[i] = 0
+		}
+	}()
+	for b, secret := range key {
+		// A random polynomial of degree threshold-1 whose constant term
+		// is the key byte.
+		coeffs[0] = secret
+		err := readEntropy(coeffs[1:])
+		if err != nil {
+			return nil, err
+		}
+		for _, share := range shares {
+			x := share[len(key)]
+			var y byte
+			for c := threshold - 1; c >= 0; c-- {
+				y = gfMul(y, x) ^ coeffs[c]
+			}
+			share[b] = y
+		}
+	}
+	return shares, nil
+}
+
+// Recover a key from shares produced by SplitMasterKey(). At least the
+// threshold number of shares must be given; with fewer, or with shares of
+// different keys, the result is wrong rather than an error, so it should
+// be checked by using it.
+func CombineShares(shares [][]byte) ([]byte, error) {
+	if len(shares) < 2 {
+		return nil, fmt.Errorf("At least 2 shares are required")
+	}
+	size := len(shares[0])
+	if size < 2 {
+		return nil, fmt.Errorf("Invalid share")
+	}
+	seen := map[byte]bool{}
+	for _, share := range shares {
+		if len(share) != size {
+			return nil, fmt.Errorf("Shares have different lengths")
+		}
+		x := share[size-1]
+		if x == 0 || seen[x] {
+			return nil, fmt.Errorf("Invalid or duplicate share %d", x)
+		}
+		seen[x] = true
+	}
+
+	// Lagrange interpolation at x = 0.
+	key := make([]byte, size-1)
+	for i, share := range shares {
+		xi := share[size-1]
+		basis := byte(1)
+		for j, other := range shares {
+			if i != j {
+				xj := other[size-1]
+				basis = gfMul(basis, gfDiv(xj, xj^xi))
+			}
+		}
+		for b := range key {
+			key[b] ^= gfMul(share[b], basis)
+		}
+	}
+	return key, nil
+}
+
+// Create a StaticKeyProvider from master key shares, for stores that must
+// only be opened when enough key holders are present.
+func NewShareKeyProvider(shares ...[]byte) (*StaticKeyProvider, error) {
+	key, err := CombineShares(shares)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for i := range key {
+			key[i] = 0
+		}
+	}()
+	return NewStaticKeyProvider(key)
+}
@@ -0,0 +1,83 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestShamir(t *testing.T) {
+	key, _ := GenerateMasterKey()
+
+	tests := []struct {
+		n         int
+		threshold int
+		use       []int
+		ok        bool
+	}{
+		{5, 3, []int{0, 1, 2}, true},
+		{5, 3, []int{4, 2, 0}, true},
+		{5, 3, []int{0, 1, 2, 3, 4}, true},
+		{5, 3, []int{0, 1}, false},
+		{2, 2, []int{1, 0}, true},
+		{255, 10, []int{254, 100, 7, 8, 9, 10, 11, 12, 13, 200}, true},
+	}
+	for i, test := range tests {
+		shares, err := SplitMasterKey(key, test.n, test.threshold)
+		if err != nil || len(shares) != test.n {
+			t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		var use [][]byte
+		for _, s := range test.use {
+			use = append(use, shares[s])
+		}
+		got, err := CombineShares(use)
+		if err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		if bytes.Equal(got, key) != test.ok {
+			t.Errorf("Test %v Failed: Expected recovery %v", i, test.ok)
+		}
+	}
+
+	for i, args := range [][2]int{{3, 1}, {3, 4}, {256, 2}} {
+		if _, err := SplitMasterKey(key, args[0], args[1]); err == nil {
+			t.Errorf("Test %v Failed: Expected error splitting into %v", i, args)
+		}
+	}
+	shares, _ := SplitMasterKey(key, 3, 2)
+	for i, bad := range [][][]byte{{shares[0]}, {shares[0], shares[0]}, {shares[0], shares[1][1:]}} {
+		if _, err := CombineShares(bad); err == nil {
+			t.Errorf("Test %v Failed: Expected error combining invalid shares", i)
+		}
+	}
+
+	kp, err := NewShareKeyProvider(shares[2], shares[0])
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	if master, _ := kp.MasterKey(); !bytes.Equal(master, key) {
+		t.Errorf("Test Failed: Expected share key provider to return the master key")
+	}
+}
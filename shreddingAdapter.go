@@ -0,0 +1,460 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrShredded is returned (wrapped) when reading a value whose data key has
+// been destroyed.
+var ErrShredded = errors.New("data key destroyed")
+
+// DefaultShredKeyPath is where a ShreddingAdapter keeps wrapped data keys
+// in its KeyStore.
+const DefaultShredKeyPath = ".keys"
+
+// ShreddingAdapter encrypts each key's values under a random data key of
+// its own, kept wrapped by the master key in KeyStore below KeyPath.
+// Delete() destroys the data key first, so every copy of the ciphertext,
+// in version history, journals or backups of the store, becomes
+// permanently unreadable.
+//
+// KeyStore must be set. For deletion to be irreversible the wrapped data
+// keys must not outlive Delete() anywhere, so use a KeyStore that keeps no
+// history and is not backed up with the data. Passing the wrapped store
+// itself works but loses that guarantee. Keys below KeyPath are rejected,
+// so they cannot reach the data keys when the stores are the same.
+//
+// If Escrow is set, each data key is also wrapped to that recovery key,
+// whose private half is kept offline. RecoverKeys() then rewraps every
+// data key under a new master key if the old one is lost. Escrow uses
+// X25519, so it cannot be used in FIPS mode.
+//
+// Data keys are created and updated under a lock per key, so concurrent
+// first writes of a key through one adapter share one data key. Backends
+// have no check-and-set, so processes sharing a KeyStore must not create
+// the same key at the same time.
+type ShreddingAdapter struct {
+	Inner    SecureStorage
+	Keys     KeyProvider
+	KeyStore SecureStorage
+	KeyPath  string
+	Escrow   *HybridPublicKey
+
+	locks keyLocks
+}
+
+// keyLocks hands out a mutex per key, dropping it once nobody holds it.
+type keyLocks struct {
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	sync.Mutex
+	refs int
+}
+
+// Lock key, returning the function that unlocks it.
+func (kl *keyLocks) lock(key string) func() {
+	kl.mu.Lock()
+	if kl.locks == nil {
+		kl.locks = map[string]*keyLock{}
+	}
+	l, ok := kl.locks[key]
+	if !ok {
+		l = &keyLock{}
+		kl.locks[key] = l
+	}
+	l.refs++
+	kl.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		kl.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(kl.locks, key)
+		}
+		kl.mu.Unlock()
+	}
+}
+
+type shreddedKey struct {
+	WrappedKey   string `mapstructure:"wrapped_key"`
+	Encapsulated string `mapstructure:"escrow_encapsulated,omitempty"`
+	EscrowedKey  string `mapstructure:"escrowed_key,omitempty"`
+}
+
+// Create a new ShreddingAdapter wrapping inner and keeping data keys in
+// keyStore.
+func NewShreddingAdapter(inner SecureStorage, keyStore SecureStorage, keyProvider KeyProvider) *ShreddingAdapter {
+	return &ShreddingAdapter{
+		Inner:    inner,
+		Keys:     keyProvider,
+		KeyStore: keyStore,
+		KeyPath:  DefaultShredKeyPath,
+	}
+}
+
+// Whether the data keys are kept in the wrapped store itself.
+func (sa *ShreddingAdapter) sharedKeyStore() bool {
+	return sameStore(sa.KeyStore, sa.Inner)
+}
+
+func (sa *ShreddingAdapter) checkKeyStore() error {
+	if sa.KeyStore == nil {
+		return fmt.Errorf("ShreddingAdapter has no KeyStore")
+	}
+	return nil
+}
+
+func (sa *ShreddingAdapter) dataKeyPath(key string) string {
+	return joinKey(sa.KeyPath, key)
+}
+
+// Check a key used through the adapter. If the KeyStore is the wrapped
+// store, keys below KeyPath, or resolving there, would reach the data keys.
+func (sa *ShreddingAdapter) checkKey(op string, key string) error {
+	err := sa.checkKeyStore()
+	if err != nil {
+		return err
+	}
+	err = CheckKey(key)
+	if err != nil {
+		return err
+	}
+	if key == sa.KeyPath || underPath(sa.KeyPath, key) {
+		return fmt.Errorf("%w: %s %s", ErrAccessDenied, op, key)
+	}
+	return nil
+}
+
+// Get the data key for key, creating it if create is set. It returns nil if
+// there is no data key. The caller must destroy the buffer.
+func (sa *ShreddingAdapter) dataKey(provider CryptoProvider, key string, create bool) (*LockedBuffer, error) {
+	var entry *shreddedKey
+
+	if create {
+		defer sa.locks.lock(key)()
+	}
+	masterKey, err := sa.Keys.MasterKey()
+	if err != nil {
+		return nil, err
+	}
+	err = sa.KeyStore.Lookup(sa.dataKeyPath(key), &entry)
+	if err != nil {
+		return nil, err
+	}
+	if entry != nil {
+		wrapped, err := base64.StdEncoding.DecodeString(entry.WrappedKey)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot decode data key for %s: %v", key, err)
+		}
+		dataKey, err := unseal(provider, masterKey, wrapped, []byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("Cannot decrypt data key for %s: %v", key, err)
+		}
+		if create && sa.Escrow != nil && entry.EscrowedKey == "" {
+			// Escrow data keys created before Escrow was set.
+			err = sa.escrow(provider, key, dataKey.Bytes(), entry)
+			if err == nil {
+				err = sa.KeyStore.Store(sa.dataKeyPath(key), entry)
+			}
+			if err != nil {
+				dataKey.Destroy()
+				return nil, err
+			}
+		}
+		return dataKey, nil
+	}
+	if !create {
+		return nil, nil
+	}
+
+	dataKey, err := newSecretBuffer(MasterKeySize)
+	if err != nil {
+		return nil, err
+	}
+	err = readEntropy(dataKey.Bytes())
+	if err == nil {
+		var wrapped []byte
+		wrapped, err = seal(provider, masterKey, dataKey.Bytes(), []byte(key))
+		if err == nil {
+			entry = &shreddedKey{WrappedKey: base64.StdEncoding.EncodeToString(wrapped)}
+			if sa.Escrow != nil {
+				err = sa.escrow(provider, key, dataKey.Bytes(), entry)
+			}
+		}
+		if err == nil {
+			err = sa.KeyStore.Store(sa.dataKeyPath(key), entry)
+		}
+	}
+	if err != nil {
+		dataKey.Destroy()
+		return nil, err
+	}
+	return dataKey, nil
+}
+
+// Wrap dataKey to the Escrow key and record it in entry.
+func (sa *ShreddingAdapter) escrow(provider CryptoProvider, key string, dataKey []byte, entry *shreddedKey) error {
+	kek, encapsulated, err := sa.Escrow.encapsulate()
+	if err != nil {
+		return err
+	}
+	escrowed, err := seal(provider, kek, dataKey, []byte(key))
+	if err != nil {
+		return err
+	}
+	entry.Encapsulated = base64.StdEncoding.EncodeToString(encapsulated)
+	entry.EscrowedKey = base64.StdEncoding.EncodeToString(escrowed)
+	return nil
+}
+
+// Rewrap every escrowed data key under the current master key using the
+// private recovery key, after the master key that wrapped them was lost.
+// Data keys that were never escrowed cannot be recovered; their keys are
+// returned so the values can be restored some other way.
+func (sa *ShreddingAdapter) RecoverKeys(recovery *HybridPrivateKey) ([]string, error) {
+	err := sa.checkKeyStore()
+	if err != nil {
+		return nil, err
+	}
+	provider, err := currentCrypto()
+	if err != nil {
+		return nil, err
+	}
+	masterKey, err := sa.Keys.MasterKey()
+	if err != nil {
+		return nil, err
+	}
+
+	lost := []string{}
+	it := IterateKeys(sa.KeyStore, sa.KeyPath)
+	defer it.Close()
+	for it.Next() {
+		var entry *shreddedKey
+
+		key := strings.TrimPrefix(it.Key(), sa.KeyPath+"/")
+		err := sa.KeyStore.Lookup(it.Key(), &entry)
+		if err != nil {
+			return nil, err
+		}
+		if entry == nil {
+			continue
+		}
+		if entry.EscrowedKey == "" {
+			lost = append(lost, key)
+			continue
+		}
+		encapsulated, err := base64.StdEncoding.DecodeString(entry.Encapsulated)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot decode escrow for %s: %v", key, err)
+		}
+		escrowed, err := base64.StdEncoding.DecodeString(entry.EscrowedKey)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot decode escrow for %s: %v", key, err)
+		}
+		kek, err := recovery.decapsulate(encapsulated)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot recover data key for %s: %v", key, err)
+		}
+		dataKey, err := unseal(provider, kek, escrowed, []byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("Cannot recover data key for %s: %v", key, err)
+		}
+		wrapped, err := seal(provider, masterKey, dataKey.Bytes(), []byte(key))
+		dataKey.Destroy()
+		if err != nil {
+			return nil, err
+		}
+		entry.WrappedKey = base64.StdEncoding.EncodeToString(wrapped)
+		err = sa.KeyStore.Store(it.Key(), entry)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	sort.Strings(lost)
+	return lost, nil
+}
+
+func (sa *ShreddingAdapter) encrypt(key string, value interface{}) (*encryptedEntry, error) {
+	data, err := toMap(value)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := currentCrypto()
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := sa.dataKey(provider, key, true)
+	if err != nil {
+		return nil, err
+	}
+	defer dataKey.Destroy()
+	sealed, err := seal(provider, dataKey.Bytes(), plaintext, []byte(key))
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedEntry{
+		Alg:        provider.Algorithm(),
+		Ciphertext: base64.StdEncoding.EncodeToString(sealed),
+	}, nil
+}
+
+func (sa *ShreddingAdapter) Store(key string, value interface{}) error {
+	err := sa.checkKey(OpStore, key)
+	if err != nil {
+		return err
+	}
+	entry, err := sa.encrypt(key, value)
+	if err != nil {
+		return err
+	}
+	return sa.Inner.Store(key, entry)
+}
+
+func (sa *ShreddingAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	err := sa.checkKey(OpStoreWithData, key)
+	if err != nil {
+		return err
+	}
+	entry, err := sa.encrypt(key, value)
+	if err != nil {
+		return err
+	}
+	return sa.Inner.StoreWithData(key, entry, output)
+}
+
+func (sa *ShreddingAdapter) Lookup(key string, output interface{}) error {
+	var (
+		entry *encryptedEntry
+		data  map[string]interface{}
+	)
+
+	if output == nil {
+		return fmt.Errorf("output interface was nil")
+	}
+	err := sa.checkKey(OpLookup, key)
+	if err != nil {
+		return err
+	}
+	err = sa.Inner.Lookup(key, &entry)
+	if err != nil || entry == nil {
+		return err
+	}
+	provider, err := currentCrypto()
+	if err != nil {
+		return err
+	}
+	if entry.Alg != provider.Algorithm() {
+		return fmt.Errorf("Unsupported encryption algorithm %q for %s", entry.Alg, key)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(entry.Ciphertext)
+	if err != nil {
+		return fmt.Errorf("Cannot decode ciphertext for %s: %v", key, err)
+	}
+	dataKey, err := sa.dataKey(provider, key, false)
+	if err != nil {
+		return err
+	}
+	if dataKey == nil {
+		return fmt.Errorf("%w: %s", ErrShredded, key)
+	}
+	defer dataKey.Destroy()
+	plaintext, err := unseal(provider, dataKey.Bytes(), sealed, []byte(key))
+	if err != nil {
+		return fmt.Errorf("Cannot decrypt %s: %v", key, err)
+	}
+	defer plaintext.Destroy()
+	err = json.Unmarshal(plaintext.Bytes(), &data)
+	if err != nil {
+		return fmt.Errorf("Cannot decode value for %s: %v", key, err)
+	}
+	return decodeValue(data, output)
+}
+
+// Destroy the data key for key, then delete its value. If the data key
+// cannot be destroyed the value is kept.
+func (sa *ShreddingAdapter) Delete(key string) error {
+	err := sa.checkKey(OpDelete, key)
+	if err != nil {
+		return err
+	}
+	defer sa.locks.lock(key)()
+	err = sa.KeyStore.Delete(sa.dataKeyPath(key))
+	if err != nil {
+		return err
+	}
+	return sa.Inner.Delete(key)
+}
+
+// KeyPath is hidden from listings, at any level.
+func (sa *ShreddingAdapter) LookupKeys(keyPath string) ([]string, error) {
+	keys, err := sa.Inner.LookupKeys(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	return hideKeyPath(keyPath, keys, sa.KeyPath), nil
+}
+
+// Close the wrapped store and the KeyStore, then the KeyProvider if it can
+// be closed.
+func (sa *ShreddingAdapter) Close() error {
+	err := Close(sa.Inner)
+	if sa.KeyStore != nil && !sa.sharedKeyStore() {
+		if cerr := Close(sa.KeyStore); err == nil {
+			err = cerr
+		}
+	}
+	if c, ok := sa.Keys.(io.Closer); ok {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+func (sa *ShreddingAdapter) Health() error {
+	err := Health(sa.Inner)
+	if err == nil && sa.KeyStore != nil && !sa.sharedKeyStore() {
+		err = Health(sa.KeyStore)
+	}
+	return err
+}
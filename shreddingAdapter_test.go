@@ -0,0 +1,272 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestShreddingAdapter(t *testing.T) {
+	key, _ := GenerateMasterKey()
+	kp, err := NewStaticKeyProvider(key)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+
+	var tests = []struct {
+		separate bool
+		rootKeys []string
+	}{
+		{separate: false, rootKeys: []string{"x0c0s1b0", "x0c0s2b0"}},
+		{separate: true, rootKeys: []string{"x0c0s1b0", "x0c0s2b0"}},
+	}
+
+	for i, test := range tests {
+		ms := newMemStore()
+		keyStore := ms
+		if test.separate {
+			keyStore = newMemStore()
+		}
+		sa := NewShreddingAdapter(ms, keyStore, kp)
+		value := creds{Username: "root", Password: "pw1"}
+		sa.Store("x0c0s1b0", creds{Username: "root", Password: "pw0"})
+		sa.Store("x0c0s1b0", value)
+		sa.Store("x0c0s2b0", creds{Username: "root", Password: "pw2"})
+
+		var r creds
+		if err := sa.Lookup("x0c0s1b0", &r); err != nil || !reflect.DeepEqual(r, value) {
+			t.Errorf("Test %v Failed: Expected %v but got %v (%v)", i, value, r, err)
+		}
+		var dk map[string]interface{}
+		keyStore.Lookup(joinKey(DefaultShredKeyPath, "x0c0s1b0"), &dk)
+		if dk == nil {
+			t.Errorf("Test %v Failed: Expected a wrapped data key", i)
+		}
+		keys, err := sa.LookupKeys("")
+		if err != nil || fmt.Sprint(keys) != fmt.Sprint(test.rootKeys) {
+			t.Errorf("Test %v Failed: Expected keys %v but got %v (%v)", i, test.rootKeys, keys, err)
+		}
+
+		// A copy of the ciphertext taken before Delete(), as in a backup,
+		// must be unreadable afterwards.
+		var backup map[string]interface{}
+		ms.Lookup("x0c0s1b0", &backup)
+		if err := sa.Delete("x0c0s1b0"); err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		dk = nil
+		keyStore.Lookup(joinKey(DefaultShredKeyPath, "x0c0s1b0"), &dk)
+		if dk != nil {
+			t.Errorf("Test %v Failed: Data key was not destroyed", i)
+		}
+		ms.Store("x0c0s1b0", backup)
+		if err := sa.Lookup("x0c0s1b0", &r); !errors.Is(err, ErrShredded) {
+			t.Errorf("Test %v Failed: Expected ErrShredded but got %v", i, err)
+		}
+
+		// Storing again starts a new data key, which cannot open the old
+		// ciphertext either.
+		sa.Store("x0c0s1b0", value)
+		ms.Store("x0c0s1b0", backup)
+		if err := sa.Lookup("x0c0s1b0", &r); err == nil {
+			t.Errorf("Test %v Failed: Expected an error reading a shredded value with a new data key", i)
+		}
+		if err := sa.Lookup("x0c0s2b0", &r); err != nil || r.Password != "pw2" {
+			t.Errorf("Test %v Failed: Expected to read an unrelated key but got %v (%v)", i, r, err)
+		}
+	}
+}
+
+func TestShreddingAdapterNestedKeyPath(t *testing.T) {
+	key, _ := GenerateMasterKey()
+	kp, _ := NewStaticKeyProvider(key)
+	ms := newMemStore()
+	sa := NewShreddingAdapter(ms, ms, kp)
+	sa.KeyPath = "tenant/.keys"
+	if err := sa.Store("tenant/x0c0s1b0", creds{Password: "pw"}); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+
+	var tests = []struct {
+		keyPath  string
+		expected []string
+	}{
+		{keyPath: "", expected: []string{"tenant/"}},
+		{keyPath: "tenant", expected: []string{"x0c0s1b0"}},
+		{keyPath: "tenant/.keys", expected: []string{}},
+	}
+
+	for i, test := range tests {
+		keys, err := sa.LookupKeys(test.keyPath)
+		if err != nil || !reflect.DeepEqual(keys, test.expected) {
+			t.Errorf("Test %v Failed: Expected keys %v but got %v (%v)", i, test.expected, keys, err)
+		}
+	}
+}
+
+func TestShreddingAdapterKeyPath(t *testing.T) {
+	key, _ := GenerateMasterKey()
+	kp, _ := NewStaticKeyProvider(key)
+	ms := newMemStore()
+	sa := NewShreddingAdapter(ms, ms, kp)
+	sa.Store("foo", creds{Password: "pw"})
+
+	var tests = []struct {
+		key string
+		err error
+	}{
+		{key: DefaultShredKeyPath + "/foo", err: ErrAccessDenied},
+		{key: DefaultShredKeyPath, err: ErrAccessDenied},
+		{key: "bar/../" + DefaultShredKeyPath + "/foo", err: ErrInvalidKey},
+	}
+	for i, test := range tests {
+		if err := sa.Store(test.key, creds{Password: "evil"}); !errors.Is(err, test.err) {
+			t.Errorf("Test %v Failed: Expected %v storing %s but got %v", i, test.err, test.key, err)
+		}
+		if err := sa.Delete(test.key); !errors.Is(err, test.err) {
+			t.Errorf("Test %v Failed: Expected %v deleting %s but got %v", i, test.err, test.key, err)
+		}
+		var r creds
+		if err := sa.Lookup(test.key, &r); !errors.Is(err, test.err) {
+			t.Errorf("Test %v Failed: Expected %v looking up %s but got %v", i, test.err, test.key, err)
+		}
+	}
+	var r creds
+	if err := sa.Lookup("foo", &r); err != nil || r.Password != "pw" {
+		t.Errorf("Test Failed: Expected the data key for foo to survive but got %v (%v)", r, err)
+	}
+
+	// Without a KeyStore nothing is written.
+	noKeys := NewShreddingAdapter(newMemStore(), nil, kp)
+	if err := noKeys.Store("foo", creds{Password: "pw"}); err == nil {
+		t.Errorf("Test Failed: Expected an error without a KeyStore")
+	}
+	if _, err := noKeys.RecoverKeys(nil); err == nil {
+		t.Errorf("Test Failed: Expected an error recovering without a KeyStore")
+	}
+}
+
+// slowStore widens race windows by pausing before each Lookup() and
+// before the first Store().
+type slowStore struct {
+	*memStore
+	first sync.Once
+}
+
+func (ss *slowStore) Lookup(key string, output interface{}) error {
+	time.Sleep(time.Millisecond)
+	return ss.memStore.Lookup(key, output)
+}
+
+func (ss *slowStore) Store(key string, value interface{}) error {
+	ss.first.Do(func() { time.Sleep(20 * time.Millisecond) })
+	return ss.memStore.Store(key, value)
+}
+
+func TestShreddingAdapterConcurrentCreate(t *testing.T) {
+	key, _ := GenerateMasterKey()
+	kp, _ := NewStaticKeyProvider(key)
+	for i, key := range []string{"x0c0s1b0", "x0c0s2b0", "x0c0s3b0"} {
+		// The first value written is delayed until after the other data
+		// keys would have been written.
+		sa := NewShreddingAdapter(&slowStore{memStore: newMemStore()}, &slowStore{memStore: newMemStore()}, kp)
+		var wg sync.WaitGroup
+		for n := 0; n < 8; n++ {
+			wg.Add(1)
+			go func(n int) {
+				defer wg.Done()
+				sa.Store(key, creds{Password: fmt.Sprint(n)})
+			}(n)
+		}
+		wg.Wait()
+
+		var r creds
+		if err := sa.Lookup(key, &r); err != nil || r.Password == "" {
+			t.Errorf("Test %v Failed: Expected a readable value after concurrent stores but got %v (%v)", i, r, err)
+		}
+	}
+}
+
+func TestShreddingAdapterRecoverKeys(t *testing.T) {
+	if fipsBuild {
+		t.Skip("Escrow is not FIPS approved")
+	}
+	recovery, err := GenerateHybridKey()
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	escrow, _ := recovery.PublicKey()
+	otherRecovery, _ := GenerateHybridKey()
+	lostKey, _ := GenerateMasterKey()
+	lostKP, _ := NewStaticKeyProvider(lostKey)
+	newKey, _ := GenerateMasterKey()
+	newKP, _ := NewStaticKeyProvider(newKey)
+
+	ms := newMemStore()
+	sa := NewShreddingAdapter(ms, ms, lostKP)
+	sa.Store("unescrowed", creds{Password: "pw0"})
+	sa.Store("upgraded", creds{Password: "pw1"})
+	sa.Escrow = escrow
+	sa.Store("upgraded", creds{Password: "pw1"})
+	sa.Store("bmc/x0c0s1b0", creds{Password: "pw2"})
+
+	// The master key is lost; a new one cannot read anything until the
+	// data keys are recovered.
+	recovered := NewShreddingAdapter(ms, ms, newKP)
+	var r creds
+	if err := recovered.Lookup("bmc/x0c0s1b0", &r); err == nil {
+		t.Errorf("Expected an error before recovery")
+	}
+	if _, err := recovered.RecoverKeys(otherRecovery); err == nil {
+		t.Errorf("Expected an error recovering with the wrong recovery key")
+	}
+	lost, err := recovered.RecoverKeys(recovery)
+	if err != nil || fmt.Sprint(lost) != "[unescrowed]" {
+		t.Errorf("Expected [unescrowed] to be lost but got %v (%v)", lost, err)
+	}
+
+	var tests = []struct {
+		key      string
+		password string
+		respErr  bool
+	}{
+		{key: "bmc/x0c0s1b0", password: "pw2"},
+		{key: "upgraded", password: "pw1"},
+		{key: "unescrowed", respErr: true},
+	}
+
+	for i, test := range tests {
+		var r creds
+		err := recovered.Lookup(test.key, &r)
+		if (err != nil) != test.respErr {
+			t.Errorf("Test %v Failed: Unexpected error result - %v", i, err)
+		}
+		if err == nil && r.Password != test.password {
+			t.Errorf("Test %v Failed: Expected password %v but got %v", i, test.password, r.Password)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrBadSignature is returned (wrapped) by SignedAdapter when a value has
+// no valid signature.
+var ErrBadSignature = errors.New("signature verification failed")
+
+// SigningKeyProvider supplies the Ed25519 key used by SignedAdapter to
+// sign values. It is called for every write so providers may reload or
+// rotate the key.
+type SigningKeyProvider interface {
+	SigningKey() (ed25519.PrivateKey, error)
+}
+
+// SigningKeyFunc adapts a plain function to a SigningKeyProvider.
+type SigningKeyFunc func() (ed25519.PrivateKey, error)
+
+func (f SigningKeyFunc) SigningKey() (ed25519.PrivateKey, error) {
+	return f()
+}
+
+// FileSigningKeyProvider reads a PEM encoded PKCS #8 Ed25519 private key
+// from a file, such as one written by "openssl genpkey -algorithm ed25519".
+// The file is re-read on every call.
+type FileSigningKeyProvider struct {
+	Path string
+}
+
+// Create a new FileSigningKeyProvider for the key file at path.
+func NewFileSigningKeyProvider(path string) *FileSigningKeyProvider {
+	return &FileSigningKeyProvider{Path: path}
+}
+
+func (kp *FileSigningKeyProvider) SigningKey() (ed25519.PrivateKey, error) {
+	contents, err := os.ReadFile(kp.Path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return nil, fmt.Errorf("No PEM data found in %s", kp.Path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot parse signing key in %s: %v", kp.Path, err)
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("Signing key in %s is not an Ed25519 key", kp.Path)
+	}
+	return edKey, nil
+}
+
+// SignedAdapter signs every value it writes with Ed25519 and verifies the
+// signature on every read, so a value changed by anyone without the
+// signing key, even someone holding the encryption key, is rejected with
+// ErrBadSignature. The signature covers the key name so a value copied to
+// another key is rejected too. Wrap it around any EncryptedAdapter so the
+// signature is encrypted with the value. Readers only need PublicKey; with
+// no Keys the adapter cannot write.
+type SignedAdapter struct {
+	Inner     SecureStorage
+	Keys      SigningKeyProvider
+	PublicKey ed25519.PublicKey
+}
+
+type signedEntry struct {
+	Value     map[string]interface{} `mapstructure:"value"`
+	Signature string                 `mapstructure:"signature"`
+}
+
+// Create a new SignedAdapter wrapping inner, signing with keys and
+// verifying with publicKey.
+func NewSignedAdapter(inner SecureStorage, keys SigningKeyProvider, publicKey ed25519.PublicKey) *SignedAdapter {
+	return &SignedAdapter{
+		Inner:     inner,
+		Keys:      keys,
+		PublicKey: publicKey,
+	}
+}
+
+// Get the bytes signed for value at key.
+func signedMessage(key string, value map[string]interface{}) ([]byte, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(key+"\x00"), encoded...), nil
+}
+
+func (sa *SignedAdapter) sign(key string, value interface{}) (*signedEntry, error) {
+	if sa.Keys == nil {
+		return nil, fmt.Errorf("Cannot store %s without a signing key", key)
+	}
+	data, err := toMap(value)
+	if err != nil {
+		return nil, err
+	}
+	// Store the value as it reads back from JSON so the signature still
+	// matches after a round trip through the backend.
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	data = nil
+	err = json.Unmarshal(encoded, &data)
+	if err != nil {
+		return nil, err
+	}
+	signingKey, err := sa.Keys.SigningKey()
+	if err != nil {
+		return nil, err
+	}
+	msg, err := signedMessage(key, data)
+	if err != nil {
+		return nil, err
+	}
+	return &signedEntry{
+		Value:     data,
+		Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(signingKey, msg)),
+	}, nil
+}
+
+func (sa *SignedAdapter) Store(key string, value interface{}) error {
+	entry, err := sa.sign(key, value)
+	if err != nil {
+		return err
+	}
+	return sa.Inner.Store(key, entry)
+}
+
+func (sa *SignedAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	entry, err := sa.sign(key, value)
+	if err != nil {
+		return err
+	}
+	return sa.Inner.StoreWithData(key, entry, output)
+}
+
+func (sa *SignedAdapter) Lookup(key string, output interface{}) error {
+	var entry *signedEntry
+
+	if output == nil {
+		return fmt.Errorf("output interface was nil")
+	}
+	err := sa.Inner.Lookup(key, &entry)
+	if err != nil || entry == nil {
+		return err
+	}
+	sig, err := base64.StdEncoding.DecodeString(entry.Signature)
+	if err != nil || len(sa.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: %s", ErrBadSignature, key)
+	}
+	msg, err := signedMessage(key, entry.Value)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(sa.PublicKey, msg, sig) {
+		return fmt.Errorf("%w: %s", ErrBadSignature, key)
+	}
+	return decodeValue(entry.Value, output)
+}
+
+func (sa *SignedAdapter) Delete(key string) error {
+	return sa.Inner.Delete(key)
+}
+
+func (sa *SignedAdapter) LookupKeys(keyPath string) ([]string, error) {
+	return sa.Inner.LookupKeys(keyPath)
+}
+
+func (sa *SignedAdapter) Close() error {
+	return Close(sa.Inner)
+}
+
+func (sa *SignedAdapter) Health() error {
+	return Health(sa.Inner)
+}
@@ -0,0 +1,107 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSignedAdapter(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	otherPub, otherPriv, _ := ed25519.GenerateKey(nil)
+	der, _ := x509.MarshalPKCS8PrivateKey(priv)
+	keyFile := filepath.Join(t.TempDir(), "signing.pem")
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600)
+
+	ms := newMemStore()
+	sa := NewSignedAdapter(ms, NewFileSigningKeyProvider(keyFile), pub)
+	value := creds{Xname: "x0c0s1b0", Username: "root", Password: "pw"}
+	if err := sa.Store("x0c0s1b0", value); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	var entry map[string]interface{}
+	ms.Lookup("x0c0s1b0", &entry)
+
+	tamper := func(key string, change func(e map[string]interface{})) {
+		e := map[string]interface{}{}
+		for k, v := range entry {
+			e[k] = v
+		}
+		change(e)
+		ms.Store(key, e)
+	}
+	tamper("moved", func(e map[string]interface{}) {})
+	tamper("modified", func(e map[string]interface{}) {
+		e["value"] = map[string]interface{}{"Xname": "x0c0s1b0", "Username": "root", "Password": "evil"}
+	})
+	tamper("unsigned", func(e map[string]interface{}) { delete(e, "signature") })
+	forger := NewSignedAdapter(newMemStore(), SigningKeyFunc(func() (ed25519.PrivateKey, error) { return otherPriv, nil }), otherPub)
+	forger.Store("forged", value)
+	var forged map[string]interface{}
+	forger.Inner.Lookup("forged", &forged)
+	ms.Store("forged", forged)
+
+	var tests = []struct {
+		ss     SecureStorage
+		key    string
+		badSig bool
+	}{
+		{ss: sa, key: "x0c0s1b0"},
+		{ss: NewSignedAdapter(ms, nil, pub), key: "x0c0s1b0"},
+		{ss: sa, key: "moved", badSig: true},
+		{ss: sa, key: "modified", badSig: true},
+		{ss: sa, key: "unsigned", badSig: true},
+		{ss: sa, key: "forged", badSig: true},
+		{ss: NewSignedAdapter(ms, nil, otherPub), key: "x0c0s1b0", badSig: true},
+	}
+
+	for i, test := range tests {
+		var r creds
+		err := test.ss.Lookup(test.key, &r)
+		if test.badSig {
+			if !errors.Is(err, ErrBadSignature) {
+				t.Errorf("Test %v Failed: Expected ErrBadSignature but got %v", i, err)
+			}
+		} else if err != nil || !reflect.DeepEqual(r, value) {
+			t.Errorf("Test %v Failed: Expected %v but got %v (%v)", i, value, r, err)
+		}
+	}
+
+	var r creds
+	if err := sa.Lookup("missing", &r); err != nil {
+		t.Errorf("Unexpected error for a missing key - %v", err)
+	}
+	if err := NewSignedAdapter(ms, nil, pub).Store("x0c0s1b0", value); err == nil {
+		t.Errorf("Expected an error storing without a signing key")
+	}
+	os.WriteFile(keyFile, []byte("junk"), 0600)
+	if err := sa.Store("x0c0s1b0", value); err == nil {
+		t.Errorf("Expected an error with an invalid key file")
+	}
+}
@@ -0,0 +1,507 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+// Package sshagent serves private keys kept in a SecureStorage over the
+// ssh-agent protocol. Keys are read from the store each time they are
+// listed or used and are never written to disk, so node access keys can be
+// stored and rotated centrally. Only listing keys and signing are
+// supported; keys cannot be added or removed through the agent.
+package sshagent
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"sync"
+
+	securestorage "github.com/Cray-HPE/hms-securestorage"
+)
+
+// ssh-agent protocol message numbers
+const (
+	agentFailure           = 5
+	agentRequestIdentities = 11
+	agentIdentitiesAnswer  = 12
+	agentSignRequest       = 13
+	agentSignResponse      = 14
+
+	agentRSASHA256 = 2
+	agentRSASHA512 = 4
+
+	// Largest message accepted from a client
+	maxMessageSize = 256 * 1024
+)
+
+// DefaultPath is the store path keys are kept under.
+const DefaultPath = "ssh-keys"
+
+// Key is the secret stored for each key. PrivateKey is a PEM encoded
+// unencrypted OpenSSH, PKCS#8, PKCS#1 (RSA) or SEC 1 (ECDSA) key.
+type Key struct {
+	PrivateKey string `mapstructure:"private_key"`
+	Comment    string `mapstructure:"comment"`
+}
+
+// Agent serves every key below Path.
+type Agent struct {
+	Store securestorage.SecureStorage
+	Path  string
+	// Optional. Called with the name of each key used to sign, for
+	// auditing.
+	OnSign func(name string)
+
+	mu        sync.Mutex
+	listeners []net.Listener
+}
+
+// Create a new Agent for the keys below path.
+func New(store securestorage.SecureStorage, path string) *Agent {
+	return &Agent{Store: store, Path: path}
+}
+
+type identity struct {
+	name    string
+	comment string
+	blob    []byte
+	signer  crypto.Signer
+}
+
+// Parse a PEM encoded private key.
+func parsePrivateKey(data string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data")
+	}
+	if block.Type == "OPENSSH PRIVATE KEY" {
+		return parseOpenSSHPrivateKey(block.Bytes)
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		if signer, ok := key.(crypto.Signer); ok {
+			return signer, nil
+		}
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported private key format %s", block.Type)
+}
+
+// Load every key below Path. Keys that cannot be read or parsed are
+// skipped so one bad key does not hide the others.
+func (a *Agent) identities() ([]identity, error) {
+	names, err := a.Store.LookupKeys(a.Path)
+	if err != nil {
+		return nil, err
+	}
+	var ids []identity
+	for _, name := range names {
+		var key Key
+		err := a.Store.Lookup(a.Path+"/"+name, &key)
+		if err != nil || key.PrivateKey == "" {
+			continue
+		}
+		signer, err := parsePrivateKey(key.PrivateKey)
+		if err != nil {
+			continue
+		}
+		blob, err := marshalPublicKey(signer.Public())
+		if err != nil {
+			continue
+		}
+		ids = append(ids, identity{name: name, comment: key.Comment, blob: blob, signer: signer})
+	}
+	return ids, nil
+}
+
+// Serve clients from l until it is closed.
+func (a *Agent) Serve(l net.Listener) error {
+	a.mu.Lock()
+	a.listeners = append(a.listeners, l)
+	a.mu.Unlock()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			a.ServeConn(conn)
+		}()
+	}
+}
+
+// Close the listeners passed to Serve().
+func (a *Agent) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, l := range a.listeners {
+		l.Close()
+	}
+	a.listeners = nil
+	return nil
+}
+
+// Answer requests on a single connection until the client disconnects.
+func (a *Agent) ServeConn(conn io.ReadWriter) error {
+	r := bufio.NewReader(conn)
+	for {
+		var length uint32
+		err := binary.Read(r, binary.BigEndian, &length)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if length == 0 || length > maxMessageSize {
+			return fmt.Errorf("Invalid ssh-agent message length %d", length)
+		}
+		msg := make([]byte, length)
+		_, err = io.ReadFull(r, msg)
+		if err != nil {
+			return err
+		}
+		reply := a.handle(msg)
+		out := binary.BigEndian.AppendUint32(nil, uint32(len(reply)))
+		_, err = conn.Write(append(out, reply...))
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (a *Agent) handle(msg []byte) []byte {
+	switch msg[0] {
+	case agentRequestIdentities:
+		ids, err := a.identities()
+		if err != nil {
+			return []byte{agentFailure}
+		}
+		reply := binary.BigEndian.AppendUint32([]byte{agentIdentitiesAnswer}, uint32(len(ids)))
+		for _, id := range ids {
+			reply = appendString(reply, id.blob)
+			reply = appendString(reply, []byte(id.comment))
+		}
+		return reply
+
+	case agentSignRequest:
+		rest := msg[1:]
+		blob, rest, ok := readString(rest)
+		if !ok {
+			return []byte{agentFailure}
+		}
+		data, rest, ok := readString(rest)
+		if !ok || len(rest) != 4 {
+			return []byte{agentFailure}
+		}
+		flags := binary.BigEndian.Uint32(rest)
+		sig, err := a.sign(blob, data, flags)
+		if err != nil {
+			return []byte{agentFailure}
+		}
+		return appendString([]byte{agentSignResponse}, sig)
+	}
+	return []byte{agentFailure}
+}
+
+func (a *Agent) sign(blob []byte, data []byte, flags uint32) ([]byte, error) {
+	ids, err := a.identities()
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if !bytes.Equal(id.blob, blob) {
+			continue
+		}
+		sig, err := signData(id.signer, data, flags)
+		if err == nil && a.OnSign != nil {
+			a.OnSign(id.name)
+		}
+		return sig, err
+	}
+	return nil, fmt.Errorf("%w: no key matches", securestorage.ErrNotFound)
+}
+
+// Append an SSH wire format string.
+func appendString(buf []byte, s []byte) []byte {
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(s)))
+	return append(buf, s...)
+}
+
+// Append an SSH wire format mpint.
+func appendMPInt(buf []byte, n *big.Int) []byte {
+	b := n.Bytes()
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	return appendString(buf, b)
+}
+
+func readString(buf []byte) ([]byte, []byte, bool) {
+	if len(buf) < 4 {
+		return nil, nil, false
+	}
+	n := binary.BigEndian.Uint32(buf)
+	if uint64(len(buf)-4) < uint64(n) {
+		return nil, nil, false
+	}
+	return buf[4 : 4+n], buf[4+n:], true
+}
+
+// SSH name and hash of an ECDSA curve.
+func curveInfo(key *ecdsa.PublicKey) (string, crypto.Hash, error) {
+	switch key.Curve.Params().BitSize {
+	case 256:
+		return "nistp256", crypto.SHA256, nil
+	case 384:
+		return "nistp384", crypto.SHA384, nil
+	case 521:
+		return "nistp521", crypto.SHA512, nil
+	}
+	return "", 0, fmt.Errorf("unsupported curve %s", key.Curve.Params().Name)
+}
+
+// Encode a public key in SSH wire format.
+func marshalPublicKey(pub crypto.PublicKey) ([]byte, error) {
+	switch key := pub.(type) {
+	case ed25519.PublicKey:
+		return appendString(appendString(nil, []byte("ssh-ed25519")), key), nil
+	case *rsa.PublicKey:
+		blob := appendString(nil, []byte("ssh-rsa"))
+		blob = appendMPInt(blob, big.NewInt(int64(key.E)))
+		return appendMPInt(blob, key.N), nil
+	case *ecdsa.PublicKey:
+		curve, _, err := curveInfo(key)
+		if err != nil {
+			return nil, err
+		}
+		point, err := key.ECDH()
+		if err != nil {
+			return nil, err
+		}
+		blob := appendString(nil, []byte("ecdsa-sha2-"+curve))
+		blob = appendString(blob, []byte(curve))
+		return appendString(blob, point.Bytes()), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %T", pub)
+}
+
+// Sign data and encode the signature in SSH wire format.
+func signData(signer crypto.Signer, data []byte, flags uint32) ([]byte, error) {
+	switch pub := signer.Public().(type) {
+	case ed25519.PublicKey:
+		sig, err := signer.Sign(rand.Reader, data, crypto.Hash(0))
+		if err != nil {
+			return nil, err
+		}
+		return appendString(appendString(nil, []byte("ssh-ed25519")), sig), nil
+
+	case *rsa.PublicKey:
+		algo, hash := "ssh-rsa", crypto.SHA1
+		switch {
+		case flags&agentRSASHA512 != 0:
+			algo, hash = "rsa-sha2-512", crypto.SHA512
+		case flags&agentRSASHA256 != 0:
+			algo, hash = "rsa-sha2-256", crypto.SHA256
+		}
+		if hash == crypto.SHA1 && securestorage.ComplianceInfo().FIPSMode {
+			return nil, fmt.Errorf("%w: SHA-1 signatures are not approved", securestorage.ErrNotCompliant)
+		}
+		h := hash.New()
+		h.Write(data)
+		sig, err := signer.Sign(rand.Reader, h.Sum(nil), hash)
+		if err != nil {
+			return nil, err
+		}
+		return appendString(appendString(nil, []byte(algo)), sig), nil
+
+	case *ecdsa.PublicKey:
+		curve, hash, err := curveInfo(pub)
+		if err != nil {
+			return nil, err
+		}
+		h := hash.New()
+		h.Write(data)
+		r, s, err := ecdsa.Sign(rand.Reader, signer.(*ecdsa.PrivateKey), h.Sum(nil))
+		if err != nil {
+			return nil, err
+		}
+		sig := appendMPInt(appendMPInt(nil, r), s)
+		return appendString(appendString(nil, []byte("ecdsa-sha2-"+curve)), sig), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %T", signer.Public())
+}
+
+// Parse the body of an unencrypted "openssh-key-v1" private key.
+func parseOpenSSHPrivateKey(data []byte) (crypto.Signer, error) {
+	const magic = "openssh-key-v1\x00"
+
+	if !bytes.HasPrefix(data, []byte(magic)) {
+		return nil, fmt.Errorf("invalid OpenSSH private key")
+	}
+	r := &wireReader{buf: data[len(magic):]}
+	cipher := string(r.string())
+	r.string() // kdf name
+	r.string() // kdf options
+	if r.uint32() != 1 {
+		return nil, fmt.Errorf("OpenSSH private key files with several keys are not supported")
+	}
+	r.string() // public key
+	r = &wireReader{buf: r.string()}
+	if r.err != nil || cipher != "none" {
+		return nil, fmt.Errorf("encrypted or invalid OpenSSH private key")
+	}
+	if r.uint32() != r.uint32() {
+		return nil, fmt.Errorf("invalid OpenSSH private key")
+	}
+
+	var key crypto.Signer
+	switch keyType := string(r.string()); keyType {
+	case "ssh-ed25519":
+		r.string() // public key
+		priv := r.string()
+		if len(priv) != ed25519.PrivateKeySize {
+			return nil, fmt.Errorf("invalid ed25519 private key")
+		}
+		key = ed25519.PrivateKey(priv)
+	case "ssh-rsa":
+		n, e, d := r.mpint(), r.mpint(), r.mpint()
+		r.mpint() // iqmp
+		p, q := r.mpint(), r.mpint()
+		rsaKey := &rsa.PrivateKey{
+			PublicKey: rsa.PublicKey{N: n, E: int(e.Int64())},
+			D:         d,
+			Primes:    []*big.Int{p, q},
+		}
+		if r.err == nil {
+			if err := rsaKey.Validate(); err != nil {
+				return nil, err
+			}
+			rsaKey.Precompute()
+		}
+		key = rsaKey
+	case "ecdsa-sha2-nistp256", "ecdsa-sha2-nistp384", "ecdsa-sha2-nistp521":
+		r.string() // curve name
+		r.string() // public point
+		d := r.mpint()
+		if r.err == nil {
+			return ecdsaPrivateKey(keyType, d)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", keyType)
+	}
+	if r.err != nil {
+		return nil, fmt.Errorf("invalid OpenSSH private key")
+	}
+	return key, nil
+}
+
+// Build an ECDSA private key from its scalar.
+func ecdsaPrivateKey(keyType string, d *big.Int) (*ecdsa.PrivateKey, error) {
+	var (
+		curve     elliptic.Curve
+		ecdhCurve ecdh.Curve
+	)
+
+	switch keyType {
+	case "ecdsa-sha2-nistp256":
+		curve, ecdhCurve = elliptic.P256(), ecdh.P256()
+	case "ecdsa-sha2-nistp384":
+		curve, ecdhCurve = elliptic.P384(), ecdh.P384()
+	default:
+		curve, ecdhCurve = elliptic.P521(), ecdh.P521()
+	}
+	size := (curve.Params().BitSize + 7) / 8
+	if len(d.Bytes()) > size {
+		return nil, fmt.Errorf("invalid ECDSA private key")
+	}
+	priv, err := ecdhCurve.NewPrivateKey(d.FillBytes(make([]byte, size)))
+	if err != nil {
+		return nil, err
+	}
+	point := priv.PublicKey().Bytes()
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(point[1 : 1+size]),
+			Y:     new(big.Int).SetBytes(point[1+size:]),
+		},
+		D: d,
+	}, nil
+}
+
+// wireReader reads SSH wire format values, recording the first error.
+type wireReader struct {
+	buf []byte
+	err error
+}
+
+func (r *wireReader) uint32() uint32 {
+	if r.err != nil || len(r.buf) < 4 {
+		r.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	v := binary.BigEndian.Uint32(r.buf)
+	r.buf = r.buf[4:]
+	return v
+}
+
+func (r *wireReader) string() []byte {
+	if r.err != nil {
+		return nil
+	}
+	s, rest, ok := readString(r.buf)
+	if !ok {
+		r.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	r.buf = rest
+	return s
+}
+
+func (r *wireReader) mpint() *big.Int {
+	return new(big.Int).SetBytes(r.string())
+}
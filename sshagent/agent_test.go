@@ -0,0 +1,269 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package sshagent
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// memStore is a minimal in-memory SecureStorage listing keys one level at a
+// time like Vault.
+type memStore struct {
+	mu   sync.Mutex
+	data map[string]map[string]interface{}
+}
+
+func newMemStore() *memStore {
+	return &memStore{data: map[string]map[string]interface{}{}}
+}
+
+func (ms *memStore) Store(key string, value interface{}) error {
+	var data map[string]interface{}
+	if err := mapstructure.Decode(value, &data); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.data[key] = data
+	return nil
+}
+
+func (ms *memStore) StoreWithData(key string, value interface{}, output interface{}) error {
+	return ms.Store(key, value)
+}
+
+func (ms *memStore) Lookup(key string, output interface{}) error {
+	ms.mu.Lock()
+	data, ok := ms.data[key]
+	ms.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return mapstructure.Decode(data, output)
+}
+
+func (ms *memStore) Delete(key string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delete(ms.data, key)
+	return nil
+}
+
+func (ms *memStore) LookupKeys(keyPath string) ([]string, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	var keys []string
+	for k := range ms.data {
+		if rest, ok := strings.CutPrefix(k, keyPath+"/"); ok && !strings.Contains(rest, "/") {
+			keys = append(keys, rest)
+		}
+	}
+	return keys, nil
+}
+
+func storeKey(t *testing.T, store *memStore, name string, key crypto.Signer) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	store.Store(DefaultPath+"/"+name, Key{
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		Comment:    name,
+	})
+	blob, err := marshalPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	return blob
+}
+
+// Send a request and read the reply.
+func request(t *testing.T, conn net.Conn, msg []byte) []byte {
+	_, err := conn.Write(append(binary.BigEndian.AppendUint32(nil, uint32(len(msg))), msg...))
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	var length uint32
+	binary.Read(conn, binary.BigEndian, &length)
+	reply := make([]byte, length)
+	_, err = io.ReadFull(conn, reply)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	return reply
+}
+
+func readMPInt(buf []byte) (*big.Int, []byte) {
+	b, rest, _ := readString(buf)
+	return new(big.Int).SetBytes(b), rest
+}
+
+func TestAgent(t *testing.T) {
+	store := newMemStore()
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	blobs := map[string][]byte{
+		"ed25519": storeKey(t, store, "ed25519", edKey),
+		"ecdsa":   storeKey(t, store, "ecdsa", ecKey),
+		"rsa":     storeKey(t, store, "rsa", rsaKey),
+	}
+	store.Store(DefaultPath+"/broken", Key{PrivateKey: "junk"})
+
+	a := New(store, DefaultPath)
+	var signed []string
+	a.OnSign = func(name string) { signed = append(signed, name) }
+	client, server := net.Pipe()
+	defer client.Close()
+	go a.ServeConn(server)
+
+	reply := request(t, client, []byte{agentRequestIdentities})
+	if reply[0] != agentIdentitiesAnswer || binary.BigEndian.Uint32(reply[1:]) != 3 {
+		t.Fatalf("Test Failed: Expected 3 identities but got %v", reply[:5])
+	}
+	rest := reply[5:]
+	for i := 0; i < 3; i++ {
+		var blob, comment []byte
+		blob, rest, _ = readString(rest)
+		comment, rest, _ = readString(rest)
+		if string(blobs[string(comment)]) != string(blob) {
+			t.Errorf("Test %v Failed: Unexpected public key for %s", i, comment)
+		}
+	}
+
+	data := []byte("session data")
+	tests := []struct {
+		name  string
+		flags uint32
+		algo  string
+	}{
+		{"ed25519", 0, "ssh-ed25519"},
+		{"ecdsa", 0, "ecdsa-sha2-nistp256"},
+		{"rsa", agentRSASHA256, "rsa-sha2-256"},
+		{"rsa", agentRSASHA512, "rsa-sha2-512"},
+	}
+	for i, test := range tests {
+		msg := appendString(appendString([]byte{agentSignRequest}, blobs[test.name]), data)
+		msg = binary.BigEndian.AppendUint32(msg, test.flags)
+		reply := request(t, client, msg)
+		if reply[0] != agentSignResponse {
+			t.Errorf("Test %v Failed: Expected signature but got %v", i, reply[0])
+			continue
+		}
+		sig, _, _ := readString(reply[1:])
+		algo, sig, _ := readString(sig)
+		blob, _, _ := readString(sig)
+		if string(algo) != test.algo {
+			t.Errorf("Test %v Failed: Expected %s but got %s", i, test.algo, algo)
+		}
+
+		var ok bool
+		switch test.algo {
+		case "ssh-ed25519":
+			ok = ed25519.Verify(edKey.Public().(ed25519.PublicKey), data, blob)
+		case "ecdsa-sha2-nistp256":
+			r, rest := readMPInt(blob)
+			s, _ := readMPInt(rest)
+			digest := sha256.Sum256(data)
+			ok = ecdsa.Verify(&ecKey.PublicKey, digest[:], r, s)
+		case "rsa-sha2-256":
+			digest := sha256.Sum256(data)
+			ok = rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, digest[:], blob) == nil
+		case "rsa-sha2-512":
+			digest := sha512.Sum512(data)
+			ok = rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA512, digest[:], blob) == nil
+		}
+		if !ok {
+			t.Errorf("Test %v Failed: Signature did not verify", i)
+		}
+	}
+	if len(signed) != len(tests) {
+		t.Errorf("Test Failed: Expected %d signatures to be reported but got %v", len(tests), signed)
+	}
+
+	// Rotated away keys can no longer sign; unsupported requests fail.
+	store.Delete(DefaultPath + "/ed25519")
+	msg := appendString(appendString([]byte{agentSignRequest}, blobs["ed25519"]), data)
+	for i, msg := range [][]byte{binary.BigEndian.AppendUint32(msg, 0), {17}} {
+		if reply := request(t, client, msg); reply[0] != agentFailure {
+			t.Errorf("Test %v Failed: Expected failure but got %v", i, reply[0])
+		}
+	}
+}
+
+func TestParseOpenSSHPrivateKey(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	body := binary.BigEndian.AppendUint32(nil, 42)
+	body = binary.BigEndian.AppendUint32(body, 42)
+	body = appendString(body, []byte("ssh-ed25519"))
+	body = appendString(body, pub)
+	body = appendString(body, priv)
+	body = appendString(body, []byte("comment"))
+	build := func(cipher string, body []byte) string {
+		data := []byte("openssh-key-v1\x00")
+		data = appendString(data, []byte(cipher))
+		data = appendString(data, []byte("none"))
+		data = appendString(data, nil)
+		data = binary.BigEndian.AppendUint32(data, 1)
+		data = appendString(data, nil)
+		data = appendString(data, body)
+		return string(pem.EncodeToMemory(&pem.Block{Type: "OPENSSH PRIVATE KEY", Bytes: data}))
+	}
+
+	tests := []struct {
+		pem string
+		ok  bool
+	}{
+		{build("none", body), true},
+		{build("aes256-ctr", body), false},
+		{build("none", body[:20]), false},
+		{build("none", append([]byte{0, 0, 0, 1}, body[4:]...)), false},
+	}
+	for i, test := range tests {
+		key, err := parsePrivateKey(test.pem)
+		if test.ok && (err != nil || !priv.Equal(key)) {
+			t.Errorf("Test %v Failed: Expected the ed25519 key but got %v", i, err)
+		}
+		if !test.ok && err == nil {
+			t.Errorf("Test %v Failed: Expected an error", i)
+		}
+	}
+}
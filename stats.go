@@ -0,0 +1,173 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StoreStats summarizes the contents of a store. Bytes is the total length
+// of the JSON encoding of every value. LastWrite and Errors only cover
+// operations seen by the process collecting the statistics.
+type StoreStats struct {
+	Backend   string    `json:"backend"`
+	Keys      int       `json:"keys"`
+	Bytes     int64     `json:"bytes"`
+	LastWrite time.Time `json:"last_write,omitempty"`
+	Errors    uint64    `json:"errors"`
+}
+
+// StatsSource is implemented by stores that can report StoreStats.
+type StatsSource interface {
+	Stats() (StoreStats, error)
+}
+
+// Count the keys below keyPath and the size of their values. Every value is
+// read, so this is as expensive as a full export.
+func CollectStats(ss SecureStorage, keyPath string) (StoreStats, error) {
+	var stats StoreStats
+
+	it := IterateKeys(ss, keyPath)
+	defer it.Close()
+	for it.Next() {
+		var data map[string]interface{}
+		err := ss.Lookup(it.Key(), &data)
+		if err != nil {
+			return stats, err
+		}
+		size, err := valueSize(data)
+		if err != nil {
+			return stats, err
+		}
+		stats.Keys++
+		stats.Bytes += size
+	}
+	return stats, it.Err()
+}
+
+// Get the statistics for the wrapped store, adding the time of the last
+// successful write and the number of failed operations recorded for
+// Backend. The lookups made to count the keys are not recorded.
+func (ia *InstrumentedAdapter) Stats() (StoreStats, error) {
+	stats, err := CollectStats(ia.Inner, "")
+	if err != nil {
+		return stats, err
+	}
+	stats.Backend = ia.Backend
+	ia.mu.Lock()
+	stats.LastWrite = ia.lastWrite
+	ia.mu.Unlock()
+	for _, om := range ia.Metrics.Snapshot() {
+		if om.Backend == ia.Backend {
+			stats.Errors += om.Errors
+		}
+	}
+	return stats, nil
+}
+
+// StatsExporter is an http.Handler serving the statistics of one or more
+// stores in the Prometheus text format, or as JSON when requested with
+// "?format=json" or an "Accept: application/json" header. Collecting the
+// statistics reads every value, so results are reused for MaxAge.
+type StatsExporter struct {
+	Sources []StatsSource
+	MaxAge  time.Duration
+
+	mu        sync.Mutex
+	collected time.Time
+	stats     []StoreStats
+	now       func() time.Time
+}
+
+// Create a new StatsExporter collecting from sources at most once a minute.
+func NewStatsExporter(sources ...StatsSource) *StatsExporter {
+	return &StatsExporter{
+		Sources: sources,
+		MaxAge:  time.Minute,
+		now:     time.Now,
+	}
+}
+
+func (se *StatsExporter) collect() ([]StoreStats, error) {
+	se.mu.Lock()
+	defer se.mu.Unlock()
+	now := se.now()
+	if se.stats != nil && now.Sub(se.collected) < se.MaxAge {
+		return se.stats, nil
+	}
+	stats := make([]StoreStats, 0, len(se.Sources))
+	for _, source := range se.Sources {
+		s, err := source.Stats()
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+	se.stats = stats
+	se.collected = now
+	return stats, nil
+}
+
+func (se *StatsExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	stats, err := se.collect()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Unable to collect statistics: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(stats)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	writeStatsPrometheus(w, stats)
+}
+
+func writeStatsPrometheus(w http.ResponseWriter, stats []StoreStats) {
+	metric := func(name string, kind string, help string, value func(s StoreStats) string) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+		for _, s := range stats {
+			fmt.Fprintf(w, "%s{backend=%q} %s\n", name, s.Backend, value(s))
+		}
+	}
+	metric("securestorage_keys", "gauge", "Number of keys stored.", func(s StoreStats) string {
+		return fmt.Sprint(s.Keys)
+	})
+	metric("securestorage_bytes", "gauge", "Total size of the stored values.", func(s StoreStats) string {
+		return fmt.Sprint(s.Bytes)
+	})
+	metric("securestorage_last_write_timestamp_seconds", "gauge", "Time of the last successful write.", func(s StoreStats) string {
+		if s.LastWrite.IsZero() {
+			return "0"
+		}
+		return fmt.Sprint(s.LastWrite.Unix())
+	})
+	metric("securestorage_errors_total", "counter", "Number of failed operations.", func(s StoreStats) string {
+		return fmt.Sprint(s.Errors)
+	})
+}
@@ -0,0 +1,85 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatsExporter(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	metrics := NewMetrics(nil)
+	vault := Instrumented(newMemStore(), metrics, "vault")
+	vault.now = func() time.Time { return now }
+	failing := Instrumented(&failStore{err: errors.New("Code: 503")}, metrics, "broken")
+
+	vault.Store("x0c0s1b0", map[string]string{"Password": "short"})
+	vault.Store("nodes/x0c0s2b0", map[string]string{"Password": "short"})
+	failing.Store("x0c0s1b0", creds{})
+
+	se := NewStatsExporter(vault)
+	se.now = func() time.Time { return now }
+
+	rec := httptest.NewRecorder()
+	se.ServeHTTP(rec, httptest.NewRequest("GET", "/stats?format=json", nil))
+	var stats []StoreStats
+	json.NewDecoder(rec.Body).Decode(&stats)
+	expected := StoreStats{Backend: "vault", Keys: 2, Bytes: 40, LastWrite: now}
+	if len(stats) != 1 || stats[0] != expected {
+		t.Errorf("Expected stats %+v but got %+v", expected, stats)
+	}
+
+	// Results are reused until MaxAge has passed.
+	vault.Store("x0c0s3b0", map[string]string{"Password": "short"})
+	rec = httptest.NewRecorder()
+	se.ServeHTTP(rec, httptest.NewRequest("GET", "/stats", nil))
+	if !strings.Contains(rec.Body.String(), `securestorage_keys{backend="vault"} 2`) {
+		t.Errorf("Expected cached key count but got:\n%s", rec.Body.String())
+	}
+	now = now.Add(2 * time.Minute)
+	rec = httptest.NewRecorder()
+	se.ServeHTTP(rec, httptest.NewRequest("GET", "/stats", nil))
+	for _, line := range []string{
+		`securestorage_keys{backend="vault"} 3`,
+		`securestorage_bytes{backend="vault"} 60`,
+		`securestorage_errors_total{backend="vault"} 0`,
+	} {
+		if !strings.Contains(rec.Body.String(), line) {
+			t.Errorf("Expected %q in:\n%s", line, rec.Body.String())
+		}
+	}
+
+	if _, err := failing.Stats(); err == nil {
+		t.Errorf("Expected an error collecting stats from a failing store")
+	}
+	for _, om := range metrics.Snapshot() {
+		if om.Backend == "broken" && om.Errors != 1 {
+			t.Errorf("Expected 1 error for the broken backend but got %d", om.Errors)
+		}
+	}
+}
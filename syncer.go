@@ -0,0 +1,263 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ConflictPolicy decides what a Syncer does with a key that was changed in
+// the target since it was last synced.
+type ConflictPolicy int
+
+const (
+	// Overwrite the target with the source value.
+	SourceWins ConflictPolicy = iota
+	// Leave the target value in place.
+	TargetWins
+)
+
+// DriftReport lists the differences a Syncer found between its stores.
+type DriftReport struct {
+	Time time.Time
+	// Keys copied to the target because they were not there.
+	Created []string
+	// Keys whose source value changed and was copied to the target.
+	Updated []string
+	// Keys removed from the target because they were removed from the
+	// source.
+	Deleted []string
+	// Keys changed in the target since they were last synced. They were
+	// resolved according to the Syncer's Policy.
+	Conflicts []string
+	// Keys in the target that were never in the source. They are left in
+	// place.
+	Extra []string
+	// Keys that could not be read or written, with the reason.
+	Failed map[string]error
+}
+
+// In sync if nothing had to be changed and nothing failed.
+func (dr *DriftReport) InSync() bool {
+	return len(dr.Created)+len(dr.Updated)+len(dr.Deleted)+len(dr.Conflicts)+
+		len(dr.Extra)+len(dr.Failed) == 0
+}
+
+// Syncer keeps every key below a prefix mirrored from one SecureStorage to
+// another, for example from Vault to a local store used as a fallback when
+// Vault is unreachable. Unlike Migrator it runs repeatedly and remembers
+// what it last wrote to each target key, so it can tell a source change
+// from a change made directly in the target.
+type Syncer struct {
+	Source SecureStorage
+	Target SecureStorage
+	Prefix string
+	Policy ConflictPolicy
+	// Delete target keys that were synced before but are no longer in the
+	// source.
+	Prune bool
+	// Optional. Called with the report of every run started by Start().
+	OnReport func(report *DriftReport)
+
+	mu     sync.Mutex
+	synced map[string]string
+	last   *DriftReport
+	stop   chan struct{}
+	done   chan struct{}
+	now    func() time.Time
+}
+
+// Create a new Syncer mirroring prefix from source to target.
+func NewSyncer(source SecureStorage, target SecureStorage, prefix string) *Syncer {
+	return &Syncer{
+		Source: source,
+		Target: target,
+		Prefix: prefix,
+		synced: map[string]string{},
+		now:    time.Now,
+	}
+}
+
+func listAll(ss SecureStorage, prefix string) (map[string]bool, error) {
+	keys := map[string]bool{}
+	it := IterateKeys(ss, prefix)
+	defer it.Close()
+	for it.Next() {
+		keys[it.Key()] = true
+	}
+	return keys, it.Err()
+}
+
+// Read and encode key. An empty string means the key does not exist.
+func encodedValue(ss SecureStorage, key string) (string, error) {
+	var data map[string]interface{}
+
+	err := ss.Lookup(key, &data)
+	if err != nil || data == nil {
+		return "", err
+	}
+	encoded, err := json.Marshal(data)
+	return string(encoded), err
+}
+
+// Bring the target in line with the source once. An error is returned if
+// either store could not be listed or any key failed; the report is
+// returned in every case but the first.
+func (s *Syncer) SyncOnce() (*DriftReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sourceKeys, err := listAll(s.Source, s.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to list source keys below %s: %v", s.Prefix, err)
+	}
+	targetKeys, err := listAll(s.Target, s.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to list target keys below %s: %v", s.Prefix, err)
+	}
+
+	report := &DriftReport{Time: s.now(), Failed: map[string]error{}}
+	for key := range sourceKeys {
+		s.syncKey(key, report)
+	}
+	for key := range targetKeys {
+		if sourceKeys[key] {
+			continue
+		}
+		_, synced := s.synced[key]
+		if !synced || !s.Prune {
+			report.Extra = append(report.Extra, key)
+			continue
+		}
+		err := s.Target.Delete(key)
+		if err != nil {
+			report.Failed[key] = err
+			continue
+		}
+		delete(s.synced, key)
+		report.Deleted = append(report.Deleted, key)
+	}
+
+	for _, keys := range [][]string{report.Created, report.Updated, report.Deleted, report.Conflicts, report.Extra} {
+		sort.Strings(keys)
+	}
+	s.last = report
+	if len(report.Failed) > 0 {
+		return report, fmt.Errorf("Sync of %s incomplete: %d keys failed", s.Prefix, len(report.Failed))
+	}
+	return report, nil
+}
+
+// Sync a key present in the source. Must be called with s.mu held.
+func (s *Syncer) syncKey(key string, report *DriftReport) {
+	source, err := encodedValue(s.Source, key)
+	if err != nil {
+		report.Failed[key] = err
+		return
+	}
+	target, err := encodedValue(s.Target, key)
+	if err != nil {
+		report.Failed[key] = err
+		return
+	}
+	if source == "" || source == target {
+		if source != "" {
+			s.synced[key] = source
+		}
+		return
+	}
+
+	base, synced := s.synced[key]
+	switch {
+	case target == "" && !synced:
+		report.Created = append(report.Created, key)
+	case target == base:
+		report.Updated = append(report.Updated, key)
+	default:
+		report.Conflicts = append(report.Conflicts, key)
+		if s.Policy == TargetWins {
+			return
+		}
+	}
+
+	var data map[string]interface{}
+	err = json.Unmarshal([]byte(source), &data)
+	if err == nil {
+		err = s.Target.Store(key, data)
+	}
+	if err != nil {
+		report.Failed[key] = err
+		return
+	}
+	s.synced[key] = source
+}
+
+// Get the report of the most recent run, or nil if there has not been one.
+func (s *Syncer) LastReport() *DriftReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.last
+}
+
+// Call SyncOnce() every interval until Stop() is called.
+func (s *Syncer) Start(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go func(stop chan struct{}, done chan struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			report, _ := s.SyncOnce()
+			if report != nil && s.OnReport != nil {
+				s.OnReport(report)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}(s.stop, s.done)
+}
+
+// Stop a Syncer started with Start() and wait for it to finish.
+func (s *Syncer) Stop() {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop, s.done = nil, nil
+	s.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
@@ -0,0 +1,96 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSyncer(t *testing.T) {
+	source := newMemStore()
+	target := newMemStore()
+	source.Store("hms-creds/x0c0s1b0", creds{Xname: "x0c0s1b0", Password: "one"})
+	source.Store("hms-creds/x0c0s2b0", creds{Xname: "x0c0s2b0", Password: "two"})
+	target.Store("hms-creds/x0c0s9b0", creds{Xname: "x0c0s9b0"})
+
+	s := NewSyncer(source, target, "hms-creds")
+	s.Prune = true
+
+	tests := []struct {
+		policy ConflictPolicy
+		change func()
+		expect DriftReport
+		value  string
+	}{
+		{
+			change: func() {},
+			expect: DriftReport{Created: []string{"hms-creds/x0c0s1b0", "hms-creds/x0c0s2b0"}, Extra: []string{"hms-creds/x0c0s9b0"}},
+			value:  "one",
+		},
+		{
+			change: func() { source.Store("hms-creds/x0c0s1b0", creds{Password: "new"}) },
+			expect: DriftReport{Updated: []string{"hms-creds/x0c0s1b0"}, Extra: []string{"hms-creds/x0c0s9b0"}},
+			value:  "new",
+		},
+		{
+			policy: TargetWins,
+			change: func() { target.Store("hms-creds/x0c0s1b0", creds{Password: "local"}) },
+			expect: DriftReport{Conflicts: []string{"hms-creds/x0c0s1b0"}, Extra: []string{"hms-creds/x0c0s9b0"}},
+			value:  "local",
+		},
+		{
+			policy: SourceWins,
+			change: func() { source.Delete("hms-creds/x0c0s2b0") },
+			expect: DriftReport{Deleted: []string{"hms-creds/x0c0s2b0"}, Conflicts: []string{"hms-creds/x0c0s1b0"}, Extra: []string{"hms-creds/x0c0s9b0"}},
+			value:  "new",
+		},
+	}
+
+	for i, test := range tests {
+		test.change()
+		s.Policy = test.policy
+		report, err := s.SyncOnce()
+		if err != nil {
+			t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		test.expect.Time = report.Time
+		test.expect.Failed = map[string]error{}
+		if !reflect.DeepEqual(*report, test.expect) {
+			t.Errorf("Test %v Failed: Expected %+v but got %+v", i, test.expect, *report)
+		}
+		var got creds
+		target.Lookup("hms-creds/x0c0s1b0", &got)
+		if got.Password != test.value {
+			t.Errorf("Test %v Failed: Expected target value %s but got %s", i, test.value, got.Password)
+		}
+	}
+
+	report, err := s.SyncOnce()
+	if err != nil || len(report.Extra) != 1 || report.InSync() {
+		t.Errorf("Test Failed: Expected only the extra key to remain but got %+v (%v)", report, err)
+	}
+	if s.LastReport() != report {
+		t.Errorf("Test Failed: Expected LastReport to return the last report")
+	}
+}
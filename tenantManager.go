@@ -0,0 +1,284 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrTenantExists is returned (wrapped with the name) when creating a tenant
+// that already exists.
+var ErrTenantExists = errors.New("tenant already exists")
+
+// Default key paths used by TenantManager
+const (
+	DefaultTenantPath         = "tenants"
+	DefaultTenantRegistryPath = ".tenant-registry"
+)
+
+// TenantInfo describes a tenant known to a TenantManager.
+type TenantInfo struct {
+	Name        string
+	CreatedTime time.Time
+	Encrypted   bool
+}
+
+type tenantEntry struct {
+	CreatedTime string `mapstructure:"created_time"`
+	// Tenant key sealed under the manager's master key, if encrypted.
+	WrappedKey string `mapstructure:"wrapped_key"`
+}
+
+// TenantManager divides a shared SecureStorage between tenants. Each tenant
+// gets a store scoped to its own key path and, optionally, its own
+// encryption key. Tenant keys are kept in the registry sealed with
+// AES-256-GCM under the manager's master key, so the KeyProvider is required
+// for encrypted tenants.
+type TenantManager struct {
+	Store        SecureStorage
+	Keys         KeyProvider
+	Path         string
+	RegistryPath string
+
+	mu  sync.Mutex
+	now func() time.Time
+}
+
+// Create a new TenantManager on store. keyProvider may be nil if no tenant
+// is encrypted.
+func NewTenantManager(store SecureStorage, keyProvider KeyProvider) *TenantManager {
+	return &TenantManager{
+		Store:        store,
+		Keys:         keyProvider,
+		Path:         DefaultTenantPath,
+		RegistryPath: DefaultTenantRegistryPath,
+		now:          time.Now,
+	}
+}
+
+// sharedStore hides Close() so closing a tenant's store leaves the store
+// shared by every tenant open.
+type sharedStore struct {
+	SecureStorage
+}
+
+func (ss sharedStore) Close() error {
+	return nil
+}
+
+func (ss sharedStore) Health() error {
+	return Health(ss.SecureStorage)
+}
+
+func checkTenantName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.Contains(name, "/") {
+		return fmt.Errorf("Invalid tenant name %q", name)
+	}
+	return nil
+}
+
+func (tm *TenantManager) lookupTenant(name string) (*tenantEntry, error) {
+	var entry *tenantEntry
+
+	err := checkTenantName(name)
+	if err != nil {
+		return nil, err
+	}
+	err = tm.Store.Lookup(joinKey(tm.RegistryPath, name), &entry)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("%w: tenant %s", ErrNotFound, name)
+	}
+	return entry, nil
+}
+
+// Register a new tenant and get its store. An encrypted tenant gets a new
+// random encryption key.
+func (tm *TenantManager) CreateTenant(name string, encrypted bool) (SecureStorageV2, error) {
+	err := checkTenantName(name)
+	if err != nil {
+		return nil, err
+	}
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if _, err := tm.lookupTenant(name); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTenantExists, name)
+	} else if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	entry := &tenantEntry{CreatedTime: tm.now().UTC().Format(time.RFC3339Nano)}
+	if encrypted {
+		if tm.Keys == nil {
+			return nil, fmt.Errorf("Cannot create encrypted tenant %s without a KeyProvider", name)
+		}
+		tenantKey, err := GenerateMasterKey()
+		if err != nil {
+			return nil, err
+		}
+		masterKey, err := tm.Keys.MasterKey()
+		if err != nil {
+			return nil, err
+		}
+		provider, err := currentCrypto()
+		if err != nil {
+			return nil, err
+		}
+		sealed, err := seal(provider, masterKey, tenantKey, []byte(name))
+		if err != nil {
+			return nil, err
+		}
+		entry.WrappedKey = base64.StdEncoding.EncodeToString(sealed)
+	}
+	err = tm.Store.Store(joinKey(tm.RegistryPath, name), entry)
+	if err != nil {
+		return nil, err
+	}
+	return tm.tenantStore(name, entry)
+}
+
+// Get the store of an existing tenant.
+func (tm *TenantManager) Tenant(name string) (SecureStorageV2, error) {
+	entry, err := tm.lookupTenant(name)
+	if err != nil {
+		return nil, err
+	}
+	return tm.tenantStore(name, entry)
+}
+
+func (tm *TenantManager) tenantStore(name string, entry *tenantEntry) (SecureStorageV2, error) {
+	scoped := WithPrefix(sharedStore{tm.Store}, joinKey(tm.Path, name))
+	if entry.WrappedKey == "" {
+		return scoped, nil
+	}
+	if tm.Keys == nil {
+		return nil, fmt.Errorf("Cannot open encrypted tenant %s without a KeyProvider", name)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(entry.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot decode key for tenant %s: %v", name, err)
+	}
+	masterKey, err := tm.Keys.MasterKey()
+	if err != nil {
+		return nil, err
+	}
+	provider, err := currentCrypto()
+	if err != nil {
+		return nil, err
+	}
+	tenantKey, err := unseal(provider, masterKey, sealed, []byte(name))
+	if err != nil {
+		return nil, fmt.Errorf("Cannot decrypt key for tenant %s: %v", name, err)
+	}
+	kp, err := NewStaticKeyProvider(tenantKey.Bytes())
+	tenantKey.Destroy()
+	if err != nil {
+		return nil, err
+	}
+	return Encrypted(scoped, kp), nil
+}
+
+// List the registered tenants, sorted by name.
+func (tm *TenantManager) Tenants() ([]TenantInfo, error) {
+	names, err := tm.Store.LookupKeys(tm.RegistryPath)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	var tenants []TenantInfo
+	for _, name := range names {
+		entry, err := tm.lookupTenant(name)
+		if err != nil {
+			return nil, err
+		}
+		created, _ := time.Parse(time.RFC3339Nano, entry.CreatedTime)
+		tenants = append(tenants, TenantInfo{
+			Name:        name,
+			CreatedTime: created,
+			Encrypted:   entry.WrappedKey != "",
+		})
+	}
+	return tenants, nil
+}
+
+// Read every value stored by a tenant, decrypted, keyed by the tenant's own
+// key names.
+func (tm *TenantManager) ExportTenant(name string) (map[string]map[string]interface{}, error) {
+	store, err := tm.Tenant(name)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	export := map[string]map[string]interface{}{}
+	it := IterateKeys(store, "")
+	defer it.Close()
+	for it.Next() {
+		var data map[string]interface{}
+		err := store.Lookup(it.Key(), &data)
+		if err != nil {
+			return nil, err
+		}
+		if data != nil {
+			export[it.Key()] = data
+		}
+	}
+	return export, it.Err()
+}
+
+// Delete every value stored by a tenant and then the tenant itself. The
+// tenant's encryption key goes with it, so any copies of its values left
+// elsewhere can no longer be decrypted.
+func (tm *TenantManager) DeleteTenant(name string) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if _, err := tm.lookupTenant(name); err != nil {
+		return err
+	}
+	scoped := WithPrefix(sharedStore{tm.Store}, joinKey(tm.Path, name))
+	var keys []string
+	it := IterateKeys(scoped, "")
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	err := it.Err()
+	it.Close()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		err := scoped.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+	return tm.Store.Delete(joinKey(tm.RegistryPath, name))
+}
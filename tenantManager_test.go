@@ -0,0 +1,103 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTenantManager(t *testing.T) {
+	key, _ := GenerateMasterKey()
+	kp, _ := NewStaticKeyProvider(key)
+	store := newMemStore()
+	tm := NewTenantManager(store, kp)
+
+	var tests = []struct {
+		name      string
+		encrypted bool
+		respErr   bool
+	}{
+		{name: "site-a", encrypted: false},
+		{name: "site-b", encrypted: true},
+		{name: "site-a", respErr: true},
+		{name: "../site-c", respErr: true},
+	}
+	for i, test := range tests {
+		ts, err := tm.CreateTenant(test.name, test.encrypted)
+		if test.respErr {
+			if err == nil {
+				t.Errorf("Test %v Failed: Expected an error.", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		ts.Store("x0c0s1b0", creds{Username: "root", Password: test.name})
+		ts.Store("nodes/x0c0s2b0", creds{Username: "root", Password: test.name})
+		ts.Close()
+	}
+
+	// Each tenant only sees its own values and the encrypted one is not
+	// stored in the clear.
+	for _, name := range []string{"site-a", "site-b"} {
+		ts, err := tm.Tenant(name)
+		if err != nil {
+			t.Fatalf("Unexpected error - %v", err)
+		}
+		var r creds
+		ts.Lookup("x0c0s1b0", &r)
+		if r.Password != name {
+			t.Errorf("Expected password %s but got %s", name, r.Password)
+		}
+	}
+	var raw map[string]interface{}
+	store.Lookup("tenants/site-b/x0c0s1b0", &raw)
+	if raw["alg"] != AlgAES256GCM {
+		t.Errorf("Expected encrypted tenant values to be encrypted but got %v", raw)
+	}
+
+	tenants, err := tm.Tenants()
+	if err != nil || len(tenants) != 2 || tenants[0].Encrypted || !tenants[1].Encrypted {
+		t.Errorf("Expected 2 tenants but got %+v (%v)", tenants, err)
+	}
+
+	export, err := tm.ExportTenant("site-b")
+	if err != nil || len(export) != 2 || export["nodes/x0c0s2b0"]["Password"] != "site-b" {
+		t.Errorf("Expected 2 decrypted values but got %v (%v)", export, err)
+	}
+
+	if err := tm.DeleteTenant("site-b"); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	for key := range store.data {
+		if strings.Contains(key, "site-b") {
+			t.Errorf("Expected every site-b key to be deleted but found %s", key)
+		}
+	}
+	if _, err := tm.Tenant("site-b"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a deleted tenant but got %v", err)
+	}
+}
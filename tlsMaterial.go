@@ -0,0 +1,273 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Field names used by Vault's PKI secrets engine, and the defaults for
+// TLSMaterial.
+const (
+	DefaultCertField = "certificate"
+	DefaultKeyField  = "private_key"
+	DefaultCAField   = "issuing_ca"
+)
+
+// TLSMaterial loads a certificate, private key and CA bundle from a store
+// and builds *tls.Config values that always use the latest ones, so a
+// rotated certificate is picked up without restarting.
+//
+// By default the PEM encoded material is read from the fields of the
+// secret at Key. If IssueRequest is set, a new certificate is instead
+// requested by writing it to Key with StoreWithData(), as with Vault's
+// pki/issue/<role> endpoint, and requested again once a third of its
+// lifetime is left.
+type TLSMaterial struct {
+	Store        SecureStorage
+	Key          string
+	CertField    string
+	KeyField     string
+	CAField      string
+	IssueRequest map[string]interface{}
+
+	mu    sync.RWMutex
+	cert  *tls.Certificate
+	pool  *x509.CertPool
+	stop  chan struct{}
+	done  chan struct{}
+	now   func() time.Time
+	issue time.Time
+}
+
+// Create a new TLSMaterial for the secret at key and load it.
+func NewTLSMaterial(store SecureStorage, key string) (*TLSMaterial, error) {
+	tm := &TLSMaterial{
+		Store:     store,
+		Key:       key,
+		CertField: DefaultCertField,
+		KeyField:  DefaultKeyField,
+		CAField:   DefaultCAField,
+		now:       time.Now,
+	}
+	return tm, tm.Reload()
+}
+
+// Create a new TLSMaterial issuing certificates by writing request to the
+// PKI endpoint at key, and issue the first one.
+func NewIssuedTLSMaterial(store SecureStorage, key string, request map[string]interface{}) (*TLSMaterial, error) {
+	tm := &TLSMaterial{
+		Store:        store,
+		Key:          key,
+		CertField:    DefaultCertField,
+		KeyField:     DefaultKeyField,
+		CAField:      DefaultCAField,
+		IssueRequest: request,
+		now:          time.Now,
+	}
+	return tm, tm.Reload()
+}
+
+// Join a PEM field that may be a single string or, like ca_chain, a list.
+func pemField(data map[string]interface{}, field string) string {
+	switch v := data[field].(type) {
+	case string:
+		return v
+	case []interface{}:
+		var parts []string
+		for _, part := range v {
+			if s, ok := part.(string); ok {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, "\n")
+	}
+	return ""
+}
+
+// Read or issue the material and replace the current one. The current
+// material is kept if anything fails.
+func (tm *TLSMaterial) Reload() error {
+	var (
+		err  error
+		data map[string]interface{}
+	)
+
+	if tm.IssueRequest != nil {
+		var resp struct {
+			Data map[string]interface{}
+		}
+		err = tm.Store.StoreWithData(tm.Key, tm.IssueRequest, &resp)
+		data = resp.Data
+	} else {
+		err = tm.Store.Lookup(tm.Key, &data)
+	}
+	if err != nil {
+		return fmt.Errorf("Unable to read TLS material from %s: %v", tm.Key, err)
+	}
+	if data == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, tm.Key)
+	}
+
+	cert, err := tls.X509KeyPair([]byte(pemField(data, tm.CertField)), []byte(pemField(data, tm.KeyField)))
+	if err != nil {
+		return fmt.Errorf("Invalid certificate or key in %s: %v", tm.Key, err)
+	}
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("Invalid certificate in %s: %v", tm.Key, err)
+	}
+	var pool *x509.CertPool
+	if ca := pemField(data, tm.CAField); ca != "" {
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(ca)) {
+			return fmt.Errorf("Invalid CA bundle in %s", tm.Key)
+		}
+	}
+
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.cert = &cert
+	tm.pool = pool
+	tm.issue = tm.now()
+	return nil
+}
+
+// Get the current certificate.
+func (tm *TLSMaterial) Certificate() *tls.Certificate {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	return tm.cert
+}
+
+// Get the current CA pool, or nil if the secret has no CA bundle.
+func (tm *TLSMaterial) CertPool() *x509.CertPool {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	return tm.pool
+}
+
+// Whether an issued certificate has less than a third of its lifetime left.
+func (tm *TLSMaterial) needsRenewal() bool {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	if tm.cert == nil {
+		return true
+	}
+	lifetime := tm.cert.Leaf.NotAfter.Sub(tm.issue)
+	return tm.cert.Leaf.NotAfter.Sub(tm.now()) < lifetime/3
+}
+
+// Build a client configuration presenting the current certificate and
+// verifying servers against the current CA bundle, or the system roots if
+// there is none.
+func (tm *TLSMaterial) ClientConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return tm.Certificate(), nil
+		},
+		// Verification is done in VerifyConnection so that the CA pool in
+		// use is always the current one.
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			opts := x509.VerifyOptions{
+				DNSName:       cs.ServerName,
+				Roots:         tm.CertPool(),
+				Intermediates: x509.NewCertPool(),
+			}
+			for _, cert := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(cert)
+			}
+			_, err := cs.PeerCertificates[0].Verify(opts)
+			return err
+		},
+	}
+}
+
+// Build a server configuration presenting the current certificate. If the
+// secret has a CA bundle, client certificates signed by it are required.
+func (tm *TLSMaterial) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*tm.Certificate()},
+			}
+			if pool := tm.CertPool(); pool != nil {
+				config.ClientCAs = pool
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return config, nil
+		},
+	}
+}
+
+// Check for new material every interval until Stop() is called. Issued
+// certificates are only renewed when they near expiry. Errors are passed
+// to onError if it is not nil.
+func (tm *TLSMaterial) Start(interval time.Duration, onError func(err error)) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if tm.stop != nil {
+		return
+	}
+	tm.stop = make(chan struct{})
+	tm.done = make(chan struct{})
+	go func(stop chan struct{}, done chan struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			if tm.IssueRequest != nil && !tm.needsRenewal() {
+				continue
+			}
+			if err := tm.Reload(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}(tm.stop, tm.done)
+}
+
+// Stop a TLSMaterial started with Start() and wait for it to finish.
+func (tm *TLSMaterial) Stop() {
+	tm.mu.Lock()
+	stop, done := tm.stop, tm.done
+	tm.stop, tm.done = nil, nil
+	tm.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
@@ -0,0 +1,180 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type testPKI struct {
+	ca    *x509.Certificate
+	caKey *ecdsa.PrivateKey
+	caPEM string
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	pki := &testPKI{}
+	pki.ca, pki.caKey, pki.caPEM, _ = pki.issue(t, "ca", true)
+	return pki
+}
+
+// Issue a certificate signed by the CA, or a self-signed CA if isCA is set.
+func (pki *testPKI) issue(t *testing.T, cn string, isCA bool) (*x509.Certificate, *ecdsa.PrivateKey, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		IsCA:         isCA,
+
+		BasicConstraintsValid: true,
+	}
+	signer, signerKey := tmpl, key
+	if !isCA {
+		signer, signerKey = pki.ca, pki.caKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	cert, _ := x509.ParseCertificate(der)
+	return cert, key, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func (pki *testPKI) secret(t *testing.T, cn string) map[string]interface{} {
+	_, _, certPEM, keyPEM := pki.issue(t, cn, false)
+	return map[string]interface{}{
+		DefaultCertField: certPEM,
+		DefaultKeyField:  keyPEM,
+		DefaultCAField:   pki.caPEM,
+		"ca_chain":       []interface{}{pki.caPEM},
+	}
+}
+
+// issuingStore answers StoreWithData like Vault's PKI issue endpoint.
+type issuingStore struct {
+	*memStore
+	pki    *testPKI
+	t      *testing.T
+	issued int
+}
+
+func (is *issuingStore) StoreWithData(key string, value interface{}, output interface{}) error {
+	is.issued++
+	return decodeValue(map[string]interface{}{"Data": is.pki.secret(is.t, "issued")}, output)
+}
+
+func TestTLSMaterial(t *testing.T) {
+	pki := newTestPKI(t)
+	store := newMemStore()
+	store.Store("tls/server", pki.secret(t, "server-1"))
+	store.Store("tls/client", pki.secret(t, "client"))
+
+	server, err := NewTLSMaterial(store, "tls/server")
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	server.CAField = "ca_chain"
+	server.Reload()
+	client, err := NewTLSMaterial(store, "tls/client")
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	client.CAField = "ca_chain"
+	client.Reload()
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	ts.TLS = server.ServerConfig()
+	ts.StartTLS()
+	defer ts.Close()
+
+	for i, expected := range []string{"server-1", "server-2"} {
+		if i > 0 {
+			store.Store("tls/server", pki.secret(t, expected))
+			if err := server.Reload(); err != nil {
+				t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+			}
+		}
+		hc := &http.Client{Transport: &http.Transport{TLSClientConfig: client.ClientConfig()}}
+		resp, err := hc.Get(ts.URL)
+		if err != nil {
+			t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		resp.Body.Close()
+		if cn := resp.TLS.PeerCertificates[0].Subject.CommonName; cn != expected {
+			t.Errorf("Test %v Failed: Expected server certificate %s but got %s", i, expected, cn)
+		}
+	}
+
+	// A bad secret leaves the current material in place.
+	store.Store("tls/server", map[string]interface{}{DefaultCertField: "junk"})
+	if err := server.Reload(); err == nil {
+		t.Errorf("Test Failed: Expected error reloading invalid material")
+	}
+	if server.Certificate().Leaf.Subject.CommonName != "server-2" {
+		t.Errorf("Test Failed: Expected previous certificate to be kept")
+	}
+}
+
+func TestIssuedTLSMaterial(t *testing.T) {
+	store := &issuingStore{memStore: newMemStore(), pki: newTestPKI(t), t: t}
+	tm, err := NewIssuedTLSMaterial(store, "issue/node", map[string]interface{}{"common_name": "issued"})
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	if tm.CertPool() == nil || tm.Certificate().Leaf.Subject.CommonName != "issued" {
+		t.Errorf("Test Failed: Expected issued certificate and CA")
+	}
+
+	now := time.Now()
+	tm.now = func() time.Time { return now }
+	if tm.needsRenewal() {
+		t.Errorf("Test Failed: Expected new certificate not to need renewal")
+	}
+	now = now.Add(45 * time.Minute)
+	if !tm.needsRenewal() {
+		t.Errorf("Test Failed: Expected certificate near expiry to need renewal")
+	}
+}
@@ -0,0 +1,185 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"time"
+)
+
+// TTLStore is implemented by SecureStorage backends that can expire keys.
+// Expired keys behave as if they were never stored.
+type TTLStore interface {
+	// Store a value that expires after ttl. A ttl <= 0 never expires.
+	StoreWithTTL(key string, value interface{}, ttl time.Duration) error
+	// Get the time remaining before key expires. Zero is returned for keys
+	// that never expire.
+	GetTTL(key string) (time.Duration, error)
+	// Reset the expiry of an existing key to ttl from now.
+	Touch(key string, ttl time.Duration) error
+}
+
+// TTLAdapter adds TTLStore support to any SecureStorage by keeping the
+// expiry time alongside the value. Vault's KV engine does not expire
+// entries on its own, so VaultAdapter relies on this as well. Expired keys
+// are removed from the backend the next time they are looked up; they may
+// still be returned by LookupKeys() until then. Values written before the
+// adapter was added have no expiry recorded and are read as they are,
+// never expiring.
+type TTLAdapter struct {
+	Inner SecureStorage
+	now   func() time.Time
+}
+
+type ttlEntry struct {
+	Value     map[string]interface{} `mapstructure:"value"`
+	ExpiresAt string                 `mapstructure:"expires_at"`
+}
+
+// Create a new TTLAdapter wrapping ss.
+func NewTTLAdapter(ss SecureStorage) *TTLAdapter {
+	return &TTLAdapter{
+		Inner: ss,
+		now:   time.Now,
+	}
+}
+
+func (ta *TTLAdapter) newEntry(value interface{}, ttl time.Duration) (*ttlEntry, error) {
+	data, err := toMap(value)
+	if err != nil {
+		return nil, err
+	}
+	entry := &ttlEntry{Value: data}
+	if ttl > 0 {
+		entry.ExpiresAt = ta.now().Add(ttl).UTC().Format(time.RFC3339Nano)
+	}
+	return entry, nil
+}
+
+// Read the entry for key. A nil entry is returned if the key is missing or
+// has expired. Expired keys are deleted from the backend.
+func (ta *TTLAdapter) lookupEntry(key string) (*ttlEntry, time.Time, error) {
+	var (
+		data    map[string]interface{}
+		entry   ttlEntry
+		expires time.Time
+	)
+
+	err := ta.Inner.Lookup(key, &data)
+	if err != nil || data == nil {
+		return nil, expires, err
+	}
+	if !isEnvelope(data, "expires_at") {
+		return &ttlEntry{Value: data}, expires, nil
+	}
+	err = decodeValue(data, &entry)
+	if err != nil {
+		return nil, expires, err
+	}
+	if entry.ExpiresAt == "" {
+		return &entry, expires, nil
+	}
+	expires, err = time.Parse(time.RFC3339Nano, entry.ExpiresAt)
+	if err != nil {
+		return nil, expires, fmt.Errorf("Invalid expiry for %s: %v", key, err)
+	}
+	if !ta.now().Before(expires) {
+		return nil, expires, ta.Inner.Delete(key)
+	}
+	return &entry, expires, nil
+}
+
+// Write a value that never expires.
+func (ta *TTLAdapter) Store(key string, value interface{}) error {
+	return ta.StoreWithTTL(key, value, 0)
+}
+
+// Write a value that never expires and return the backend response.
+func (ta *TTLAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	entry, err := ta.newEntry(value, 0)
+	if err != nil {
+		return err
+	}
+	return ta.Inner.StoreWithData(key, entry, output)
+}
+
+// Read a value. Missing and expired keys leave output untouched.
+func (ta *TTLAdapter) Lookup(key string, output interface{}) error {
+	if output == nil {
+		return fmt.Errorf("output interface was nil")
+	}
+	entry, _, err := ta.lookupEntry(key)
+	if err != nil || entry == nil {
+		return err
+	}
+	return decodeValue(entry.Value, output)
+}
+
+func (ta *TTLAdapter) Delete(key string) error {
+	return ta.Inner.Delete(key)
+}
+
+func (ta *TTLAdapter) LookupKeys(keyPath string) ([]string, error) {
+	return ta.Inner.LookupKeys(keyPath)
+}
+
+func (ta *TTLAdapter) StoreWithTTL(key string, value interface{}, ttl time.Duration) error {
+	entry, err := ta.newEntry(value, ttl)
+	if err != nil {
+		return err
+	}
+	return ta.Inner.Store(key, entry)
+}
+
+func (ta *TTLAdapter) GetTTL(key string) (time.Duration, error) {
+	entry, expires, err := ta.lookupEntry(key)
+	if err != nil {
+		return 0, err
+	}
+	if entry == nil {
+		return 0, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if expires.IsZero() {
+		return 0, nil
+	}
+	return expires.Sub(ta.now()), nil
+}
+
+func (ta *TTLAdapter) Touch(key string, ttl time.Duration) error {
+	entry, _, err := ta.lookupEntry(key)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return ta.StoreWithTTL(key, entry.Value, ttl)
+}
+
+func (ta *TTLAdapter) Close() error {
+	return Close(ta.Inner)
+}
+
+func (ta *TTLAdapter) Health() error {
+	return Health(ta.Inner)
+}
@@ -0,0 +1,103 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTTLAdapter(t *testing.T) {
+	value := creds{
+		Xname:    "x0c0s1b0",
+		URL:      "10.4.0.21/redfish/v1/UpdateService",
+		Username: "test1",
+		Password: "123",
+	}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	// A value stored before the adapter was added never expires.
+	ms := newMemStore()
+	ms.Store("existing", value)
+	ta := NewTTLAdapter(ms)
+	ta.now = func() time.Time { return now }
+
+	var ss SecureStorage = ta
+	var _ TTLStore = ta
+
+	if err := ss.Store("forever", value); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	if err := ta.StoreWithTTL("short", value, time.Minute); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+
+	var tests = []struct {
+		key     string
+		advance time.Duration
+		touch   time.Duration
+		ttl     time.Duration
+		found   bool
+	}{
+		{key: "forever", advance: 0, ttl: 0, found: true},
+		{key: "short", advance: 0, ttl: time.Minute, found: true},
+		{key: "short", advance: 30 * time.Second, ttl: 30 * time.Second, found: true},
+		{key: "short", advance: 0, touch: time.Minute, ttl: time.Minute, found: true},
+		{key: "short", advance: 2 * time.Minute, found: false},
+		{key: "forever", advance: time.Hour, ttl: 0, found: true},
+		{key: "missing", advance: 0, found: false},
+		{key: "existing", advance: 0, ttl: 0, found: true},
+		{key: "existing", advance: 0, touch: time.Minute, ttl: time.Minute, found: true},
+		{key: "existing", advance: 2 * time.Minute, found: false},
+	}
+
+	for i, test := range tests {
+		now = now.Add(test.advance)
+		if test.touch != 0 {
+			if err := ta.Touch(test.key, test.touch); err != nil {
+				t.Errorf("Test %v Failed: Unexpected error on Touch - %v", i, err)
+			}
+		}
+		var r creds
+		if err := ss.Lookup(test.key, &r); err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		ttl, err := ta.GetTTL(test.key)
+		if test.found {
+			if !reflect.DeepEqual(r, value) {
+				t.Errorf("Test %v Failed: Expected credentials %v but got %v", i, value, r)
+			}
+			if err != nil || ttl != test.ttl {
+				t.Errorf("Test %v Failed: Expected TTL %v but got %v (%v)", i, test.ttl, ttl, err)
+			}
+		} else {
+			if !reflect.DeepEqual(r, creds{}) {
+				t.Errorf("Test %v Failed: Expected no credentials but got %v", i, r)
+			}
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("Test %v Failed: Expected ErrNotFound but got %v", i, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,268 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ErrValidation is returned (wrapped) when a value is rejected by a
+// Validator.
+var ErrValidation = errors.New("validation failed")
+
+// Validator checks a value before it is written. value is exactly what the
+// caller passed to Store().
+type Validator func(key string, value interface{}) error
+
+type prefixValidator struct {
+	prefix    string
+	validator Validator
+}
+
+// ValidatingAdapter runs registered validators before every Store() and
+// StoreWithData(), so bad values are rejected before they are encrypted or
+// reach the backend. Wrap it around any EncryptedAdapter so validators see
+// the plaintext.
+type ValidatingAdapter struct {
+	Inner SecureStorage
+
+	mu         sync.RWMutex
+	validators []prefixValidator
+}
+
+// Create a new ValidatingAdapter wrapping inner.
+func NewValidatingAdapter(inner SecureStorage) *ValidatingAdapter {
+	return &ValidatingAdapter{Inner: inner}
+}
+
+// Register a validator for every key starting with prefix. An empty prefix
+// applies to every key.
+func (va *ValidatingAdapter) AddValidator(prefix string, v Validator) {
+	va.mu.Lock()
+	defer va.mu.Unlock()
+	va.validators = append(va.validators, prefixValidator{prefix: prefix, validator: v})
+}
+
+// Run every validator that applies to key.
+func (va *ValidatingAdapter) Validate(key string, value interface{}) error {
+	va.mu.RLock()
+	defer va.mu.RUnlock()
+	for _, pv := range va.validators {
+		if !strings.HasPrefix(key, pv.prefix) {
+			continue
+		}
+		err := pv.validator(key, value)
+		if err != nil {
+			if errors.Is(err, ErrValidation) {
+				return err
+			}
+			return fmt.Errorf("%w for %s: %v", ErrValidation, key, err)
+		}
+	}
+	return nil
+}
+
+// Get a Validator that requires each named field to be present and not
+// empty, e.g. RequireFields("Username", "Password") for BMC credentials.
+func RequireFields(fields ...string) Validator {
+	return func(key string, value interface{}) error {
+		data, err := toMap(value)
+		if err != nil {
+			return err
+		}
+		for _, field := range fields {
+			v, ok := data[field]
+			if !ok || v == nil || v == "" {
+				return fmt.Errorf("field %s must not be empty", field)
+			}
+		}
+		return nil
+	}
+}
+
+// DefaultBMCPasswords are factory default BMC and switch passwords that
+// DenyDefaultPasswords() rejects when no list is given.
+var DefaultBMCPasswords = []string{
+	"0penBmc", "ADMIN", "admin", "calvin", "changeme", "initial0",
+	"password", "root", "superuser",
+}
+
+var privateKeyPattern = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)
+
+// Estimate the entropy of s in bits from its length and the character
+// classes it uses. This assumes the characters were chosen at random, so
+// it overestimates the strength of dictionary words.
+func estimateEntropy(s string) float64 {
+	var lower, upper, digit, other bool
+	for _, c := range s {
+		switch {
+		case unicode.IsLower(c):
+			lower = true
+		case unicode.IsUpper(c):
+			upper = true
+		case unicode.IsDigit(c):
+			digit = true
+		default:
+			other = true
+		}
+	}
+	pool := 0
+	if lower {
+		pool += 26
+	}
+	if upper {
+		pool += 26
+	}
+	if digit {
+		pool += 10
+	}
+	if other {
+		pool += 33
+	}
+	if pool == 0 {
+		return 0
+	}
+	return float64(len([]rune(s))) * math.Log2(float64(pool))
+}
+
+// Get a Validator that rejects a string field shorter than minLength
+// characters or with less than minBits of estimated entropy. A missing
+// field is not checked; use RequireFields() for that.
+func MinStrength(field string, minLength int, minBits float64) Validator {
+	return func(key string, value interface{}) error {
+		data, err := toMap(value)
+		if err != nil {
+			return err
+		}
+		s, ok := data[field].(string)
+		if !ok {
+			return nil
+		}
+		if n := len([]rune(s)); n < minLength {
+			return fmt.Errorf("field %s is %d characters long, it must be at least %d", field, n, minLength)
+		}
+		if bits := estimateEntropy(s); bits < minBits {
+			return fmt.Errorf("field %s is too weak (about %.0f bits of entropy, need %.0f); use a longer value mixing upper and lower case letters, digits and symbols",
+				field, bits, minBits)
+		}
+		return nil
+	}
+}
+
+// Get a Validator that rejects a string field equal to one of passwords,
+// ignoring case, or to one of DefaultBMCPasswords if none are given.
+func DenyDefaultPasswords(field string, passwords ...string) Validator {
+	if len(passwords) == 0 {
+		passwords = DefaultBMCPasswords
+	}
+	return func(key string, value interface{}) error {
+		data, err := toMap(value)
+		if err != nil {
+			return err
+		}
+		s, ok := data[field].(string)
+		if !ok {
+			return nil
+		}
+		for _, pw := range passwords {
+			if strings.EqualFold(s, pw) {
+				return fmt.Errorf("field %s is a factory default password; set a unique password on the device first", field)
+			}
+		}
+		return nil
+	}
+}
+
+// Get a Validator that rejects PEM private keys in any string field other
+// than allowedFields, e.g. a key pasted into a password field.
+func DenyPrivateKeys(allowedFields ...string) Validator {
+	allowed := map[string]bool{}
+	for _, field := range allowedFields {
+		allowed[field] = true
+	}
+	return func(key string, value interface{}) error {
+		data, err := toMap(value)
+		if err != nil {
+			return err
+		}
+		fields := make([]string, 0, len(data))
+		for field := range data {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			s, ok := data[field].(string)
+			if ok && !allowed[field] && privateKeyPattern.MatchString(s) {
+				if len(allowedFields) == 0 {
+					return fmt.Errorf("field %s contains a private key; private keys are not allowed here", field)
+				}
+				return fmt.Errorf("field %s contains a private key; private keys belong in %s",
+					field, strings.Join(allowedFields, " or "))
+			}
+		}
+		return nil
+	}
+}
+
+func (va *ValidatingAdapter) Store(key string, value interface{}) error {
+	err := va.Validate(key, value)
+	if err != nil {
+		return err
+	}
+	return va.Inner.Store(key, value)
+}
+
+func (va *ValidatingAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	err := va.Validate(key, value)
+	if err != nil {
+		return err
+	}
+	return va.Inner.StoreWithData(key, value, output)
+}
+
+func (va *ValidatingAdapter) Lookup(key string, output interface{}) error {
+	return va.Inner.Lookup(key, output)
+}
+
+func (va *ValidatingAdapter) Delete(key string) error {
+	return va.Inner.Delete(key)
+}
+
+func (va *ValidatingAdapter) LookupKeys(keyPath string) ([]string, error) {
+	return va.Inner.LookupKeys(keyPath)
+}
+
+func (va *ValidatingAdapter) Close() error {
+	return Close(va.Inner)
+}
+
+func (va *ValidatingAdapter) Health() error {
+	return Health(va.Inner)
+}
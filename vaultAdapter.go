@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright [2019-2022] Hewlett Packard Enterprise Development LP
+// (C) Copyright [2019-2022,2026] Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -27,6 +27,7 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/vault/api"
 	"github.com/mitchellh/mapstructure"
@@ -93,19 +94,59 @@ func NewVaultAdapterAs(basePath string, role string) (SecureStorage, error) {
 	if err != nil {
 		return ss, err
 	}
+	err = applyPoolEnvironment(config)
+	if err != nil {
+		return ss, err
+	}
 
 	ss.Config = config
 
-	// Create our http client for our vault connection
-	client, err := api.NewClient(config)
+	err = ss.connect()
 	if err != nil {
 		return ss, err
 	}
 
+	return ss, nil
+}
+
+// Create the vault client from ss.Config and authenticate with it using
+// ss.AuthConfig.
+func (ss *VaultAdapter) connect() error {
+	// Create our http client for our vault connection
+	client, err := api.NewClient(ss.Config)
+	if err != nil {
+		return err
+	}
+
 	ss.Client = NewRealVaultApi(client)
 
 	// Connect to and authenticate with vault
-	err = ss.loadToken()
+	return ss.loadToken()
+}
+
+// Create a new SecureStorage interface that uses Vault, authenticating with
+// authConfig instead of the k8s settings from the environment, e.g. one
+// from NewJWTAuthConfig().
+func NewVaultAdapterWithAuth(basePath string, authConfig *AuthConfig) (SecureStorage, error) {
+	ss := &VaultAdapter{
+		BasePath:   basePath,
+		VaultRetry: 1,
+		AuthConfig: authConfig,
+	}
+
+	config := api.DefaultConfig()
+	err := config.ReadEnvironment()
+	if err != nil {
+		return ss, err
+	}
+	err = applyPoolEnvironment(config)
+	if err != nil {
+		return ss, err
+	}
+
+	ss.Config = config
+
+	err = ss.connect()
 	if err != nil {
 		return ss, err
 	}
@@ -181,7 +222,7 @@ func (ss *VaultAdapter) Store(key string, value interface{}) error {
 		data map[string]interface{}
 	)
 
-	err = mapstructure.Decode(value, &data)
+	data, err = toMap(value)
 	if err != nil {
 		return err
 	}
@@ -215,7 +256,7 @@ func (ss *VaultAdapter) StoreWithData(key string, value interface{}, output inte
 		data map[string]interface{}
 	)
 
-	err = mapstructure.Decode(value, &data)
+	data, err = toMap(value)
 	if err != nil {
 		return err
 	}
@@ -242,7 +283,7 @@ func (ss *VaultAdapter) StoreWithData(key string, value interface{}, output inte
 			break
 		}
 
-		err = mapstructure.Decode(secretValues, output)
+		err = decodeValue(secretValues, output)
 		break
 	}
 
@@ -280,7 +321,7 @@ func (ss *VaultAdapter) Lookup(key string, output interface{}) error {
 			break
 		}
 
-		err = mapstructure.Decode(secretValues.Data, output)
+		err = decodeValue(secretValues.Data, output)
 		break
 	}
 
@@ -338,6 +379,10 @@ func (ss *VaultAdapter) LookupKeys(keyPath string) ([]string, error) {
 				return nil, err
 			}
 		}
+		if secretValues == nil {
+			// Nothing exists at this path
+			break
+		}
 		keys, ok := secretValues.Data["keys"].([]interface{})
 		if !ok {
 			return klist, fmt.Errorf("Cannot get secret data")
@@ -355,17 +400,154 @@ func (ss *VaultAdapter) LookupKeys(keyPath string) ([]string, error) {
 	return klist, err
 }
 
+// Get a KeyIterator over every key below keyPath, descending into
+// sub-paths. Each Vault directory is listed only when the walk reaches it.
+func (ss *VaultAdapter) IterateKeys(keyPath string) KeyIterator {
+	return newListKeyIterator(ss, keyPath)
+}
+
+// Run op, renewing the token and running op again if it fails because the
+// token has expired.
+func (ss *VaultAdapter) retry(op func() error) error {
+	var err error
+
+	for i := 0; i <= ss.VaultRetry; i++ {
+		err = op()
+		if err != nil && ss.checkErrForTokenRefresh(err) {
+			// We need to renew the token and then retry
+			if err = ss.loadToken(); err != nil {
+				return err
+			}
+			continue
+		}
+		break
+	}
+	return err
+}
+
+func leaseFromSecret(secret *api.Secret) *Lease {
+	lease := &Lease{
+		ID:        secret.LeaseID,
+		Duration:  time.Duration(secret.LeaseDuration) * time.Second,
+		Renewable: secret.Renewable,
+	}
+	if lease.Duration > 0 {
+		lease.Expires = time.Now().Add(lease.Duration)
+	}
+	return lease
+}
+
+// Read a leased secret, such as credentials from a dynamic secrets engine,
+// at the location specified by key. This function prepends the basePath.
+func (ss *VaultAdapter) AcquireLease(key string, output interface{}) (*Lease, error) {
+	var secret *api.Secret
+
+	path := ss.BasePath + "/" + key
+	err := ss.retry(func() error {
+		var err error
+		secret, err = ss.Client.Read(path)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	err = decodeValue(secret.Data, output)
+	if err != nil {
+		return nil, err
+	}
+	return leaseFromSecret(secret), nil
+}
+
+// Extend a lease by increment using sys/leases/renew.
+func (ss *VaultAdapter) RenewLease(lease *Lease, increment time.Duration) (*Lease, error) {
+	var secret *api.Secret
+
+	data := map[string]interface{}{
+		"lease_id":  lease.ID,
+		"increment": int(increment.Seconds()),
+	}
+	err := ss.retry(func() error {
+		var err error
+		secret, err = ss.Client.Write("sys/leases/renew", data)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("No response renewing lease %s", lease.ID)
+	}
+	renewed := leaseFromSecret(secret)
+	if renewed.ID == "" {
+		renewed.ID = lease.ID
+	}
+	return renewed, nil
+}
+
+// Revoke a lease using sys/leases/revoke.
+func (ss *VaultAdapter) ReleaseLease(lease *Lease) error {
+	data := map[string]interface{}{
+		"lease_id": lease.ID,
+	}
+	return ss.retry(func() error {
+		_, err := ss.Client.Write("sys/leases/revoke", data)
+		return err
+	})
+}
+
+// Check that vault is initialized and unsealed and that the adapter can
+// still authenticate. The sys/health check is skipped if the VaultApi does
+// not implement VaultHealthApi.
+func (ss *VaultAdapter) Health() error {
+	if hc, ok := ss.Client.(VaultHealthApi); ok {
+		resp, err := hc.Health()
+		if err != nil {
+			return err
+		}
+		if !resp.Initialized {
+			return fmt.Errorf("Vault is not initialized")
+		}
+		if resp.Sealed {
+			return fmt.Errorf("Vault is sealed")
+		}
+	}
+	return ss.retry(func() error {
+		_, err := ss.Client.Read("auth/token/lookup-self")
+		return err
+	})
+}
+
+// Forget the vault token and release idle connections. The adapter must
+// not be used afterwards.
+func (ss *VaultAdapter) Close() error {
+	if ss.Client != nil {
+		ss.Client.SetToken("")
+	}
+	if ss.Config != nil && ss.Config.HttpClient != nil {
+		ss.Config.HttpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
 ///////////////////////////////
 // K8s Authentication functions
 ///////////////////////////////
 
-// AuthConfig struct for vault k8s authentication
+// AuthConfig struct for vault k8s authentication. The same login payload
+// (role and jwt) is used by Vault's generic jwt/oidc auth method, so an
+// AuthConfig from NewJWTAuthConfig() authenticates with tokens issued by
+// Keycloak, Dex or any other OIDC provider instead.
 type AuthConfig struct {
 	JWTFile  string
 	RoleFile string
 	Path     string
-	jwt      string
-	role     string
+	// If set, called for the JWT instead of reading JWTFile.
+	JWTFunc func() (string, error)
+	jwt     string
+	role    string
 }
 
 // DefaultAuthConfig Create the default auth config that will work for almost all scenarios
@@ -416,9 +598,47 @@ func getFileContents(filePath string) (string, error) {
 	return strings.TrimSpace(string(contents)), nil
 }
 
-// LoadJWT save contents of JWTFile to the jwt field
+// NewJWTAuthConfig Create an auth config for Vault's jwt/oidc auth method
+// mounted at mount (e.g. "jwt" or "oidc"), logging in as role with the JWT
+// returned by jwtFunc. See JWTFromFile and JWTFromEnv.
+func NewJWTAuthConfig(mount string, role string, jwtFunc func() (string, error)) *AuthConfig {
+	return &AuthConfig{
+		Path:    "auth/" + strings.Trim(mount, "/") + "/login",
+		JWTFunc: jwtFunc,
+		role:    role,
+	}
+}
+
+// JWTFromFile Get a JWTFunc reading the token from a file on every login, so
+// a refreshed token is picked up.
+func JWTFromFile(path string) func() (string, error) {
+	return func() (string, error) {
+		return getFileContents(path)
+	}
+}
+
+// JWTFromEnv Get a JWTFunc reading the token from an environment variable.
+func JWTFromEnv(name string) func() (string, error) {
+	return func() (string, error) {
+		jwt := strings.TrimSpace(os.Getenv(name))
+		if jwt == "" {
+			return "", fmt.Errorf("Environment variable %s is not set", name)
+		}
+		return jwt, nil
+	}
+}
+
+// LoadJWT save contents of JWTFile, or the result of JWTFunc, to the jwt
+// field
 func (authConfig *AuthConfig) LoadJWT() error {
-	jwt, err := getFileContents(authConfig.JWTFile)
+	var jwt string
+	var err error
+
+	if authConfig.JWTFunc != nil {
+		jwt, err = authConfig.JWTFunc()
+	} else {
+		jwt, err = getFileContents(authConfig.JWTFile)
+	}
 	if err != nil {
 		return err
 	}
@@ -426,8 +646,12 @@ func (authConfig *AuthConfig) LoadJWT() error {
 	return nil
 }
 
-// LoadRole save contents of RoleFile to the role field
+// LoadRole save contents of RoleFile to the role field. Without a RoleFile
+// the role is left as it is.
 func (authConfig *AuthConfig) LoadRole() error {
+	if authConfig.RoleFile == "" {
+		return nil
+	}
 	role, err := getFileContents(authConfig.RoleFile)
 	if err != nil {
 		return err
@@ -463,6 +687,12 @@ type VaultApi interface {
 	SetToken(t string)
 }
 
+// VaultHealthApi is implemented by VaultApi clients that can query
+// sys/health.
+type VaultHealthApi interface {
+	Health() (*api.HealthResponse, error)
+}
+
 type RealVaultApi struct {
 	Client *api.Client
 }
@@ -493,3 +723,7 @@ func (v *RealVaultApi) List(path string) (*api.Secret, error) {
 func (v *RealVaultApi) SetToken(t string) {
 	v.Client.SetToken(t)
 }
+
+func (v *RealVaultApi) Health() (*api.HealthResponse, error) {
+	return v.Client.Sys().Health()
+}
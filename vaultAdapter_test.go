@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright [2019, 2021] Hewlett Packard Enterprise Development LP
+// (C) Copyright [2019,2021,2026] Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"github.com/hashicorp/vault/api"
 	"github.com/mitchellh/mapstructure"
+	"os"
 	"reflect"
 	"testing"
 )
@@ -520,3 +521,57 @@ func TestVaultAdapterLookupKeys(t *testing.T) {
 		}
 	}
 }
+
+func TestVaultAdapterJWTAuth(t *testing.T) {
+	var tests = []struct {
+		authConfig *AuthConfig
+		env        map[string]string
+		path       string
+		data       map[string]interface{}
+		respErr    bool
+	}{
+		{
+			authConfig: NewJWTAuthConfig("jwt", "hms", JWTFromEnv("OIDC_TOKEN")),
+			env:        map[string]string{"OIDC_TOKEN": "eyJhbGciOi.keycloak.sig\n"},
+			path:       "auth/jwt/login",
+			data:       map[string]interface{}{"role": "hms", "jwt": "eyJhbGciOi.keycloak.sig"},
+		}, {
+			authConfig: NewJWTAuthConfig("/oidc/", "admin", func() (string, error) { return "dex-token", nil }),
+			path:       "auth/oidc/login",
+			data:       map[string]interface{}{"role": "admin", "jwt": "dex-token"},
+		}, {
+			authConfig: NewJWTAuthConfig("jwt", "hms", JWTFromEnv("OIDC_TOKEN")),
+			respErr:    true,
+		},
+	}
+
+	for i, test := range tests {
+		for k, v := range test.env {
+			t.Setenv(k, v)
+		}
+		vaultApi, mockApi := NewMockVaultApi()
+		mockApi.WriteData = []MockVWrite{{
+			Output: OutputVWrite{S: &api.Secret{Auth: &api.SecretAuth{ClientToken: "token"}}},
+		}}
+		ss := &VaultAdapter{Client: vaultApi, AuthConfig: test.authConfig}
+		err := ss.loadToken()
+		for k := range test.env {
+			os.Unsetenv(k)
+		}
+		if test.respErr {
+			if err == nil {
+				t.Errorf("Test %v Failed: Expected an error.", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+			continue
+		}
+		input := mockApi.WriteData[0].Input
+		if input.Path != test.path || !reflect.DeepEqual(input.Data, test.data) {
+			t.Errorf("Test %v Failed: Expected login at %s with %v but got %s with %v",
+				i, test.path, test.data, input.Path, input.Data)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// Env vars read by PoolConfig.ReadEnvironment() to tune the connection pool
+// of the Vault client. SECURESTORAGE_VAULT_IDLE_CONN_TIMEOUT takes a
+// duration such as "90s".
+const EnvVaultMaxIdleConns = "SECURESTORAGE_VAULT_MAX_IDLE_CONNS"
+const EnvVaultMaxIdleConnsPerHost = "SECURESTORAGE_VAULT_MAX_IDLE_CONNS_PER_HOST"
+const EnvVaultMaxConnsPerHost = "SECURESTORAGE_VAULT_MAX_CONNS_PER_HOST"
+const EnvVaultIdleConnTimeout = "SECURESTORAGE_VAULT_IDLE_CONN_TIMEOUT"
+const EnvVaultDisableHTTP2 = "SECURESTORAGE_VAULT_DISABLE_HTTP2"
+
+// PoolConfig tunes the connection pool of the http.Transport a VaultAdapter
+// uses. Zero values keep the vault api defaults: 100 idle connections, one
+// more per host than GOMAXPROCS, no limit on open connections per host, a
+// 90 second idle timeout and HTTP/2 when Vault offers it. Raising
+// MaxIdleConnsPerHost to the number of concurrent requests keeps busy
+// services from opening, and leaving in TIME_WAIT, a new connection for
+// most requests.
+type PoolConfig struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+	DisableHTTP2        bool
+}
+
+// ReadEnvironment Update a PoolConfig with environment variables. Variables
+// that are unset or empty leave the current value alone.
+func (pc *PoolConfig) ReadEnvironment() error {
+	for _, v := range []struct {
+		env   string
+		value *int
+	}{
+		{EnvVaultMaxIdleConns, &pc.MaxIdleConns},
+		{EnvVaultMaxIdleConnsPerHost, &pc.MaxIdleConnsPerHost},
+		{EnvVaultMaxConnsPerHost, &pc.MaxConnsPerHost},
+	} {
+		if s := os.Getenv(v.env); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return fmt.Errorf("Invalid %s: %v", v.env, err)
+			}
+			*v.value = n
+		}
+	}
+	if s := os.Getenv(EnvVaultIdleConnTimeout); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("Invalid %s: %v", EnvVaultIdleConnTimeout, err)
+		}
+		pc.IdleConnTimeout = d
+	}
+	if s := os.Getenv(EnvVaultDisableHTTP2); s != "" {
+		disable, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("Invalid %s: %v", EnvVaultDisableHTTP2, err)
+		}
+		pc.DisableHTTP2 = disable
+	}
+	return pc.Validate()
+}
+
+// Check a PoolConfig for values that cannot work.
+func (pc *PoolConfig) Validate() error {
+	if pc.MaxIdleConns < 0 || pc.MaxIdleConnsPerHost < 0 || pc.MaxConnsPerHost < 0 {
+		return fmt.Errorf("Connection pool sizes must not be negative")
+	}
+	if pc.IdleConnTimeout < 0 {
+		return fmt.Errorf("Idle connection timeout must not be negative")
+	}
+	return nil
+}
+
+// Apply the PoolConfig to the transport of config.HttpClient, which every
+// client created from config shares.
+func (pc *PoolConfig) Apply(config *api.Config) error {
+	err := pc.Validate()
+	if err != nil {
+		return err
+	}
+	if config.HttpClient == nil {
+		return fmt.Errorf("Vault config has no http client")
+	}
+	transport, ok := config.HttpClient.Transport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("Vault http client transport is %T, not *http.Transport", config.HttpClient.Transport)
+	}
+
+	if pc.MaxIdleConns > 0 {
+		transport.MaxIdleConns = pc.MaxIdleConns
+	}
+	if pc.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = pc.MaxIdleConnsPerHost
+	}
+	if pc.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = pc.MaxConnsPerHost
+	}
+	if pc.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = pc.IdleConnTimeout
+	}
+	if pc.DisableHTTP2 {
+		// The vault api registers HTTP/2 through TLSNextProto and ALPN.
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		if transport.TLSClientConfig != nil {
+			var protos []string
+			for _, p := range transport.TLSClientConfig.NextProtos {
+				if p != "h2" {
+					protos = append(protos, p)
+				}
+			}
+			transport.TLSClientConfig.NextProtos = protos
+		}
+	}
+	return nil
+}
+
+// Read a PoolConfig from the environment and apply it to config.
+func applyPoolEnvironment(config *api.Config) error {
+	pool := &PoolConfig{}
+	err := pool.ReadEnvironment()
+	if err != nil {
+		return err
+	}
+	return pool.Apply(config)
+}
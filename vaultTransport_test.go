@@ -0,0 +1,125 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+)
+
+func TestPoolConfigApply(t *testing.T) {
+	var tests = []struct {
+		env     map[string]string
+		pool    PoolConfig
+		respErr bool
+	}{
+		{
+			pool: PoolConfig{},
+		}, {
+			env: map[string]string{
+				EnvVaultMaxIdleConns:        "200",
+				EnvVaultMaxIdleConnsPerHost: "64",
+				EnvVaultMaxConnsPerHost:     "128",
+				EnvVaultIdleConnTimeout:     "30s",
+				EnvVaultDisableHTTP2:        "true",
+			},
+			pool: PoolConfig{
+				MaxIdleConns:        200,
+				MaxIdleConnsPerHost: 64,
+				MaxConnsPerHost:     128,
+				IdleConnTimeout:     30 * time.Second,
+				DisableHTTP2:        true,
+			},
+		}, {
+			env:     map[string]string{EnvVaultMaxIdleConns: "lots"},
+			respErr: true,
+		}, {
+			env:     map[string]string{EnvVaultDisableHTTP2: "maybe"},
+			respErr: true,
+		}, {
+			env:     map[string]string{EnvVaultIdleConnTimeout: "-1s"},
+			respErr: true,
+		},
+	}
+
+	for i, test := range tests {
+		for k, v := range test.env {
+			t.Setenv(k, v)
+		}
+		pool := &PoolConfig{}
+		err := pool.ReadEnvironment()
+		for k := range test.env {
+			os.Unsetenv(k)
+		}
+		if (err != nil) != test.respErr {
+			t.Errorf("Test %v Failed: Expected error %v, got %v", i, test.respErr, err)
+			continue
+		}
+		if test.respErr {
+			continue
+		}
+		if *pool != test.pool {
+			t.Errorf("Test %v Failed: Expected %+v, got %+v", i, test.pool, *pool)
+		}
+
+		config := api.DefaultConfig()
+		transport := config.HttpClient.Transport.(*http.Transport)
+		def := transport.Clone()
+		err = pool.Apply(config)
+		if err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+			continue
+		}
+		if config.HttpClient.Transport != transport {
+			t.Errorf("Test %v Failed: Expected the transport to be reused", i)
+		}
+		want := test.pool
+		if want.MaxIdleConns == 0 {
+			want.MaxIdleConns = def.MaxIdleConns
+		}
+		if want.MaxIdleConnsPerHost == 0 {
+			want.MaxIdleConnsPerHost = def.MaxIdleConnsPerHost
+		}
+		if want.IdleConnTimeout == 0 {
+			want.IdleConnTimeout = def.IdleConnTimeout
+		}
+		if transport.MaxIdleConns != want.MaxIdleConns ||
+			transport.MaxIdleConnsPerHost != want.MaxIdleConnsPerHost ||
+			transport.MaxConnsPerHost != want.MaxConnsPerHost ||
+			transport.IdleConnTimeout != want.IdleConnTimeout {
+			t.Errorf("Test %v Failed: Transport not configured as %+v", i, want)
+		}
+		_, h2 := transport.TLSNextProto["h2"]
+		if h2 == test.pool.DisableHTTP2 {
+			t.Errorf("Test %v Failed: Expected HTTP/2 disabled %v", i, test.pool.DisableHTTP2)
+		}
+	}
+
+	config := &api.Config{HttpClient: &http.Client{Transport: http.NewFileTransport(http.Dir("."))}}
+	if (&PoolConfig{}).Apply(config) == nil {
+		t.Errorf("Expected an error for a transport that is not an *http.Transport")
+	}
+}
@@ -0,0 +1,78 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+)
+
+// VerifySecret reports whether candidate matches a stored secret, so that
+// callers checking a presented credential never handle the stored value.
+// key has the form "path/to/key#field" as in Populate(); without "#field"
+// the secret must have exactly one field. The comparison takes the same
+// time whatever the contents or lengths of the values. A missing key or
+// field is an error wrapping ErrNotFound.
+func VerifySecret(ss SecureStorage, key string, candidate []byte) (bool, error) {
+	var data map[string]interface{}
+
+	key, field, _ := strings.Cut(key, "#")
+	err := ss.Lookup(key, &data)
+	if err != nil {
+		return false, err
+	}
+	if data == nil {
+		return false, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if field == "" {
+		if len(data) != 1 {
+			return false, fmt.Errorf("Secret %s has %d fields; a field must be given", key, len(data))
+		}
+		for name := range data {
+			field = name
+		}
+	}
+
+	var stored []byte
+	switch v := data[field].(type) {
+	case string:
+		stored = []byte(v)
+	case []byte:
+		stored = v
+	case nil:
+		return false, fmt.Errorf("%w: %s#%s", ErrNotFound, key, field)
+	default:
+		return false, fmt.Errorf("Field %s of %s is not a string", field, key)
+	}
+
+	// Compare digests so neither the lengths nor the contents leak through
+	// timing.
+	want := sha256.Sum256(stored)
+	got := sha256.Sum256(candidate)
+	for i := range stored {
+		stored[i] = 0
+	}
+	return subtle.ConstantTimeCompare(want[:], got[:]) == 1, nil
+}
@@ -0,0 +1,66 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVerifySecret(t *testing.T) {
+	ms := newMemStore()
+	ms.Store("hms-creds/x0c0s0b0", creds{Xname: "x0c0s0b0", Username: "root", Password: "hunter2"})
+	ms.Store("tokens/api", map[string]interface{}{"value": "s3cr3t-token"})
+	ms.Store("tokens/count", map[string]interface{}{"value": 42})
+
+	tests := []struct {
+		key       string
+		candidate string
+		ok        bool
+		err       error
+	}{
+		{"hms-creds/x0c0s0b0#Password", "hunter2", true, nil},
+		{"hms-creds/x0c0s0b0#Password", "hunter3", false, nil},
+		{"hms-creds/x0c0s0b0#Password", "hunter22", false, nil},
+		{"hms-creds/x0c0s0b0#Password", "", false, nil},
+		{"tokens/api", "s3cr3t-token", true, nil},
+		{"tokens/api#value", "s3cr3t", false, nil},
+		{"tokens/missing", "x", false, ErrNotFound},
+		{"hms-creds/x0c0s0b0#Token", "x", false, ErrNotFound},
+	}
+	for i, test := range tests {
+		ok, err := VerifySecret(ms, test.key, []byte(test.candidate))
+		if ok != test.ok || !errors.Is(err, test.err) {
+			t.Errorf("Test %v Failed: Expected %v, %v but got %v, %v", i, test.ok, test.err, ok, err)
+		}
+	}
+
+	for i, key := range []string{"hms-creds/x0c0s0b0", "tokens/count"} {
+		if ok, err := VerifySecret(ms, key, []byte("42")); ok || err == nil {
+			t.Errorf("Test %v Failed: Expected an error for %s", i, key)
+		}
+	}
+	if _, err := VerifySecret(&failStore{err: ErrAccessDenied}, "key", nil); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("Test Failed: Expected the backend error but got %v", err)
+	}
+}
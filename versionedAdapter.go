@@ -0,0 +1,224 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"time"
+)
+
+// VersionInfo describes one stored version of a key.
+type VersionInfo struct {
+	Version     int
+	CreatedTime time.Time
+}
+
+// VersionedStore is implemented by SecureStorage backends that keep older
+// values of each key, so rollback tooling works the same way everywhere.
+type VersionedStore interface {
+	// Read a specific version of key into output.
+	LookupVersion(key string, version int, output interface{}) error
+	// List the versions of key that are still kept, oldest first.
+	ListVersions(key string) ([]VersionInfo, error)
+	// Write the value of an older version back as a new version.
+	Rollback(key string, version int) error
+}
+
+// Number of versions a VersionedAdapter keeps when MaxVersions is <= 0, as
+// every version is stored in the same value as the current one.
+const DefaultMaxVersions = 10
+
+// VersionedAdapter adds VersionedStore support to any SecureStorage by
+// keeping the last MaxVersions values of each key alongside the current
+// one, DefaultMaxVersions if MaxVersions is <= 0. Delete() removes every
+// version.
+type VersionedAdapter struct {
+	Inner       SecureStorage
+	MaxVersions int
+	now         func() time.Time
+}
+
+type versionedEntry struct {
+	Versions []versionRecord `mapstructure:"versions"`
+}
+
+type versionRecord struct {
+	Version     int                    `mapstructure:"version"`
+	CreatedTime string                 `mapstructure:"created_time"`
+	Value       map[string]interface{} `mapstructure:"value"`
+}
+
+// Create a new VersionedAdapter keeping up to maxVersions versions of each
+// key. A maxVersions <= 0 keeps DefaultMaxVersions.
+func NewVersionedAdapter(inner SecureStorage, maxVersions int) *VersionedAdapter {
+	if maxVersions <= 0 {
+		maxVersions = DefaultMaxVersions
+	}
+	return &VersionedAdapter{
+		Inner:       inner,
+		MaxVersions: maxVersions,
+		now:         time.Now,
+	}
+}
+
+func (va *VersionedAdapter) lookupEntry(key string) (*versionedEntry, error) {
+	var entry *versionedEntry
+
+	err := va.Inner.Lookup(key, &entry)
+	if err != nil {
+		return nil, err
+	}
+	if entry != nil && len(entry.Versions) == 0 {
+		entry = nil
+	}
+	return entry, nil
+}
+
+func (va *VersionedAdapter) findVersion(key string, version int) (*versionRecord, error) {
+	entry, err := va.lookupEntry(key)
+	if err != nil {
+		return nil, err
+	}
+	if entry != nil {
+		for i := range entry.Versions {
+			if entry.Versions[i].Version == version {
+				return &entry.Versions[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: %s version %d", ErrNotFound, key, version)
+}
+
+// Build the entry that adds value as the newest version of key.
+func (va *VersionedAdapter) nextEntry(key string, value interface{}) (*versionedEntry, error) {
+	data, err := toMap(value)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := va.lookupEntry(key)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		entry = &versionedEntry{}
+	}
+	version := 1
+	if n := len(entry.Versions); n > 0 {
+		version = entry.Versions[n-1].Version + 1
+	}
+	entry.Versions = append(entry.Versions, versionRecord{
+		Version:     version,
+		CreatedTime: va.now().UTC().Format(time.RFC3339Nano),
+		Value:       data,
+	})
+	maxVersions := va.MaxVersions
+	if maxVersions <= 0 {
+		maxVersions = DefaultMaxVersions
+	}
+	if len(entry.Versions) > maxVersions {
+		entry.Versions = entry.Versions[len(entry.Versions)-maxVersions:]
+	}
+	return entry, nil
+}
+
+func (va *VersionedAdapter) Store(key string, value interface{}) error {
+	entry, err := va.nextEntry(key, value)
+	if err != nil {
+		return err
+	}
+	return va.Inner.Store(key, entry)
+}
+
+func (va *VersionedAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	entry, err := va.nextEntry(key, value)
+	if err != nil {
+		return err
+	}
+	return va.Inner.StoreWithData(key, entry, output)
+}
+
+// Read the newest version of key.
+func (va *VersionedAdapter) Lookup(key string, output interface{}) error {
+	if output == nil {
+		return fmt.Errorf("output interface was nil")
+	}
+	entry, err := va.lookupEntry(key)
+	if err != nil || entry == nil {
+		return err
+	}
+	return decodeValue(entry.Versions[len(entry.Versions)-1].Value, output)
+}
+
+func (va *VersionedAdapter) Delete(key string) error {
+	return va.Inner.Delete(key)
+}
+
+func (va *VersionedAdapter) LookupKeys(keyPath string) ([]string, error) {
+	return va.Inner.LookupKeys(keyPath)
+}
+
+func (va *VersionedAdapter) LookupVersion(key string, version int, output interface{}) error {
+	if output == nil {
+		return fmt.Errorf("output interface was nil")
+	}
+	record, err := va.findVersion(key, version)
+	if err != nil {
+		return err
+	}
+	return decodeValue(record.Value, output)
+}
+
+func (va *VersionedAdapter) ListVersions(key string) ([]VersionInfo, error) {
+	entry, err := va.lookupEntry(key)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	versions := make([]VersionInfo, 0, len(entry.Versions))
+	for _, record := range entry.Versions {
+		created, err := time.Parse(time.RFC3339Nano, record.CreatedTime)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid created time for %s version %d: %v", key, record.Version, err)
+		}
+		versions = append(versions, VersionInfo{Version: record.Version, CreatedTime: created})
+	}
+	return versions, nil
+}
+
+func (va *VersionedAdapter) Rollback(key string, version int) error {
+	record, err := va.findVersion(key, version)
+	if err != nil {
+		return err
+	}
+	return va.Store(key, record.Value)
+}
+
+func (va *VersionedAdapter) Health() error {
+	return Health(va.Inner)
+}
+
+func (va *VersionedAdapter) Close() error {
+	return Close(va.Inner)
+}
@@ -0,0 +1,123 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestVersionedAdapter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	va := NewVersionedAdapter(newMemStore(), 3)
+	va.now = func() time.Time { return now }
+
+	var _ VersionedStore = va
+	var _ SecureStorageV2 = va
+
+	for i := 1; i <= 4; i++ {
+		now = now.Add(time.Minute)
+		if err := va.Store("x0c0s1b0", creds{Username: "root", Password: fmt.Sprintf("pw%d", i)}); err != nil {
+			t.Fatalf("Unexpected error - %v", err)
+		}
+	}
+
+	versions, err := va.ListVersions("x0c0s1b0")
+	if err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	expected := []VersionInfo{
+		{Version: 2, CreatedTime: now.Add(-2 * time.Minute)},
+		{Version: 3, CreatedTime: now.Add(-time.Minute)},
+		{Version: 4, CreatedTime: now},
+	}
+	if !reflect.DeepEqual(versions, expected) {
+		t.Errorf("Expected versions %v but got %v", expected, versions)
+	}
+
+	var tests = []struct {
+		version  int
+		password string
+		respErr  bool
+	}{
+		{version: 4, password: "pw4"},
+		{version: 2, password: "pw2"},
+		{version: 1, respErr: true},
+	}
+	for i, test := range tests {
+		var r creds
+		err := va.LookupVersion("x0c0s1b0", test.version, &r)
+		if test.respErr {
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("Test %v Failed: Expected ErrNotFound but got %v", i, err)
+			}
+		} else if err != nil || r.Password != test.password {
+			t.Errorf("Test %v Failed: Expected password %v but got %v (%v)", i, test.password, r.Password, err)
+		}
+	}
+
+	if err := va.Rollback("x0c0s1b0", 2); err != nil {
+		t.Fatalf("Unexpected error - %v", err)
+	}
+	var r creds
+	va.Lookup("x0c0s1b0", &r)
+	if r.Password != "pw2" {
+		t.Errorf("Expected rolled back password pw2 but got %v", r.Password)
+	}
+	versions, _ = va.ListVersions("x0c0s1b0")
+	if len(versions) != 3 || versions[2].Version != 5 {
+		t.Errorf("Expected rollback to add version 5 but got %v", versions)
+	}
+
+	va.Delete("x0c0s1b0")
+	if _, err := va.ListVersions("x0c0s1b0"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after Delete but got %v", err)
+	}
+}
+
+func TestVersionedAdapterDefaultMax(t *testing.T) {
+	var tests = []struct {
+		va       *VersionedAdapter
+		expected int
+	}{
+		{va: NewVersionedAdapter(newMemStore(), 0), expected: DefaultMaxVersions},
+		{va: NewVersionedAdapter(newMemStore(), -1), expected: DefaultMaxVersions},
+		{va: &VersionedAdapter{Inner: newMemStore(), now: time.Now}, expected: DefaultMaxVersions},
+		{va: NewVersionedAdapter(newMemStore(), 2), expected: 2},
+	}
+
+	for i, test := range tests {
+		for j := 0; j < DefaultMaxVersions+5; j++ {
+			if err := test.va.Store("x0c0s1b0", creds{Password: fmt.Sprintf("pw%d", j)}); err != nil {
+				t.Fatalf("Test %v Failed: Unexpected error - %v", i, err)
+			}
+		}
+		versions, err := test.va.ListVersions("x0c0s1b0")
+		if err != nil || len(versions) != test.expected {
+			t.Errorf("Test %v Failed: Expected %v versions but got %v (%v)", i, test.expected, len(versions), err)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Number of directories Walk() lists at once by default
+const DefaultWalkConcurrency = 8
+
+// Deepest directory nesting Walk() descends into, as a guard against
+// backends that list a directory inside itself.
+const MaxWalkDepth = 64
+
+type walker struct {
+	ss  SecureStorage
+	fn  func(key string) error
+	sem chan struct{}
+	wg  sync.WaitGroup
+
+	mu      sync.Mutex
+	visited map[string]bool
+	err     error
+}
+
+// Record the first error. It stops the walk.
+func (w *walker) fail(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+func (w *walker) failed() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err != nil
+}
+
+// Mark dir visited, returning false if it already was.
+func (w *walker) visit(dir string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.visited[dir] {
+		return false
+	}
+	w.visited[dir] = true
+	return true
+}
+
+func (w *walker) walk(dir string, depth int) {
+	defer w.wg.Done()
+
+	w.sem <- struct{}{}
+	var subdirs []string
+	if !w.failed() {
+		names, err := w.ss.LookupKeys(dir)
+		if err != nil {
+			w.fail(err)
+		}
+		for i := 0; err == nil && i < len(names); i++ {
+			name := strings.TrimPrefix(names[i], "/")
+			if sub, ok := strings.CutSuffix(name, "/"); ok {
+				if sub != "" && sub != "." && sub != ".." {
+					subdirs = append(subdirs, joinKey(dir, sub))
+				}
+				continue
+			}
+			err = w.fn(joinKey(dir, name))
+			if err != nil {
+				w.fail(err)
+			}
+		}
+	}
+	<-w.sem
+
+	for _, sub := range subdirs {
+		if depth+1 > MaxWalkDepth {
+			w.fail(fmt.Errorf("Key path %s is nested more than %d levels deep", sub, MaxWalkDepth))
+			return
+		}
+		if w.visit(sub) {
+			w.wg.Add(1)
+			go w.walk(sub, depth+1)
+		}
+	}
+}
+
+// Call fn for every key below keyPath, listing up to concurrency
+// directories at once (DefaultWalkConcurrency if it is not positive). fn is
+// called from several goroutines at once, in no particular order. The walk
+// stops at the first error from fn or LookupKeys(), which is returned.
+// Each directory is listed once, even if a backend lists it more than
+// once.
+func Walk(ss SecureStorage, keyPath string, concurrency int, fn func(key string) error) error {
+	if concurrency <= 0 {
+		concurrency = DefaultWalkConcurrency
+	}
+	root := strings.TrimSuffix(keyPath, "/")
+	w := &walker{
+		ss:      ss,
+		fn:      fn,
+		sem:     make(chan struct{}, concurrency),
+		visited: map[string]bool{root: true},
+	}
+	w.wg.Add(1)
+	go w.walk(root, 0)
+	w.wg.Wait()
+	return w.err
+}
@@ -0,0 +1,132 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package securestorage
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// loopStore lists a "loop/" sub-path in every directory, like a backend
+// with a link back into its own tree.
+type loopStore struct {
+	*memStore
+}
+
+func (ls *loopStore) LookupKeys(keyPath string) ([]string, error) {
+	names, err := ls.memStore.LookupKeys(keyPath)
+	return append(names, "loop/", "./", "../"), err
+}
+
+func TestWalk(t *testing.T) {
+	ms := newMemStore()
+	for _, key := range []string{
+		"hms-creds/x0c0s1b0",
+		"hms-creds/x0c0s2b0",
+		"hms-creds/x1000/x1000c0s0b0",
+		"hms-creds/x1000/c0/x1000c0s1b0",
+		"other/x0c0s3b0",
+	} {
+		ms.Store(key, map[string]interface{}{"Username": "root"})
+	}
+	errStop := errors.New("stop")
+
+	var tests = []struct {
+		ss          SecureStorage
+		keyPath     string
+		concurrency int
+		stopAt      string
+		resp        []string
+		err         string
+	}{
+		{
+			ss:      ms,
+			keyPath: "hms-creds",
+			resp: []string{
+				"hms-creds/x0c0s1b0",
+				"hms-creds/x0c0s2b0",
+				"hms-creds/x1000/c0/x1000c0s1b0",
+				"hms-creds/x1000/x1000c0s0b0",
+			},
+		}, {
+			ss:          ms,
+			keyPath:     "hms-creds/x1000/",
+			concurrency: 1,
+			resp: []string{
+				"hms-creds/x1000/c0/x1000c0s1b0",
+				"hms-creds/x1000/x1000c0s0b0",
+			},
+		}, {
+			ss:      ms,
+			keyPath: "missing",
+			resp:    []string{},
+		}, {
+			ss:          ms,
+			keyPath:     "hms-creds/x1000",
+			concurrency: 1,
+			stopAt:      "hms-creds/x1000/x1000c0s0b0",
+			resp:        []string{"hms-creds/x1000/x1000c0s0b0"},
+			err:         "stop",
+		}, {
+			ss:      &failStore{err: errors.New("list failed")},
+			keyPath: "hms-creds",
+			resp:    []string{},
+			err:     "list failed",
+		}, {
+			ss:      &loopStore{ms},
+			keyPath: "other",
+			err:     "nested more than",
+		},
+	}
+
+	for i, test := range tests {
+		var mu sync.Mutex
+		r := []string{}
+		err := Walk(test.ss, test.keyPath, test.concurrency, func(key string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			r = append(r, key)
+			if key == test.stopAt {
+				return errStop
+			}
+			return nil
+		})
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("Test %v Failed: Expected error containing '%v', got %v", i, test.err, err)
+			}
+		} else if err != nil {
+			t.Errorf("Test %v Failed: Unexpected error - %v", i, err)
+		}
+		if test.resp == nil {
+			continue
+		}
+		sort.Strings(r)
+		if !reflect.DeepEqual(r, test.resp) {
+			t.Errorf("Test %v Failed: Expected keys %v, got %v", i, test.resp, r)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Headers set on every webhook request
+const (
+	WebhookSignatureHeader = "X-Securestorage-Signature"
+	WebhookTimestampHeader = "X-Securestorage-Timestamp"
+)
+
+// WebhookNotifier POSTs each ChangeEvent as JSON to a set of URLs. Requests
+// are made in the background, in order, so writes are never held up by a
+// slow receiver.
+//
+// Each request is signed with HMAC-SHA256 over the timestamp header, a ".",
+// and the body, sent as "sha256=<hex>" in WebhookSignatureHeader. Receivers
+// should check the signature with SignWebhook and reject old timestamps.
+type WebhookNotifier struct {
+	URLs       []string
+	Secret     []byte
+	Client     *http.Client
+	MaxRetries int
+	RetryDelay time.Duration
+	// Called when an event could not be delivered to a URL.
+	OnError func(url string, event ChangeEvent, err error)
+
+	queue  chan ChangeEvent
+	done   chan struct{}
+	mu     sync.RWMutex
+	closed bool
+	now    func() time.Time
+}
+
+// Create a new WebhookNotifier queueing up to queueSize events.
+func NewWebhookNotifier(secret []byte, queueSize int, urls ...string) *WebhookNotifier {
+	wn := &WebhookNotifier{
+		URLs:       urls,
+		Secret:     secret,
+		Client:     &http.Client{Timeout: 10 * time.Second},
+		MaxRetries: 3,
+		RetryDelay: time.Second,
+		queue:      make(chan ChangeEvent, queueSize),
+		done:       make(chan struct{}),
+		now:        time.Now,
+	}
+	go wn.deliver()
+	return wn
+}
+
+// Compute the signature for a webhook request.
+func SignWebhook(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Queue an event. Events sent after Close() are dropped; Notify blocks
+// while the queue is full.
+func (wn *WebhookNotifier) Notify(event ChangeEvent) {
+	wn.mu.RLock()
+	defer wn.mu.RUnlock()
+	if !wn.closed {
+		wn.queue <- event
+	}
+}
+
+func (wn *WebhookNotifier) deliver() {
+	defer close(wn.done)
+	for event := range wn.queue {
+		body, err := json.Marshal(event)
+		if err != nil {
+			continue
+		}
+		for _, url := range wn.URLs {
+			err := wn.post(url, body)
+			for i := 0; err != nil && i < wn.MaxRetries; i++ {
+				time.Sleep(wn.RetryDelay)
+				err = wn.post(url, body)
+			}
+			if err != nil && wn.OnError != nil {
+				wn.OnError(url, event, err)
+			}
+		}
+	}
+}
+
+func (wn *WebhookNotifier) post(url string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(wn.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(wn.Secret, timestamp, body))
+	resp, err := wn.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("Webhook %s returned %s", url, resp.Status)
+	}
+	return nil
+}
+
+// Stop accepting events and wait for the queued ones to be delivered.
+func (wn *WebhookNotifier) Close() error {
+	wn.mu.Lock()
+	if wn.closed {
+		wn.mu.Unlock()
+		return nil
+	}
+	wn.closed = true
+	close(wn.queue)
+	wn.mu.Unlock()
+	<-wn.done
+	return nil
+}
@@ -0,0 +1,98 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	secret := []byte("webhook-secret")
+	var mu sync.Mutex
+	var events []ChangeEvent
+	failures := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sig := SignWebhook(secret, r.Header.Get(WebhookTimestampHeader), body)
+		if r.Header.Get(WebhookSignatureHeader) != sig {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var event ChangeEvent
+		json.Unmarshal(body, &event)
+		events = append(events, event)
+	}))
+	defer server.Close()
+
+	wn := NewWebhookNotifier(secret, 10, server.URL)
+	wn.RetryDelay = time.Millisecond
+	na := NewNotifyingAdapter(NewVersionedAdapter(newMemStore(), 0), wn)
+
+	na.Store("x0c0s1b0", creds{Password: "pw1"})
+	na.Store("x0c0s1b0", creds{Password: "pw2"})
+	na.Delete("x0c0s1b0")
+	var r creds
+	na.Lookup("x0c0s1b0", &r)
+	na.Close()
+
+	expected := []struct {
+		op      string
+		version int
+	}{
+		{op: OpStore, version: 1},
+		{op: OpStore, version: 2},
+		{op: OpDelete, version: 0},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events but got %+v", len(expected), events)
+	}
+	for i, e := range expected {
+		if events[i].Key != "x0c0s1b0" || events[i].Operation != e.op || events[i].Version != e.version {
+			t.Errorf("Test %v Failed: Expected %s version %d but got %+v", i, e.op, e.version, events[i])
+		}
+	}
+
+	// A receiver with the wrong secret rejects every request.
+	var failed []string
+	bad := NewWebhookNotifier([]byte("wrong"), 1, server.URL)
+	bad.MaxRetries = 0
+	bad.OnError = func(url string, event ChangeEvent, err error) { failed = append(failed, event.Key) }
+	bad.Notify(ChangeEvent{Key: "x0c0s2b0", Operation: OpStore})
+	bad.Close()
+	if len(failed) != 1 {
+		t.Errorf("Expected a delivery failure but got %v", failed)
+	}
+}
@@ -0,0 +1,249 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// WriteBehindAdapter acknowledges Store() and Delete() as soon as they are
+// queued and applies them to the backend from a background goroutine,
+// retrying failures. Queued writes to the same key are coalesced so only
+// the latest one is applied. Lookup() sees queued writes before they reach
+// the backend; LookupKeys() does not. Writes that are still queued when
+// the process exits are lost, so Close() should always be called to drain
+// the queue.
+//
+// If Fallback is set, writes are instead applied to the backend directly
+// while nothing is queued, and are only queued when the backend fails with
+// an error Fallback returns true for, such as DefaultIsRetryable(). Other
+// errors are returned to the caller.
+type WriteBehindAdapter struct {
+	Inner SecureStorage
+	// Called when a queued write could not be applied after all retries.
+	OnError func(key string, err error)
+	// Optional. Decides which backend errors a write is queued for.
+	Fallback func(err error) bool
+
+	maxPending int
+	maxRetries int
+	retryDelay time.Duration
+	mu         sync.Mutex
+	cond       *sync.Cond
+	pending    map[string]mirrorOp
+	order      []string
+	inflight   *mirrorOp
+	closed     bool
+	done       chan struct{}
+}
+
+// Create a new WriteBehindAdapter and start flushing. At most maxPending
+// keys are queued; further writes block until there is room. A failed write
+// is retried up to maxRetries times, retryDelay apart.
+func NewWriteBehindAdapter(inner SecureStorage, maxPending int, maxRetries int, retryDelay time.Duration) *WriteBehindAdapter {
+	wa := &WriteBehindAdapter{
+		Inner:      inner,
+		maxPending: maxPending,
+		maxRetries: maxRetries,
+		retryDelay: retryDelay,
+		pending:    map[string]mirrorOp{},
+		done:       make(chan struct{}),
+	}
+	wa.cond = sync.NewCond(&wa.mu)
+	go wa.flush()
+	return wa
+}
+
+func (wa *WriteBehindAdapter) apply(op mirrorOp) error {
+	if op.delete {
+		return wa.Inner.Delete(op.key)
+	}
+	return wa.Inner.Store(op.key, op.value)
+}
+
+// Apply queued writes in the order their keys were first queued until the
+// adapter is closed and the queue is empty.
+func (wa *WriteBehindAdapter) flush() {
+	defer close(wa.done)
+	wa.mu.Lock()
+	for {
+		for len(wa.order) == 0 && !wa.closed {
+			wa.cond.Wait()
+		}
+		if len(wa.order) == 0 {
+			wa.mu.Unlock()
+			return
+		}
+		key := wa.order[0]
+		wa.order = wa.order[1:]
+		op := wa.pending[key]
+		delete(wa.pending, key)
+		wa.inflight = &op
+		wa.cond.Broadcast()
+		wa.mu.Unlock()
+
+		err := wa.apply(op)
+		for i := 0; err != nil && i < wa.maxRetries; i++ {
+			time.Sleep(wa.retryDelay)
+			err = wa.apply(op)
+		}
+		if err != nil && wa.OnError != nil {
+			wa.OnError(op.key, err)
+		}
+
+		wa.mu.Lock()
+		wa.inflight = nil
+		wa.cond.Broadcast()
+	}
+}
+
+func (wa *WriteBehindAdapter) enqueue(op mirrorOp) error {
+	wa.mu.Lock()
+	defer wa.mu.Unlock()
+	if wa.closed {
+		return fmt.Errorf("WriteBehindAdapter is closed")
+	}
+	if _, ok := wa.pending[op.key]; !ok {
+		for wa.maxPending > 0 && len(wa.order) >= wa.maxPending && !wa.closed {
+			wa.cond.Wait()
+		}
+		if wa.closed {
+			return fmt.Errorf("WriteBehindAdapter is closed")
+		}
+		wa.order = append(wa.order, op.key)
+	}
+	wa.pending[op.key] = op
+	wa.cond.Broadcast()
+	return nil
+}
+
+// Apply op to the backend when Fallback is set and nothing is queued,
+// otherwise queue it. Once a write is queued later ones are queued behind
+// it, so they are applied in order.
+func (wa *WriteBehindAdapter) write(op mirrorOp) error {
+	if wa.Fallback != nil {
+		wa.mu.Lock()
+		queued := len(wa.order) > 0 || wa.inflight != nil
+		wa.mu.Unlock()
+		if !queued {
+			err := wa.apply(op)
+			if err == nil || !wa.Fallback(err) {
+				return err
+			}
+		}
+	}
+	return wa.enqueue(op)
+}
+
+// Queue a write of value to key.
+func (wa *WriteBehindAdapter) Store(key string, value interface{}) error {
+	data, err := toMap(value)
+	if err != nil {
+		return err
+	}
+	return wa.write(mirrorOp{key: key, value: data})
+}
+
+// StoreWithData needs the backend's response so it is not queued. Any
+// queued write to key is applied first.
+func (wa *WriteBehindAdapter) StoreWithData(key string, value interface{}, output interface{}) error {
+	err := wa.Flush()
+	if err != nil {
+		return err
+	}
+	return wa.Inner.StoreWithData(key, value, output)
+}
+
+// Read a value, preferring a queued or in progress write to key over the
+// backend.
+func (wa *WriteBehindAdapter) Lookup(key string, output interface{}) error {
+	if output == nil {
+		return fmt.Errorf("output interface was nil")
+	}
+	wa.mu.Lock()
+	op, ok := wa.pending[key]
+	if !ok && wa.inflight != nil && wa.inflight.key == key {
+		op, ok = *wa.inflight, true
+	}
+	wa.mu.Unlock()
+	if ok {
+		if op.delete {
+			return nil
+		}
+		return decodeValue(op.value, output)
+	}
+	return wa.Inner.Lookup(key, output)
+}
+
+// Queue the removal of key.
+func (wa *WriteBehindAdapter) Delete(key string) error {
+	return wa.write(mirrorOp{delete: true, key: key})
+}
+
+func (wa *WriteBehindAdapter) LookupKeys(keyPath string) ([]string, error) {
+	return wa.Inner.LookupKeys(keyPath)
+}
+
+// Get the number of keys with queued writes.
+func (wa *WriteBehindAdapter) Pending() int {
+	wa.mu.Lock()
+	defer wa.mu.Unlock()
+	return len(wa.order)
+}
+
+// Wait until every write queued so far has been applied or given up on.
+func (wa *WriteBehindAdapter) Flush() error {
+	wa.mu.Lock()
+	defer wa.mu.Unlock()
+	for len(wa.order) > 0 || wa.inflight != nil {
+		wa.cond.Wait()
+	}
+	return nil
+}
+
+// Stop accepting writes, wait for the queue to drain, then close the
+// wrapped store.
+func (wa *WriteBehindAdapter) Close() error {
+	wa.mu.Lock()
+	if wa.closed {
+		wa.mu.Unlock()
+		return nil
+	}
+	wa.closed = true
+	wa.cond.Broadcast()
+	wa.mu.Unlock()
+	<-wa.done
+	return Close(wa.Inner)
+}
+
+func (wa *WriteBehindAdapter) Health() error {
+	wa.mu.Lock()
+	closed := wa.closed
+	wa.mu.Unlock()
+	if closed {
+		return fmt.Errorf("WriteBehindAdapter is closed")
+	}
+	return Health(wa.Inner)
+}
@@ -0,0 +1,118 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+
+package securestorage
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWriteBehindAdapter(t *testing.T) {
+	value := creds{
+		Xname:    "x0c0s1b0",
+		URL:      "10.4.0.21/redfish/v1/UpdateService",
+		Username: "test1",
+		Password: "123",
+	}
+	inner := &flakyStore{memStore: newMemStore(), failures: 2}
+	wa := NewWriteBehindAdapter(inner, 8, 3, time.Millisecond)
+	errs := []string{}
+	wa.OnError = func(key string, err error) {
+		errs = append(errs, key)
+	}
+
+	for i := 0; i < 5; i++ {
+		value.Password = fmt.Sprintf("pw%d", i)
+		if err := wa.Store("x0c0s1b0", value); err != nil {
+			t.Fatalf("Unexpected error - %v", err)
+		}
+		var r creds
+		if err := wa.Lookup("x0c0s1b0", &r); err != nil || !reflect.DeepEqual(r, value) {
+			t.Errorf("Test %v Failed: Expected credentials %v but got %v (%v)", i, value, r, err)
+		}
+	}
+	wa.Store("x0c0s2b0", value)
+	wa.Delete("x0c0s2b0")
+	if err := wa.Close(); err != nil {
+		t.Errorf("Unexpected error on Close - %v", err)
+	}
+	if wa.Pending() != 0 {
+		t.Errorf("Expected an empty queue after Close but got %v", wa.Pending())
+	}
+	if len(errs) != 0 {
+		t.Errorf("Expected no failed writes but got %v", errs)
+	}
+
+	var r creds
+	inner.Lookup("x0c0s1b0", &r)
+	if !reflect.DeepEqual(r, value) {
+		t.Errorf("Expected backend credentials %v but got %v", value, r)
+	}
+	var d map[string]interface{}
+	inner.Lookup("x0c0s2b0", &d)
+	if d != nil {
+		t.Errorf("Expected x0c0s2b0 to be deleted but got %v", d)
+	}
+	if err := wa.Store("x0c0s3b0", value); err == nil {
+		t.Errorf("Expected an error writing after Close()")
+	}
+}
+
+func TestWriteBehindAdapterFallback(t *testing.T) {
+	var tests = []struct {
+		failures int
+		readOnly bool
+		respErr  bool
+		written  bool
+	}{
+		{written: true},
+		{failures: 1, written: false},
+		{readOnly: true, respErr: true},
+	}
+
+	for i, test := range tests {
+		ms := newMemStore()
+		var inner SecureStorage = &flakyStore{memStore: ms, failures: test.failures}
+		if test.readOnly {
+			inner = ReadOnly(ms)
+		}
+		wa := NewWriteBehindAdapter(inner, 8, 3, 10*time.Millisecond)
+		wa.Fallback = DefaultIsRetryable
+		err := wa.Store("x0c0s1b0", creds{Password: "pw"})
+		if (err != nil) != test.respErr {
+			t.Errorf("Test %v Failed: Unexpected error result - %v", i, err)
+		}
+		var r creds
+		ms.Lookup("x0c0s1b0", &r)
+		if (r.Password == "pw") != test.written {
+			t.Errorf("Test %v Failed: Expected written %v before Store returned but got %v", i, test.written, r)
+		}
+		wa.Close()
+		ms.Lookup("x0c0s1b0", &r)
+		if (r.Password == "pw") == test.readOnly {
+			t.Errorf("Test %v Failed: Unexpected backend value after Close - %v", i, r)
+		}
+	}
+}